@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v2"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// batchConfiguration configures many targets, which are generated together
+// from a single config file.
+type batchConfiguration struct {
+	// Defaults are the options which every target starts from.
+	Defaults yaml.MapSlice `yaml:"defaults,omitempty"`
+	// Options are named blocks of options, which targets can use.
+	Options map[string]yaml.MapSlice `yaml:"options,omitempty"`
+	// Targets are the specs to generate code from, with the options of each.
+	Targets []yaml.MapSlice `yaml:"targets"`
+}
+
+// batchTarget is a spec to generate code from, in a batchConfiguration.
+type batchTarget struct {
+	configuration `yaml:",inline"`
+
+	// Spec is the path, or URL, of the spec.
+	Spec string `yaml:"spec"`
+	// Overlay is the path of an OpenAPI Overlay to apply to the spec, which is
+	// the same as setting the `output-options.overlay.path`.
+	Overlay string `yaml:"overlay,omitempty"`
+	// Use are the names of the Options that the target uses, which are
+	// applied over the Defaults in order, before the target's own options.
+	Use []string `yaml:"use,omitempty"`
+}
+
+// String names the target in errors and warnings.
+func (t batchTarget) String() string {
+	return fmt.Sprintf("the target of %s", t.Spec)
+}
+
+// loadBatchConfiguration reads the config file, and returns whether it's a
+// batchConfiguration, which is when it has `targets`.
+func loadBatchConfiguration(configFile string) (batchConfiguration, bool, error) {
+	buf, err := os.ReadFile(configFile)
+	if err != nil {
+		return batchConfiguration{}, false, fmt.Errorf("error reading config file '%s': %w", configFile, err)
+	}
+
+	var keys map[string]interface{}
+	if err := yaml.Unmarshal(buf, &keys); err != nil {
+		// it's left to be reported as an invalid configuration
+		return batchConfiguration{}, false, nil
+	}
+	if _, ok := keys["targets"]; !ok {
+		return batchConfiguration{}, false, nil
+	}
+
+	var batch batchConfiguration
+	if err := yaml.UnmarshalStrict(buf, &batch); err != nil {
+		return batchConfiguration{}, false, fmt.Errorf("error parsing '%s' as a configuration of targets: %w", configFile, err)
+	}
+	return batch, true, nil
+}
+
+// targets returns each of the targets, with the options that they use applied
+// over the defaults, and its own options applied over them.
+func (b batchConfiguration) targets() ([]batchTarget, error) {
+	if len(b.Targets) == 0 {
+		return nil, errors.New("the configuration has no targets")
+	}
+
+	var targets []batchTarget
+	outputs := map[string]int{}
+	for i, raw := range b.Targets {
+		// the target's own options are decoded on their own first, to find
+		// the options that it uses
+		var target batchTarget
+		if err := unmarshalOptions(raw, &target); err != nil {
+			return nil, fmt.Errorf("targets[%d]: %w", i, err)
+		}
+
+		layers := []yaml.MapSlice{b.Defaults}
+		for _, name := range target.Use {
+			options, ok := b.Options[name]
+			if !ok {
+				return nil, fmt.Errorf("targets[%d] uses the options %q, which aren't defined in the `options`", i, name)
+			}
+			layers = append(layers, options)
+		}
+
+		// each layer is decoded over the options of those before it, so that
+		// it only overrides the options that it sets
+		target = batchTarget{}
+		for _, layer := range layers {
+			if err := unmarshalOptions(layer, &target.configuration); err != nil {
+				return nil, fmt.Errorf("targets[%d]: %w", i, err)
+			}
+		}
+		if err := unmarshalOptions(raw, &target); err != nil {
+			return nil, fmt.Errorf("targets[%d]: %w", i, err)
+		}
+
+		if target.Spec == "" {
+			return nil, fmt.Errorf("targets[%d] has no `spec`", i)
+		}
+		if target.OutputFile == "" {
+			return nil, fmt.Errorf("targets[%d] has no `output`, which is required as the targets can't be written to stdout", i)
+		}
+		if j, ok := outputs[target.OutputFile]; ok {
+			return nil, fmt.Errorf("targets[%d] and targets[%d] are both output to %s", j, i, target.OutputFile)
+		}
+		outputs[target.OutputFile] = i
+
+		if target.Overlay != "" {
+			target.OutputOptions.Overlay.Path = target.Overlay
+		}
+
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// unmarshalOptions decodes a block of options over the options in out.
+func unmarshalOptions(options yaml.MapSlice, out interface{}) error {
+	if len(options) == 0 {
+		return nil
+	}
+	buf, err := yaml.Marshal(options)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(buf, out)
+}
+
+// generateBatch generates each of the targets concurrently, as many at a time
+// as GOMAXPROCS, and writes the code of those which were generated, or, when
+// checking, writes a diff of the targets whose code is out of date to out. The
+// files that more than one of the specs refer to are only read once. It
+// returns the diagnostics of all of the targets, in the order of the targets.
+func generateBatch(batch batchConfiguration, check bool, out io.Writer) ([]specDiagnostic, error) {
+	targets, err := batch.targets()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range targets {
+		targets[i].Configuration = targets[i].UpdateDefaults()
+
+		if err := detectPackageName(&targets[i].configuration, targets[i].Spec); err != nil {
+			return nil, fmt.Errorf("%s: %w", targets[i], err)
+		}
+
+		if err := targets[i].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: configuration error: %w", targets[i], err)
+		}
+
+		warnGenerateOptions(targets[i].Generate, targets[i].String())
+	}
+
+	readFromURI := util.NewSharedReadFromURI()
+	diffs := make([]string, len(targets))
+	diagnostics := make([][]codegen.Diagnostic, len(targets))
+	errs := make([]error, len(targets))
+	limit := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			diffs[i], diagnostics[i], errs[i] = generateBatchTarget(targets[i], readFromURI, check)
+		}(i)
+	}
+	wg.Wait()
+
+	// the diffs are printed in the order of the targets, rather than the
+	// order that they were generated in
+	var stale int
+	for _, diff := range diffs {
+		if diff != "" {
+			if _, err := io.WriteString(out, diff); err != nil {
+				return nil, err
+			}
+			stale++
+		}
+	}
+	if stale > 0 {
+		errs = append(errs, fmt.Errorf("the generated code of %d of the %d targets is out of date, as it differs from the files above", stale, len(targets)))
+	}
+
+	var specDiagnostics []specDiagnostic
+	for i, target := range targets {
+		specDiagnostics = append(specDiagnostics, withSpec(target.Spec, diagnostics[i])...)
+	}
+
+	return specDiagnostics, errors.Join(errs...)
+}
+
+// generateBatchTarget generates the code of a target, and writes it, or, when
+// checking, returns the diff of the code which is out of date, along with the
+// diagnostics of the generation.
+func generateBatchTarget(target batchTarget, readFromURI openapi3.ReadFromURIFunc, check bool) (string, []codegen.Diagnostic, error) {
+	files, diagnostics, err := generateTarget(target.Spec, target.configuration, readFromURI)
+	if err != nil {
+		return "", diagnostics, fmt.Errorf("%s: %w", target, err)
+	}
+
+	if check {
+		diff, err := diffGeneratedFiles(files, target.OutputFile)
+		if err != nil {
+			return "", diagnostics, fmt.Errorf("%s: %w", target, err)
+		}
+		return diff, diagnostics, nil
+	}
+
+	if err := writeGeneratedFiles(files, target.OutputFile); err != nil {
+		return "", diagnostics, fmt.Errorf("%s: %w", target, err)
+	}
+	return "", diagnostics, nil
+}
+
+// batchFlags are the flags which can be used with a batchConfiguration, as
+// the others configure a single target.
+var batchFlags = map[string]bool{
+	"config":             true,
+	"check":              true,
+	"diagnostics":        true,
+	"diagnostics-output": true,
+}
+
+// checkBatchFlags returns an error when any of the flags which configure a
+// single target have been set.
+func checkBatchFlags() error {
+	var errs []error
+	flag.Visit(func(f *flag.Flag) {
+		if !batchFlags[f.Name] {
+			errs = append(errs, fmt.Errorf("the -%s flag can't be used with a configuration of targets, which configures each target", f.Name))
+		}
+	})
+	if flag.NArg() > 0 {
+		errs = append(errs, errors.New("the spec can't be an argument with a configuration of targets, which configures the `spec` of each target"))
+	}
+	return errors.Join(errs...)
+}
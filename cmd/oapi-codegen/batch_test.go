@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+func TestBatchConfigurationTargets(t *testing.T) {
+	var batch batchConfiguration
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+defaults:
+  generate:
+    models: true
+  output-options:
+    skip-prune: true
+options:
+  client:
+    generate:
+      client: true
+    output-options:
+      client-type-name: PetsClient
+  server:
+    generate:
+      std-http-server: true
+      strict-server: true
+targets:
+  - spec: pets.yaml
+    package: pets
+    output: pets/pets.gen.go
+    use: [client, server]
+    overlay: pets-overlay.yaml
+    output-options:
+      client-type-name: Client
+  - spec: stores.yaml
+    package: stores
+    output: stores/stores.gen.go
+    generate:
+      models: false
+      client: true
+`), &batch))
+
+	targets, err := batch.targets()
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+
+	pets := targets[0]
+	assert.Equal(t, "pets.yaml", pets.Spec)
+	assert.Equal(t, "pets", pets.PackageName)
+	assert.Equal(t, "pets/pets.gen.go", pets.OutputFile)
+	assert.True(t, pets.Generate.Models)
+	assert.True(t, pets.Generate.Client)
+	assert.True(t, pets.Generate.StdHTTPServer)
+	assert.True(t, pets.Generate.Strict)
+	assert.True(t, pets.OutputOptions.SkipPrune)
+	// the target's own options override those it uses
+	assert.Equal(t, "Client", pets.OutputOptions.ClientTypeName)
+	assert.Equal(t, "pets-overlay.yaml", pets.OutputOptions.Overlay.Path)
+
+	stores := targets[1]
+	assert.False(t, stores.Generate.Models)
+	assert.True(t, stores.Generate.Client)
+	assert.False(t, stores.Generate.StdHTTPServer)
+	assert.True(t, stores.OutputOptions.SkipPrune)
+	assert.Empty(t, stores.OutputOptions.ClientTypeName)
+}
+
+func TestBatchConfigurationTargetsAreValidated(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		err    string
+	}{
+		"unknown options": {
+			config: `
+targets:
+  - spec: pets.yaml
+    output: pets.gen.go
+    use: [client]
+`,
+			err: `targets[0] uses the options "client", which aren't defined in the ` + "`options`",
+		},
+		"no spec": {
+			config: `
+targets:
+  - output: pets.gen.go
+`,
+			err: "targets[0] has no `spec`",
+		},
+		"no output": {
+			config: `
+targets:
+  - spec: pets.yaml
+`,
+			err: "targets[0] has no `output`",
+		},
+		"the same output": {
+			config: `
+targets:
+  - spec: pets.yaml
+    output: api.gen.go
+  - spec: stores.yaml
+    output: api.gen.go
+`,
+			err: "targets[0] and targets[1] are both output to api.gen.go",
+		},
+		"unknown option": {
+			config: `
+options:
+  client:
+    generate:
+      clients: true
+targets:
+  - spec: pets.yaml
+    output: pets.gen.go
+    use: [client]
+`,
+			err: "field clients not found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var batch batchConfiguration
+			require.NoError(t, yaml.UnmarshalStrict([]byte(tc.config), &batch))
+
+			_, err := batch.targets()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestGenerateBatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	writeFile("common.yaml", `
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`)
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: API
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: './common.yaml#/components/schemas/Pet'
+            text/csv:
+              schema:
+                type: array
+                items:
+                  $ref: './common.yaml#/components/schemas/Pet'
+`
+	configFile := writeFile("batch.yaml", strings.NewReplacer("$DIR", dir).Replace(`
+defaults:
+  generate:
+    models: true
+    client: true
+  import-mapping:
+    ./common.yaml: "-"
+targets:
+  - spec: $DIR/pets.yaml
+    package: pets
+    output: $DIR/pets/pets.gen.go
+  - spec: $DIR/stores.yaml
+    package: stores
+    output: $DIR/stores/stores.gen.go
+    output-options:
+      client-type-name: StoresClient
+`))
+	writeFile("pets.yaml", spec)
+	writeFile("stores.yaml", spec)
+
+	batch, ok, err := loadBatchConfiguration(configFile)
+	require.NoError(t, err)
+	require.True(t, ok)
+	diagnostics, err := generateBatch(batch, false, io.Discard)
+	require.NoError(t, err)
+	// the diagnostics are in the order of the targets
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, filepath.Join(dir, "pets.yaml"), diagnostics[0].Spec)
+	assert.Equal(t, filepath.Join(dir, "stores.yaml"), diagnostics[1].Spec)
+	assert.Equal(t, codegen.DiagnosticUnsupportedContentType, diagnostics[1].Rule)
+	assert.Equal(t, "#/paths/~1pets/get/responses/200/content/text~1csv", diagnostics[1].Pointer)
+
+	pets, err := os.ReadFile(filepath.Join(dir, "pets", "pets.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pets), "package pets")
+	assert.Contains(t, string(pets), "type Client struct {")
+	assert.Contains(t, string(pets), "JSON200      *[]Pet")
+
+	stores, err := os.ReadFile(filepath.Join(dir, "stores", "stores.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(stores), "package stores")
+	assert.Contains(t, string(stores), "type StoresClient struct {")
+
+	// the code that was written is up to date
+	var out bytes.Buffer
+	_, err = generateBatch(batch, true, &out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stores", "stores.gen.go"), []byte("package stores\n"), 0o644))
+	_, err = generateBatch(batch, true, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the generated code of 1 of the 2 targets is out of date")
+	assert.Contains(t, out.String(), "stores.gen.go")
+	assert.NotContains(t, out.String(), "pets.gen.go")
+
+	// checking doesn't write the code
+	stores, err = os.ReadFile(filepath.Join(dir, "stores", "stores.gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package stores\n", string(stores))
+}
+
+func TestLoadBatchConfigurationOfASingleTarget(t *testing.T) {
+	_, ok, err := loadBatchConfiguration("../../examples/xml-bodies/cfg.yaml")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
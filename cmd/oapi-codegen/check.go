@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// diffGeneratedFiles compares the code of each of the files with the file that
+// it would be written to, and returns a unified diff of those which differ,
+// which is empty when all of them are up to date. A file which doesn't exist
+// yet differs from its code in its entirety.
+func diffGeneratedFiles(files []codegen.GeneratedFile, outputFile string) (string, error) {
+	var diff strings.Builder
+	for _, file := range files {
+		filename := file.Filename
+		if filename == "" {
+			filename = outputFile
+		}
+		if filename == "" {
+			return "", errors.New("the generated code can only be checked when it's written to an `output`, rather than stdout")
+		}
+
+		existing, err := os.ReadFile(filename)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("error reading the existing code of %s: %w", filename, err)
+		}
+		if string(existing) == file.Code {
+			continue
+		}
+
+		name := filepath.ToSlash(filename)
+		fileDiff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(string(existing)),
+			B:        splitLines(file.Code),
+			FromFile: name,
+			ToFile:   name + " (generated)",
+			Context:  3,
+		})
+		if err != nil {
+			return "", fmt.Errorf("error comparing the generated code of %s: %w", filename, err)
+		}
+		diff.WriteString(fileDiff)
+	}
+	return diff.String(), nil
+}
+
+// splitLines splits the code into lines, which each keep their newline.
+func splitLines(code string) []string {
+	if code == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(code, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+func TestDiffGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "api.gen.go")
+	modelsFile := filepath.Join(dir, "models", "models.gen.go")
+	require.NoError(t, os.WriteFile(outputFile, []byte("package api\n\nfunc A() {}\n\nfunc B() {}\n"), 0o644))
+
+	files := []codegen.GeneratedFile{
+		{PackageName: "api", Code: "package api\n\nfunc A() {}\n\nfunc B() {}\n"},
+	}
+	diff, err := diffGeneratedFiles(files, outputFile)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	files[0].Code = "package api\n\nfunc A() {}\n\nfunc C() {}\n"
+	diff, err = diffGeneratedFiles(files, outputFile)
+	require.NoError(t, err)
+	name := filepath.ToSlash(outputFile)
+	assert.Equal(t, "--- "+name+"\n+++ "+name+" (generated)\n@@ -2,4 +2,4 @@\n \n func A() {}\n \n-func B() {}\n+func C() {}\n", diff)
+
+	// a file of the layout which hasn't been written yet is all new
+	files = append(files, codegen.GeneratedFile{Filename: modelsFile, PackageName: "models", Code: "package models\n"})
+	diff, err = diffGeneratedFiles(files, outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+++ "+filepath.ToSlash(modelsFile)+" (generated)\n@@ -0,0 +1 @@\n+package models\n")
+
+	// nothing was written
+	_, err = os.Stat(modelsFile)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDiffGeneratedFilesRequiresAnOutput(t *testing.T) {
+	_, err := diffGeneratedFiles([]codegen.GeneratedFile{{PackageName: "api", Code: "package api\n"}}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// diagnosticsFormats are the formats which the -diagnostics can be printed in.
+var diagnosticsFormats = []string{"text", "json", "sarif"}
+
+// specDiagnostic is a codegen.Diagnostic of the spec it was found in.
+type specDiagnostic struct {
+	Spec string `json:"spec"`
+	codegen.Diagnostic
+}
+
+// withSpec returns the diagnostics of the spec.
+func withSpec(spec string, diagnostics []codegen.Diagnostic) []specDiagnostic {
+	out := make([]specDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		out = append(out, specDiagnostic{Spec: spec, Diagnostic: diagnostic})
+	}
+	return out
+}
+
+// checkDiagnosticsFormat returns an error when the format of the -diagnostics
+// isn't one of the diagnosticsFormats.
+func checkDiagnosticsFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, f := range diagnosticsFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("the -diagnostics must be one of %s, rather than %q", strings.Join(diagnosticsFormats, ", "), format)
+}
+
+// printDiagnostics prints the diagnostics in the format, to the file, or to
+// stderr when it's empty. They're only printed when there's a format, so that
+// the output of oapi-codegen is unchanged unless they're asked for.
+func printDiagnostics(diagnostics []specDiagnostic, format string, filename string) error {
+	if format == "" {
+		return nil
+	}
+
+	var w io.Writer = os.Stderr
+	if filename != "" {
+		f, err := os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("error creating the diagnostics file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeDiagnostics(w, diagnostics, format); err != nil {
+		return fmt.Errorf("error writing the diagnostics: %w", err)
+	}
+	return nil
+}
+
+// writeDiagnostics writes the diagnostics in the format, which is one of the
+// diagnosticsFormats.
+func writeDiagnostics(w io.Writer, diagnostics []specDiagnostic, format string) error {
+	switch format {
+	case "text":
+		for _, diagnostic := range diagnostics {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", diagnostic.Spec, diagnostic.Diagnostic); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		if diagnostics == nil {
+			diagnostics = []specDiagnostic{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diagnostics)
+	case "sarif":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newSARIFLog(diagnostics))
+	default:
+		return checkDiagnosticsFormat(format)
+	}
+}
+
+// diagnosticRules describe each of the rules of the codegen.Diagnostics.
+var diagnosticRules = map[string]string{
+	codegen.DiagnosticUntypedSchema:                 "A schema without a type is generated as interface{}.",
+	codegen.DiagnosticArrayWithoutItems:             "An array without items has items which are generated as interface{}.",
+	codegen.DiagnosticDiscriminatorMappingUnmatched: "A discriminator mapping to a schema which isn't one of its union's is ignored.",
+	codegen.DiagnosticDiscriminatorPropertyMissing:  "A schema of a union with a discriminator doesn't have the discriminator's property.",
+	codegen.DiagnosticUnsupportedContentType:        "The schema of a body whose media type isn't supported is skipped, and the body is read and written as bytes.",
+	codegen.DiagnosticTypedClientSkipped:            "An operation whose successful responses have bodies of different types has no typed client methods.",
+}
+
+// The parts of a SARIF 2.1.0 log which the diagnostics are written as, so that
+// they can be uploaded to code scanning tools.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifLogicalLocation struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+)
+
+// newSARIFLog returns a SARIF log of a single run of oapi-codegen, whose
+// results are the diagnostics, each located in its spec, and at its pointer.
+func newSARIFLog(diagnostics []specDiagnostic) sarifLog {
+	driver := sarifDriver{
+		Name:           "oapi-codegen",
+		InformationURI: "https://github.com/oapi-codegen/oapi-codegen",
+	}
+	for _, rule := range codegen.SortedMapKeys(diagnosticRules) {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               rule,
+			ShortDescription: sarifMessage{Text: diagnosticRules[rule]},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(diagnostic.Spec)},
+			},
+		}
+		if diagnostic.Pointer != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: diagnostic.Pointer}}
+		}
+		results = append(results, sarifResult{
+			RuleID:    diagnostic.Rule,
+			Level:     "warning",
+			Message:   sarifMessage{Text: diagnostic.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	}
+}
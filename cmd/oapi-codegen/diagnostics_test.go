@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+var testDiagnostics = []specDiagnostic{
+	{
+		Spec: "api/pets.yaml",
+		Diagnostic: codegen.Diagnostic{
+			Rule:    codegen.DiagnosticUntypedSchema,
+			Message: "the schema has no `type`, so it's generated as `interface{}`",
+			Pointer: "#/components/schemas/Pet/properties/extra",
+		},
+	},
+	{
+		Spec: "api/stores.yaml",
+		Diagnostic: codegen.Diagnostic{
+			Rule:    codegen.DiagnosticUnsupportedContentType,
+			Message: "the schema of the text/csv response body is skipped, as its media type isn't supported, so the body is read and written as bytes",
+		},
+	},
+}
+
+func TestWriteDiagnosticsAsText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeDiagnostics(&buf, testDiagnostics, "text"))
+	assert.Equal(t, "api/pets.yaml: #/components/schemas/Pet/properties/extra: the schema has no `type`, so it's generated as `interface{}` (untyped-schema)\n"+
+		"api/stores.yaml: the schema of the text/csv response body is skipped, as its media type isn't supported, so the body is read and written as bytes (unsupported-content-type)\n", buf.String())
+}
+
+func TestWriteDiagnosticsAsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeDiagnostics(&buf, testDiagnostics, "json"))
+	assert.JSONEq(t, `[
+		{
+			"spec": "api/pets.yaml",
+			"rule": "untyped-schema",
+			"message": "the schema has no `+"`type`, so it's generated as `interface{}`"+`",
+			"pointer": "#/components/schemas/Pet/properties/extra"
+		},
+		{
+			"spec": "api/stores.yaml",
+			"rule": "unsupported-content-type",
+			"message": "the schema of the text/csv response body is skipped, as its media type isn't supported, so the body is read and written as bytes"
+		}
+	]`, buf.String())
+
+	// no diagnostics are an empty list, rather than null
+	buf.Reset()
+	require.NoError(t, writeDiagnostics(&buf, nil, "json"))
+	assert.JSONEq(t, `[]`, buf.String())
+}
+
+func TestWriteDiagnosticsAsSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeDiagnostics(&buf, testDiagnostics, "sarif"))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	driver := log.Runs[0].Tool.Driver
+	assert.Equal(t, "oapi-codegen", driver.Name)
+	// every rule is described
+	assert.Len(t, driver.Rules, len(diagnosticRules))
+	for _, rule := range driver.Rules {
+		assert.NotEmpty(t, rule.ShortDescription.Text, rule.ID)
+	}
+
+	assert.Equal(t, []sarifResult{
+		{
+			RuleID:  codegen.DiagnosticUntypedSchema,
+			Level:   "warning",
+			Message: sarifMessage{Text: "the schema has no `type`, so it's generated as `interface{}`"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "api/pets.yaml"}},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "#/components/schemas/Pet/properties/extra"}},
+			}},
+		},
+		{
+			RuleID:  codegen.DiagnosticUnsupportedContentType,
+			Level:   "warning",
+			Message: sarifMessage{Text: "the schema of the text/csv response body is skipped, as its media type isn't supported, so the body is read and written as bytes"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "api/stores.yaml"}},
+			}},
+		},
+	}, log.Runs[0].Results)
+}
+
+func TestCheckDiagnosticsFormat(t *testing.T) {
+	assert.NoError(t, checkDiagnosticsFormat(""))
+	assert.NoError(t, checkDiagnosticsFormat("sarif"))
+	assert.EqualError(t, checkDiagnosticsFormat("xml"), `the -diagnostics must be one of text, json, sarif, rather than "xml"`)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        tag:
+          type: string
+`
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	oldSpec := writeFile("old.yaml", diffSpec)
+	newSpec := writeFile("new.yaml", strings.Replace(diffSpec, "required: [id]", "required: [id, tag]", 1))
+	configFile := writeFile("cfg.yaml", `
+package: pets
+generate:
+  models: true
+  client: true
+`)
+
+	// the flags can come after the specs
+	var stdout bytes.Buffer
+	err := runDiff([]string{oldSpec, newSpec, "--config", configFile}, &stdout)
+	require.Error(t, err)
+	assert.Equal(t, "the generated code of "+newSpec+" has 1 breaking change(s) from that of "+oldSpec, err.Error())
+	assert.Equal(t, "Pet.Tag: the field's type changed from *string to string, losing its pointer, such as when it becomes required\n", stdout.String())
+
+	// a new field is compatible
+	stdout.Reset()
+	compatibleSpec := writeFile("compatible.yaml", strings.Replace(diffSpec, "        tag:\n", "        name:\n          type: string\n        tag:\n", 1))
+	require.NoError(t, runDiff([]string{"-config", configFile, oldSpec, compatibleSpec}, &stdout))
+	assert.Empty(t, stdout.String())
+
+	// without the models, the types aren't compared
+	stdout.Reset()
+	clientConfigFile := writeFile("client.yaml", `
+generate:
+  client: true
+`)
+	require.NoError(t, runDiff([]string{"-config", clientConfigFile, oldSpec, newSpec}, &stdout))
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunDiffRequiresTwoSpecs(t *testing.T) {
+	err := runDiff([]string{"old.yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "please specify the paths to the old and the new OpenAPI 3.0 spec files")
+}
+
+func TestRunDiffRejectsAConfigurationOfTargets(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cfg.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("targets:\n  - spec: api.yaml\n    output: api.gen.go\n"), 0o644))
+
+	err := runDiff([]string{"-config", configFile, "old.yaml", "new.yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "the specs can only be compared with the configuration of a single target")
+}
+
+func TestLoadDiffConfiguration(t *testing.T) {
+	// without a config file, the defaults of generating are compared
+	opts, err := loadDiffConfiguration("")
+	require.NoError(t, err)
+	defaults, err := loadConfiguration("")
+	require.NoError(t, err)
+	assert.Equal(t, defaults.Generate, opts.Generate)
+
+	// a config file is loaded as it is when generating
+	configFile := filepath.Join(t.TempDir(), "cfg.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("package: pets\noutput: pets.gen.go\ngenerate:\n  models: true\n"), 0o644))
+	opts, err = loadDiffConfiguration(configFile)
+	require.NoError(t, err)
+	generating, err := loadConfiguration(configFile)
+	require.NoError(t, err)
+	generating.Configuration = generating.UpdateDefaults()
+	assert.Equal(t, generating, opts)
+}
@@ -23,6 +23,7 @@ import (
 	"runtime/debug"
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"gopkg.in/yaml.v2"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
@@ -47,6 +48,10 @@ var (
 	flagPrintUsage     bool
 	flagGenerate       string
 	flagTemplatesDir   string
+	flagCheck          bool
+
+	flagDiagnostics       string
+	flagDiagnosticsOutput string
 
 	// Deprecated: The options below will be removed in a future
 	// release. Please use the new config file format.
@@ -89,7 +94,34 @@ type oldConfiguration struct {
 // See documentation for how to use it in examples/no-vcs-version-override/README.md
 var noVCSVersionOverride string
 
+// usage prints how to generate the code of a spec, or of the config file's
+// targets, with the flags, and how to find the breaking changes between two
+// specs with `oapi-codegen diff`.
+func usage() {
+	_, _ = fmt.Fprintf(flag.CommandLine.Output(), `Usage: oapi-codegen [flags] spec.yaml
+       oapi-codegen [flags] -config targets.yaml
+       oapi-codegen diff [-config cfg.yaml] old.yaml new.yaml
+
+Generates the Go code of an OpenAPI spec, or, with the diff command, prints the
+changes to the generated code from the old spec to the new one which break the
+code that uses it. Run "oapi-codegen diff -help" for its flags.
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
 func main() {
+	// `oapi-codegen diff` compares the Go API of the code of two specs, rather
+	// than generating it.
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:], os.Stdout); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+
+	flag.Usage = usage
 	flag.StringVar(&flagOutputFile, "o", "", "Where to output generated code, stdout is default.")
 	flag.BoolVar(&flagOldConfigStyle, "old-config-style", false, "Whether to use the older style config file format.")
 	flag.BoolVar(&flagOutputConfig, "output-config", false, "When true, outputs a configuration file for oapi-codegen using current settings.")
@@ -98,6 +130,10 @@ func main() {
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 	flag.BoolVar(&flagPrintUsage, "h", false, "Same as -help.")
+	flag.BoolVar(&flagCheck, "check", false, "Check that the generated code is the same as the files it would be written to, printing a diff of those which differ, and exiting with an error when any do, rather than writing them.")
+
+	flag.StringVar(&flagDiagnostics, "diagnostics", "", "Print the diagnostics of the spec, which are warnings about what couldn't be generated as intended, in a format of text, json or sarif.")
+	flag.StringVar(&flagDiagnosticsOutput, "diagnostics-output", "", "Where to print the diagnostics, stderr is default.")
 
 	// All flags below are deprecated, and will be removed in a future release. Please do not
 	// update their behavior.
@@ -136,6 +172,32 @@ func main() {
 		return
 	}
 
+	if err := checkDiagnosticsFormat(flagDiagnostics); err != nil {
+		errExit("%s\n", err)
+	}
+
+	// A config file with `targets` generates each of them, rather than the
+	// spec that's given as an argument.
+	if flagConfigFile != "" {
+		batch, ok, err := loadBatchConfiguration(flagConfigFile)
+		if err != nil {
+			errExit("%s\n", err)
+		}
+		if ok {
+			if err := checkBatchFlags(); err != nil {
+				errExit("%s\n", err)
+			}
+			diagnostics, err := generateBatch(batch, flagCheck, os.Stdout)
+			if err := printDiagnostics(diagnostics, flagDiagnostics, flagDiagnosticsOutput); err != nil {
+				errExit("%s\n", err)
+			}
+			if err != nil {
+				errExit("%s\n", err)
+			}
+			return
+		}
+	}
+
 	if flag.NArg() < 1 {
 		errExit("Please specify a path to a OpenAPI 3.0 spec file\n")
 	} else if flag.NArg() > 1 {
@@ -208,30 +270,10 @@ func main() {
 	var opts configuration
 	if !*oldConfigStyle {
 		// We simply read the configuration from disk.
-		if flagConfigFile != "" {
-			buf, err := os.ReadFile(flagConfigFile)
-			if err != nil {
-				errExit("error reading config file '%s': %v\n", flagConfigFile, err)
-			}
-			err = yaml.Unmarshal(buf, &opts)
-			if err != nil {
-				errExit("error parsing'%s' as YAML: %v\n", flagConfigFile, err)
-			}
-		} else {
-			// In the case where no config file is provided, we assume some
-			// defaults, so that when this is invoked very simply, it's similar
-			// to old behavior.
-			opts = configuration{
-				Configuration: codegen.Configuration{
-					Generate: codegen.GenerateOptions{
-						EchoServer:   true,
-						Client:       true,
-						Models:       true,
-						EmbeddedSpec: true,
-					},
-				},
-				OutputFile: flagOutputFile,
-			}
+		var err error
+		opts, err = loadConfiguration(flagConfigFile)
+		if err != nil {
+			errExit("%s\n", err)
 		}
 
 		if err := updateConfigFromFlags(&opts); err != nil {
@@ -262,7 +304,7 @@ func main() {
 	// fields.
 	opts.Configuration = opts.UpdateDefaults()
 
-	if err := detectPackageName(&opts); err != nil {
+	if err := detectPackageName(&opts, flag.Arg(0)); err != nil {
 		errExit("%s\n", err)
 	}
 
@@ -271,14 +313,7 @@ func main() {
 		errExit("configuration error: %v\n", err)
 	}
 
-	if warnings := opts.Generate.Warnings(); len(warnings) > 0 {
-		out := "WARNING: A number of warning(s) were returned when validating the GenerateOptions:"
-		for k, v := range warnings {
-			out += "\n- " + k + ": " + v
-		}
-
-		_, _ = fmt.Fprint(os.Stderr, out)
-	}
+	warnGenerateOptions(opts.Generate, "")
 
 	// If the user asked to output configuration, output it to stdout and exit
 	if flagOutputConfig {
@@ -290,45 +325,153 @@ func main() {
 		return
 	}
 
+	files, diagnostics, err := generateTarget(flag.Arg(0), opts, nil)
+	if err := printDiagnostics(withSpec(flag.Arg(0), diagnostics), flagDiagnostics, flagDiagnosticsOutput); err != nil {
+		errExit("%s\n", err)
+	}
+	if err != nil {
+		errExit("%s\n", err)
+	}
+
+	if flagCheck {
+		diff, err := diffGeneratedFiles(files, opts.OutputFile)
+		if err != nil {
+			errExit("%s\n", err)
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			errExit("the generated code is out of date, as it differs from the files above\n")
+		}
+		return
+	}
+
+	if err := writeGeneratedFiles(files, opts.OutputFile); err != nil {
+		errExit("%s\n", err)
+	}
+}
+
+// loadConfiguration loads the configuration from the config file, in the new
+// style. In the case where no config file is provided, we assume some
+// defaults, so that when this is invoked very simply, it's similar to old
+// behavior.
+func loadConfiguration(configFile string) (configuration, error) {
+	if configFile == "" {
+		return configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{
+					EchoServer:   true,
+					Client:       true,
+					Models:       true,
+					EmbeddedSpec: true,
+				},
+			},
+		}, nil
+	}
+
+	buf, err := os.ReadFile(configFile)
+	if err != nil {
+		return configuration{}, fmt.Errorf("error reading config file '%s': %w", configFile, err)
+	}
+	var opts configuration
+	if err := yaml.Unmarshal(buf, &opts); err != nil {
+		return configuration{}, fmt.Errorf("error parsing'%s' as YAML: %w", configFile, err)
+	}
+	return opts, nil
+}
+
+// warnGenerateOptions prints any warnings about the GenerateOptions, of the
+// target when it's named.
+func warnGenerateOptions(opts codegen.GenerateOptions, target string) {
+	warnings := opts.Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+
+	out := "WARNING: A number of warning(s) were returned when validating the GenerateOptions"
+	if target != "" {
+		out += " of " + target
+	}
+	out += ":"
+	for k, v := range warnings {
+		out += "\n- " + k + ": " + v
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, out)
+}
+
+// generateTarget loads the spec, whose files and URLs are read by
+// readFromURI, or the loader's default when it's nil, and generates its code,
+// along with the diagnostics of the generation.
+func generateTarget(specPath string, opts configuration, readFromURI openapi3.ReadFromURIFunc) ([]codegen.GeneratedFile, []codegen.Diagnostic, error) {
+	swagger, err := loadSpec(specPath, opts, readFromURI)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(noVCSVersionOverride) > 0 {
+		opts.NoVCSVersionOverride = &noVCSVersionOverride
+	}
+
+	g, err := codegen.NewGenerator(opts.Configuration)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating code: %s", err)
+	}
+	files, diagnostics, err := g.GenerateFilesWithDiagnostics(swagger)
+	if err != nil {
+		return nil, diagnostics, fmt.Errorf("error generating code: %s", err)
+	}
+	return files, diagnostics, nil
+}
+
+// loadSpec loads the spec, with the overlay of the configuration, whose files
+// and URLs are read by readFromURI, or the loader's default when it's nil.
+func loadSpec(specPath string, opts configuration, readFromURI openapi3.ReadFromURIFunc) (*openapi3.T, error) {
 	overlayOpts := util.LoadSwaggerWithOverlayOpts{
 		Path: opts.OutputOptions.Overlay.Path,
 		// default to strict, but can be overridden
-		Strict: true,
+		Strict:          true,
+		ReadFromURIFunc: readFromURI,
 	}
 
 	if opts.OutputOptions.Overlay.Strict != nil {
 		overlayOpts.Strict = *opts.OutputOptions.Overlay.Strict
 	}
 
-	swagger, err := util.LoadSwaggerWithOverlay(flag.Arg(0), overlayOpts)
+	swagger, err := util.LoadSwaggerWithOverlay(specPath, overlayOpts)
 	if err != nil {
-		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
+		return nil, fmt.Errorf("error loading swagger spec in %s\n: %s", specPath, err)
 	}
 
 	if strings.HasPrefix(swagger.OpenAPI, "3.1.") {
-		fmt.Fprintln(os.Stderr, "WARNING: You are using an OpenAPI 3.1.x specification, which is not yet supported by oapi-codegen (https://github.com/oapi-codegen/oapi-codegen/issues/373) and so some functionality may not be available. Until oapi-codegen supports OpenAPI 3.1, it is recommended to downgrade your spec to 3.0.x")
+		fmt.Fprintf(os.Stderr, "WARNING: %s is an OpenAPI 3.1.x specification, which is not yet supported by oapi-codegen (https://github.com/oapi-codegen/oapi-codegen/issues/373) and so some functionality may not be available. Until oapi-codegen supports OpenAPI 3.1, it is recommended to downgrade your spec to 3.0.x\n", specPath)
 	}
 
-	if len(noVCSVersionOverride) > 0 {
-		opts.NoVCSVersionOverride = &noVCSVersionOverride
-	}
+	return swagger, nil
+}
 
-	code, err := codegen.Generate(swagger, opts.Configuration)
-	if err != nil {
-		errExit("error generating code: %s\n", err)
-	}
+// writeGeneratedFiles writes the main output to the outputFile, or to stdout
+// when it's empty, and the other files to the filenames configured through
+// the `output-options.layout`.
+func writeGeneratedFiles(files []codegen.GeneratedFile, outputFile string) error {
+	for _, file := range files {
+		filename := file.Filename
+		if filename == "" {
+			filename = outputFile
+		}
 
-	if opts.OutputFile != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.OutputFile), 0o755); err != nil {
-			errExit("error unable to create directory: %s\n", err)
+		if filename == "" {
+			fmt.Print(file.Code)
+			continue
 		}
-		err = os.WriteFile(opts.OutputFile, []byte(code), 0o644)
-		if err != nil {
-			errExit("error writing generated code to file: %s\n", err)
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			return fmt.Errorf("error unable to create directory: %s", err)
+		}
+		if err := os.WriteFile(filename, []byte(file.Code), 0o644); err != nil {
+			return fmt.Errorf("error writing generated code to file: %s", err)
 		}
-	} else {
-		fmt.Print(code)
 	}
+	return nil
 }
 
 func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
@@ -368,7 +511,7 @@ func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
 }
 
 // detectPackageName detects and sets PackageName if not already set.
-func detectPackageName(cfg *configuration) error {
+func detectPackageName(cfg *configuration, specPath string) error {
 	if cfg.PackageName != "" {
 		return nil
 	}
@@ -397,7 +540,7 @@ func detectPackageName(cfg *configuration) error {
 	}
 
 	// Fallback to determining from the spec file name.
-	parts := strings.Split(filepath.Base(flag.Arg(0)), ".")
+	parts := strings.Split(filepath.Base(specPath), ".")
 	cfg.PackageName = codegen.LowercaseFirstCharacter(codegen.ToCamelCase(parts[0]))
 
 	return nil
@@ -525,6 +668,10 @@ func generationTargets(cfg *codegen.Configuration, targets []string) error {
 			opts.Models = true
 		case "spec", "embedded-spec":
 			opts.EmbeddedSpec = true
+		case "webhooks":
+			opts.Webhooks = true
+		case "callbacks":
+			opts.Callbacks = true
 		case "skip-fmt":
 			cfg.OutputOptions.SkipFmt = true
 		case "skip-prune":
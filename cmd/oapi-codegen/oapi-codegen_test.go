@@ -1,6 +1,9 @@
 package main
 
 import (
+	"bytes"
+	"flag"
+	"strings"
 	"testing"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
@@ -24,3 +27,14 @@ func TestLoader(t *testing.T) {
 		}
 	}
 }
+
+func TestUsageDescribesDiff(t *testing.T) {
+	var buf bytes.Buffer
+	flag.CommandLine.SetOutput(&buf)
+	defer flag.CommandLine.SetOutput(nil)
+
+	usage()
+	if !strings.Contains(buf.String(), "oapi-codegen diff [-config cfg.yaml] old.yaml new.yaml") {
+		t.Errorf("the usage doesn't describe the diff command:\n%s", buf.String())
+	}
+}
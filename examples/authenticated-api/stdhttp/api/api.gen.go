@@ -51,6 +51,10 @@ type AddThingJSONRequestBody = Thing
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
 // Doer performs HTTP requests.
 //
 // The standard http.Client implements this interface.
@@ -58,6 +62,25 @@ type HttpRequestDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
 // Client which conforms to the OpenAPI3 specification for this service.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
@@ -73,6 +96,14 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -98,6 +129,9 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if client.Client == nil {
 		client.Client = &http.Client{}
 	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
 	return &client, nil
 }
 
@@ -119,6 +153,26 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// ListThings request
@@ -135,11 +189,12 @@ func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn)
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListThings")
 	req = req.WithContext(ctx)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req)
 }
 
 func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -147,11 +202,12 @@ func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddThing")
 	req = req.WithContext(ctx)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req)
 }
 
 func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -159,11 +215,12 @@ func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, req
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddThing")
 	req = req.WithContext(ctx)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req)
 }
 
 // NewListThingsRequest generates requests for ListThings
@@ -233,6 +290,21 @@ func NewAddThingRequestWithBody(server string, contentType string, body io.Reade
 	return req, nil
 }
 
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
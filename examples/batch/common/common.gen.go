@@ -0,0 +1,10 @@
+// Package common provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package common
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
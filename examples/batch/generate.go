@@ -0,0 +1,4 @@
+// Package batch generates the code of several specs from a single config file.
+package batch
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen.yaml
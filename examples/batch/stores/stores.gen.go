@@ -0,0 +1,314 @@
+// Package stores provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	externalRef0 "github.com/oapi-codegen/oapi-codegen/v2/examples/batch/common"
+	"github.com/oapi-codegen/runtime"
+)
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// StoresClient which conforms to the OpenAPI3 specification for this service.
+type StoresClient struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*StoresClient) error
+
+// Creates a new StoresClient, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*StoresClient, error) {
+	// create a client with sane default values
+	client := StoresClient{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *StoresClient) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *StoresClient) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *StoresClient) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *StoresClient) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListPetsInStore request
+	ListPetsInStore(ctx context.Context, storeId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *StoresClient) ListPetsInStore(ctx context.Context, storeId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsInStoreRequest(c.Server, storeId)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPetsInStore")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewListPetsInStoreRequest generates requests for ListPetsInStore
+func NewListPetsInStoreRequest(server string, storeId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "storeId", runtime.ParamLocationPath, storeId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stores/%s/pets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *StoresClient) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *StoresClient) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *StoresClient) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListPetsInStoreWithResponse request
+	ListPetsInStoreWithResponse(ctx context.Context, storeId string, reqEditors ...RequestEditorFn) (*ListPetsInStoreResponse, error)
+}
+
+type ListPetsInStoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsInStoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsInStoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListPetsInStoreWithResponse request returning *ListPetsInStoreResponse
+func (c *ClientWithResponses) ListPetsInStoreWithResponse(ctx context.Context, storeId string, reqEditors ...RequestEditorFn) (*ListPetsInStoreResponse, error) {
+	rsp, err := c.ListPetsInStore(ctx, storeId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsInStoreResponse(rsp)
+}
+
+// ParseListPetsInStoreResponse parses an HTTP response from a ListPetsInStoreWithResponse call
+func ParseListPetsInStoreResponse(rsp *http.Response) (*ListPetsInStoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsInStoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,136 @@
+package clientmiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/pets/1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"there's no pet with that ID"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"name":"Tom"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// metrics counts the responses to each operation, by their status code.
+type metrics struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *metrics) middleware(next HttpRequestDoer) HttpRequestDoer {
+	return HttpRequestDoerFunc(func(req *http.Request) (*http.Response, error) {
+		rsp, err := next.Do(req)
+		if err == nil {
+			operationID, _ := OperationIDFromContext(req.Context())
+			m.mu.Lock()
+			m.counts[fmt.Sprintf("%s %d", operationID, rsp.StatusCode)]++
+			m.mu.Unlock()
+		}
+		return rsp, err
+	})
+}
+
+func TestMiddlewareSeesEachRequestWithItsOperationID(t *testing.T) {
+	m := &metrics{counts: map[string]int{}}
+	client, err := NewClientWithResponses(newServer(t).URL, WithMiddleware(m.middleware))
+	require.NoError(t, err)
+
+	_, err = client.GetPetWithResponse(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = client.GetPetWithResponse(context.Background(), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"GetPet 200": 1, "GetPet 404": 1}, m.counts)
+}
+
+func TestMiddlewaresAreApplied(t *testing.T) {
+	var order []string
+	named := func(name string) func(HttpRequestDoer) HttpRequestDoer {
+		return func(next HttpRequestDoer) HttpRequestDoer {
+			return HttpRequestDoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	client, err := NewClient(newServer(t).URL, WithMiddleware(named("first")), WithMiddleware(named("second")))
+	require.NoError(t, err)
+
+	rsp, err := client.GetPet(context.Background(), 1)
+	require.NoError(t, err)
+	_ = rsp.Body.Close()
+
+	// the last middleware to be added is the outermost
+	assert.Equal(t, []string{"second", "first"}, order)
+}
+
+// APIError is the error which responses with an Error body are mapped to.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+func mapErrors(ctx context.Context, rsp *http.Response) error {
+	if rsp.StatusCode < 400 {
+		return nil
+	}
+	var body Error
+	if err := json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		return err
+	}
+	return &APIError{StatusCode: rsp.StatusCode, Message: body.Message}
+}
+
+func TestResponseEditorMapsErrors(t *testing.T) {
+	client, err := NewClientWithResponses(newServer(t).URL, WithResponseEditorFn(mapErrors))
+	require.NoError(t, err)
+
+	response, err := client.GetPetWithResponse(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Tom", response.JSON200.Name)
+
+	_, err = client.GetPetWithResponse(context.Background(), 2)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "there's no pet with that ID", apiErr.Message)
+}
+
+func TestResponseEditorCanObserveTheResponse(t *testing.T) {
+	var seen []string
+	client, err := NewClientWithResponses(newServer(t).URL, WithResponseEditorFn(func(ctx context.Context, rsp *http.Response) error {
+		operationID, ok := OperationIDFromContext(ctx)
+		require.True(t, ok)
+		seen = append(seen, fmt.Sprintf("%s %s", operationID, rsp.Status))
+		return nil
+	}))
+	require.NoError(t, err)
+
+	response, err := client.GetPetWithResponse(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), response.JSON200.Id)
+	assert.Equal(t, []string{"GetPet 200 OK"}, seen)
+}
@@ -0,0 +1,904 @@
+// Package clientpagination provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package clientpagination
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Owner defines model for Owner.
+type Owner struct {
+	Name string `json:"name"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// PetPage defines model for PetPage.
+type PetPage struct {
+	Data []Pet `json:"data"`
+	Meta struct {
+		NextCursor *string `json:"nextCursor,omitempty"`
+	} `json:"meta"`
+}
+
+// ListOwnersParams defines parameters for ListOwners.
+type ListOwnersParams struct {
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListPetsParams defines parameters for ListPets.
+type ListPetsParams struct {
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListOwners request
+	ListOwners(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPets request
+	ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListToys request
+	ListToys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListOwners(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListOwnersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListOwners")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) ListToys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListToysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListToys")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewListOwnersRequest generates requests for ListOwners
+func NewListOwnersRequest(server string, params *ListOwnersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/owners")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string, params *ListPetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListToysRequest generates requests for ListToys
+func NewListToysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/toys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	// the page at a `next` link is requested before any of the editors run, so that those which sign the request sign its URL
+	if next, ok := ctx.Value(paginationNextLinkKey{}).(*url.URL); ok {
+		u := *next
+		req.URL = &u
+		req.Host = u.Host
+	}
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListOwnersWithResponse request
+	ListOwnersWithResponse(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) (*ListOwnersResponse, error)
+
+	// ListPetsWithResponse request
+	ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// ListToysWithResponse request
+	ListToysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListToysResponse, error)
+}
+
+type ListOwnersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Owners []Owner `json:"owners"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListOwnersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListOwnersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PetPage
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListToysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+}
+
+// Status returns HTTPResponse.Status
+func (r ListToysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListToysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListOwnersWithResponse request returning *ListOwnersResponse
+func (c *ClientWithResponses) ListOwnersWithResponse(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) (*ListOwnersResponse, error) {
+	rsp, err := c.ListOwners(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListOwnersResponse(rsp)
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// ListToysWithResponse request returning *ListToysResponse
+func (c *ClientWithResponses) ListToysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListToysResponse, error) {
+	rsp, err := c.ListToys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListToysResponse(rsp)
+}
+
+// ParseListOwnersResponse parses an HTTP response from a ListOwnersWithResponse call
+func ParseListOwnersResponse(rsp *http.Response) (*ListOwnersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListOwnersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Owners []Owner `json:"owners"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PetPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListToysResponse parses an HTTP response from a ListToysWithResponse call
+func ParseListToysResponse(rsp *http.Response) (*ListToysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListToysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// paginationField returns the JSON of the field at the path within a page's body, or nil when it's absent.
+func paginationField(body []byte, path ...string) (json.RawMessage, error) {
+	value := json.RawMessage(body)
+	for _, name := range path {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(value, &object); err != nil {
+			return nil, fmt.Errorf("can't read the field %s of the page: %w", name, err)
+		}
+		if value = object[name]; value == nil {
+			return nil, nil
+		}
+	}
+	return value, nil
+}
+
+// paginationIsEmpty returns whether the JSON of a cursor is absent, null or an empty string.
+func paginationIsEmpty(value json.RawMessage) bool {
+	s := strings.TrimSpace(string(value))
+	return s == "" || s == "null" || s == `""`
+}
+
+// paginationItems decodes the items at the path within a page's body into items.
+func paginationItems(body []byte, items interface{}, path ...string) error {
+	value, err := paginationField(body, path...)
+	if err != nil || value == nil {
+		return err
+	}
+	if err := json.Unmarshal(value, items); err != nil {
+		return fmt.Errorf("can't read the items of the page: %w", err)
+	}
+	return nil
+}
+
+// paginationOffset returns the value of an offset parameter, which is zero when it's unset.
+func paginationOffset(offset interface{}) (int, error) {
+	data, err := json.Marshal(offset)
+	if err != nil {
+		return 0, err
+	}
+	var value *int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return 0, fmt.Errorf("can't read the offset: %w", err)
+	}
+	if value == nil {
+		return 0, nil
+	}
+	return *value, nil
+}
+
+// paginationNextLink returns the URL of the `next` link in the `Link` header of a response, relative to the URL of its request, which must have the same scheme and host as the request, so that its credentials aren't sent elsewhere.
+func paginationNextLink(rsp *http.Response) (*url.URL, error) {
+	if rsp == nil {
+		return nil, nil
+	}
+	for _, header := range rsp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if !strings.EqualFold(rel, "next") {
+						continue
+					}
+					next, err := url.Parse(target[1 : len(target)-1])
+					if err != nil {
+						return nil, fmt.Errorf("can't parse the next link: %w", err)
+					}
+					if rsp.Request != nil && rsp.Request.URL != nil {
+						next = rsp.Request.URL.ResolveReference(next)
+						if !strings.EqualFold(next.Scheme, rsp.Request.URL.Scheme) || !strings.EqualFold(next.Host, rsp.Request.URL.Host) {
+							return nil, fmt.Errorf("the next link %s isn't on the same origin as the request", next.Redacted())
+						}
+					}
+					return next, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// paginationNextLinkKey is the key of the URL of a `next` link in the context of a request, which the client requests instead of the operation's URL.
+type paginationNextLinkKey struct{}
+
+// ListOwnersPages returns an iterator over the pages of responses to the ListOwners operation, which requests each page after the items of the previous page, until a page has none.
+//
+// An error is yielded, with the response, when a page isn't successful, after which the iteration stops.
+func (c *ClientWithResponses) ListOwnersPages(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) iter.Seq2[*ListOwnersResponse, error] {
+	return func(yield func(*ListOwnersResponse, error) bool) {
+		// the parameters are copied, so that the caller's aren't changed
+		var pageParams, zero ListOwnersParams
+		if params != nil {
+			pageParams = *params
+		}
+		for {
+			response, err := c.ListOwnersWithResponse(ctx, &pageParams, reqEditors...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if response.StatusCode() < 200 || response.StatusCode() > 299 {
+				yield(response, fmt.Errorf("the ListOwners operation responded with %s", response.Status()))
+				return
+			}
+			if !yield(response, nil) {
+				return
+			}
+
+			var items []json.RawMessage
+			if err := paginationItems(response.Body, &items, "owners"); err != nil {
+				yield(nil, err)
+				return
+			}
+			if len(items) == 0 {
+				return
+			}
+			offset, err := paginationOffset(pageParams.Offset)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			pageParams.Offset = zero.Offset
+			if err := json.Unmarshal([]byte(strconv.Itoa(offset+len(items))), &pageParams.Offset); err != nil {
+				yield(nil, fmt.Errorf("can't set the offset of the next page: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// ListOwnersItems returns an iterator over the items of each of the pages from ListOwnersPages.
+func (c *ClientWithResponses) ListOwnersItems(ctx context.Context, params *ListOwnersParams, reqEditors ...RequestEditorFn) iter.Seq2[Owner, error] {
+	return func(yield func(Owner, error) bool) {
+		var zero Owner
+		c.ListOwnersPages(ctx, params, reqEditors...)(func(response *ListOwnersResponse, err error) bool {
+			if err != nil {
+				return yield(zero, err)
+			}
+			var items []Owner
+			if err := paginationItems(response.Body, &items, "owners"); err != nil {
+				yield(zero, err)
+				return false
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// ListPetsPages returns an iterator over the pages of responses to the ListPets operation, which requests each page with the cursor of the previous page, until there's none.
+//
+// An error is yielded, with the response, when a page isn't successful, after which the iteration stops.
+func (c *ClientWithResponses) ListPetsPages(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[*ListPetsResponse, error] {
+	return func(yield func(*ListPetsResponse, error) bool) {
+		// the parameters are copied, so that the caller's aren't changed
+		var pageParams, zero ListPetsParams
+		if params != nil {
+			pageParams = *params
+		}
+		// the pages that have been requested, by their cursor or URL, which stop the iteration if they're repeated
+		requested := map[string]bool{}
+		for {
+			response, err := c.ListPetsWithResponse(ctx, &pageParams, reqEditors...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if response.StatusCode() < 200 || response.StatusCode() > 299 {
+				yield(response, fmt.Errorf("the ListPets operation responded with %s", response.Status()))
+				return
+			}
+			if !yield(response, nil) {
+				return
+			}
+
+			cursor, err := paginationField(response.Body, "meta", "nextCursor")
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if paginationIsEmpty(cursor) || requested[string(cursor)] {
+				return
+			}
+			requested[string(cursor)] = true
+			pageParams.Cursor = zero.Cursor
+			if err := json.Unmarshal(cursor, &pageParams.Cursor); err != nil {
+				yield(nil, fmt.Errorf("can't read the cursor of the next page: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// ListPetsItems returns an iterator over the items of each of the pages from ListPetsPages.
+func (c *ClientWithResponses) ListPetsItems(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[Pet, error] {
+	return func(yield func(Pet, error) bool) {
+		var zero Pet
+		c.ListPetsPages(ctx, params, reqEditors...)(func(response *ListPetsResponse, err error) bool {
+			if err != nil {
+				return yield(zero, err)
+			}
+			var items []Pet
+			if err := paginationItems(response.Body, &items, "data"); err != nil {
+				yield(zero, err)
+				return false
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// ListToysPages returns an iterator over the pages of responses to the ListToys operation, which requests each page from the `next` link of the previous page, until there's none.
+//
+// An error is yielded, with the response, when a page isn't successful, after which the iteration stops.
+func (c *ClientWithResponses) ListToysPages(ctx context.Context, reqEditors ...RequestEditorFn) iter.Seq2[*ListToysResponse, error] {
+	return func(yield func(*ListToysResponse, error) bool) {
+		var next *url.URL
+		// the pages that have been requested, by their cursor or URL, which stop the iteration if they're repeated
+		requested := map[string]bool{}
+		for {
+			pageCtx := ctx
+			if next != nil {
+				pageCtx = context.WithValue(ctx, paginationNextLinkKey{}, next)
+			}
+			response, err := c.ListToysWithResponse(pageCtx, reqEditors...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if response.StatusCode() < 200 || response.StatusCode() > 299 {
+				yield(response, fmt.Errorf("the ListToys operation responded with %s", response.Status()))
+				return
+			}
+			if !yield(response, nil) {
+				return
+			}
+
+			if response.HTTPResponse != nil && response.HTTPResponse.Request != nil {
+				requested[response.HTTPResponse.Request.URL.String()] = true
+			}
+			if next, err = paginationNextLink(response.HTTPResponse); err != nil {
+				yield(nil, err)
+				return
+			}
+			if next == nil || requested[next.String()] {
+				return
+			}
+		}
+	}
+}
+
+// ListToysItems returns an iterator over the items of each of the pages from ListToysPages.
+func (c *ClientWithResponses) ListToysItems(ctx context.Context, reqEditors ...RequestEditorFn) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		var zero string
+		c.ListToysPages(ctx, reqEditors...)(func(response *ListToysResponse, err error) bool {
+			if err != nil {
+				return yield(zero, err)
+			}
+			var items []string
+			if err := paginationItems(response.Body, &items); err != nil {
+				yield(zero, err)
+				return false
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
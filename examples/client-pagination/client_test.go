@@ -0,0 +1,259 @@
+package clientpagination
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pets := []Pet{{Id: 1, Name: "Tom"}, {Id: 2, Name: "Jerry"}, {Id: 3, Name: "Spike"}}
+	owners := []Owner{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pets", func(w http.ResponseWriter, r *http.Request) {
+		// the cursor is the index of the first pet of the page, with pages of 2 pets
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		end := min(start+2, len(pets))
+		var page PetPage
+		page.Data = pets[start:end]
+		if end < len(pets) {
+			next := strconv.Itoa(end)
+			page.Meta.NextCursor = &next
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("/owners", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset > len(owners) {
+			offset = len(owners)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"owners": owners[offset:min(offset+2, len(owners))]})
+	})
+	mux.HandleFunc("/toys", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`</toys?page=%d>; rel="next", </toys>; rel="first"`, page+1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]string{fmt.Sprintf("ball %d", page), fmt.Sprintf("bone %d", page)})
+	})
+	mux.HandleFunc("/broken/pets", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	// the looping pages always refer to themselves
+	mux.HandleFunc("/looping/pets", func(w http.ResponseWriter, r *http.Request) {
+		next := "0"
+		var page PetPage
+		page.Data = pets[:1]
+		page.Meta.NextCursor = &next
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("/looping/toys", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `</looping/toys?page=1>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]string{"ball " + r.URL.Query().Get("page")})
+	})
+	mux.HandleFunc("/elsewhere/toys", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://example.com/toys?page=1>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]string{"ball"})
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestCursorPagination(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	var pages int
+	for response, err := range client.ListPetsPages(context.Background(), nil) {
+		require.NoError(t, err)
+		require.NotNil(t, response.JSON200)
+		pages++
+	}
+	assert.Equal(t, 2, pages)
+
+	var names []string
+	for pet, err := range client.ListPetsItems(context.Background(), &ListPetsParams{}) {
+		require.NoError(t, err)
+		names = append(names, pet.Name)
+	}
+	assert.Equal(t, []string{"Tom", "Jerry", "Spike"}, names)
+}
+
+func TestCursorPaginationDoesNotChangeParams(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	cursor := "1"
+	params := &ListPetsParams{Cursor: &cursor}
+	var names []string
+	for pet, err := range client.ListPetsItems(context.Background(), params) {
+		require.NoError(t, err)
+		names = append(names, pet.Name)
+	}
+	assert.Equal(t, []string{"Jerry", "Spike"}, names)
+	assert.Equal(t, "1", *params.Cursor)
+}
+
+func TestOffsetPagination(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	var names []string
+	for owner, err := range client.ListOwnersItems(context.Background(), nil) {
+		require.NoError(t, err)
+		names = append(names, owner.Name)
+	}
+	assert.Equal(t, []string{"Alice", "Bob", "Carol"}, names)
+}
+
+func TestLinkPagination(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	var toys []string
+	for toy, err := range client.ListToysItems(context.Background()) {
+		require.NoError(t, err)
+		toys = append(toys, toy)
+	}
+	assert.Equal(t, []string{"ball 0", "bone 0", "ball 1", "bone 1", "ball 2", "bone 2"}, toys)
+}
+
+func TestLinkPaginationSignsEachPage(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := securityprovider.NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := securityprovider.NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+	// the signature of each page covers its URL
+	s := httptest.NewServer(verifier.Middleware(newServer(t).Config.Handler))
+	t.Cleanup(s.Close)
+
+	clientSigned, err := NewClientWithResponses(s.URL, WithRequestEditorFn(signer.Intercept))
+	require.NoError(t, err)
+	requestSigned, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	for name, pages := range map[string]func() []string{
+		"client editor": func() []string {
+			var toys []string
+			for toy, err := range clientSigned.ListToysItems(context.Background()) {
+				require.NoError(t, err)
+				toys = append(toys, toy)
+			}
+			return toys
+		},
+		"request editor": func() []string {
+			var toys []string
+			for toy, err := range requestSigned.ListToysItems(context.Background(), signer.Intercept) {
+				require.NoError(t, err)
+				toys = append(toys, toy)
+			}
+			return toys
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, []string{"ball 0", "bone 0", "ball 1", "bone 1", "ball 2", "bone 2"}, pages())
+		})
+	}
+}
+
+func TestPaginationStopsEarly(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+
+	var toys []string
+	for toy, err := range client.ListToysItems(context.Background()) {
+		require.NoError(t, err)
+		toys = append(toys, toy)
+		if len(toys) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"ball 0", "bone 0", "ball 1"}, toys)
+}
+
+func TestPaginationYieldsUnsuccessfulResponses(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL + "/broken")
+	require.NoError(t, err)
+
+	var pages int
+	for response, err := range client.ListPetsPages(context.Background(), nil) {
+		require.Error(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode())
+		pages++
+	}
+	assert.Equal(t, 1, pages)
+
+	for _, err := range client.ListPetsItems(context.Background(), nil) {
+		assert.Error(t, err)
+	}
+}
+
+func TestPaginationStopsAtRepeatedPages(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL + "/looping")
+	require.NoError(t, err)
+
+	var names []string
+	for pet, err := range client.ListPetsItems(context.Background(), nil) {
+		require.NoError(t, err)
+		names = append(names, pet.Name)
+	}
+	assert.Equal(t, []string{"Tom", "Tom"}, names)
+
+	var toys []string
+	for toy, err := range client.ListToysItems(context.Background()) {
+		require.NoError(t, err)
+		toys = append(toys, toy)
+	}
+	assert.Equal(t, []string{"ball ", "ball 1"}, toys)
+}
+
+func TestLinkPaginationRejectsOtherOrigins(t *testing.T) {
+	s := newServer(t)
+	client, err := NewClientWithResponses(s.URL + "/elsewhere")
+	require.NoError(t, err)
+
+	var toys []string
+	var errs []error
+	for toy, err := range client.ListToysItems(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		toys = append(toys, toy)
+	}
+	assert.Equal(t, []string{"ball"}, toys)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "isn't on the same origin as the request")
+}
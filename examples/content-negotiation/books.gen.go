@@ -0,0 +1,849 @@
+//go:build go1.22
+
+// Package contentnegotiation provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package contentnegotiation
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Book defines model for Book.
+type Book struct {
+	Isbn  string `json:"isbn"`
+	Title string `json:"title"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	Detail string `json:"detail"`
+}
+
+// NotFound defines model for NotFound.
+type NotFound = Problem
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetBook request
+	GetBook(ctx context.Context, isbn string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetBook(ctx context.Context, isbn string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBookRequest(c.Server, isbn)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "GetBook")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewGetBookRequest generates requests for GetBook
+func NewGetBookRequest(server string, isbn string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isbn", runtime.ParamLocationPath, isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/books/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBookWithResponse request
+	GetBookWithResponse(ctx context.Context, isbn string, reqEditors ...RequestEditorFn) (*GetBookResponse, error)
+}
+
+type GetBookResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Book
+	XML200                    *Book
+	ApplicationproblemJSON404 *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetBookWithResponse request returning *GetBookResponse
+func (c *ClientWithResponses) GetBookWithResponse(ctx context.Context, isbn string, reqEditors ...RequestEditorFn) (*GetBookResponse, error) {
+	rsp, err := c.GetBook(ctx, isbn, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBookResponse(rsp)
+}
+
+// ParseGetBookResponse parses an HTTP response from a GetBookWithResponse call
+func ParseGetBookResponse(rsp *http.Response) (*GetBookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Book
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Book
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case rsp.StatusCode == 200:
+	// Content-type (text/csv) unsupported
+
+	case rsp.StatusCode == 404:
+		// Content-type (application/problem+xml) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /books/{isbn})
+	GetBook(w http.ResponseWriter, r *http.Request, isbn string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetBook operation middleware
+func (siw *ServerInterfaceWrapper) GetBook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "isbn" -------------
+	var isbn string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isbn", r.PathValue("isbn"), &isbn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isbn", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBook(w, r, isbn)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/books/{isbn}", wrapper.GetBook)
+
+	return m
+}
+
+type NotFoundApplicationProblemPlusJSONResponse Problem
+type NotFoundApplicationproblemXmlResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type GetBookRequestObject struct {
+	Isbn string `json:"isbn"`
+}
+
+type GetBookResponseObject interface {
+	VisitGetBookResponse(w http.ResponseWriter) error
+}
+
+type GetBook200ResponseHeaders struct {
+	XEdition int
+}
+
+type GetBook200JSONResponse struct {
+	Body    Book
+	Headers GetBook200ResponseHeaders
+}
+
+func (response GetBook200JSONResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Edition", fmt.Sprint(response.Headers.XEdition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetBook200ApplicationxmlResponse struct {
+	Body          io.Reader
+	Headers       GetBook200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetBook200ApplicationxmlResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("X-Edition", fmt.Sprint(response.Headers.XEdition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetBook200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetBook200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetBook200TextcsvResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("X-Edition", fmt.Sprint(response.Headers.XEdition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetBook404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetBook404ApplicationProblemPlusJSONResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBook404ApplicationproblemXmlResponse struct {
+	NotFoundApplicationproblemXmlResponse
+}
+
+func (response GetBook404ApplicationproblemXmlResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(404)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /books/{isbn})
+	GetBook(ctx context.Context, request GetBookRequestObject) (GetBookResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// Codecs encode the bodies of the responses which have several media types, in the one that's preferred by the Accept header of each request, by their media type. When it's nil, the DefaultStrictHTTPCodecs are used
+	Codecs map[string]StrictHTTPCodec
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetBook operation middleware
+func (sh *strictHandler) GetBook(w http.ResponseWriter, r *http.Request, isbn string) {
+	var request GetBookRequestObject
+
+	request.Isbn = isbn
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBook(ctx, request.(GetBookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBook")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBookResponseObject); ok {
+		if negotiated, ok := validResponse.(strictHTTPNegotiatedResponse); ok {
+			if err := negotiated.visitNegotiated(w, r.Header.Get("Accept"), sh.codecs()); err != nil {
+				sh.options.ResponseErrorHandlerFunc(w, r, err)
+			}
+		} else if err := validResponse.VisitGetBookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StrictHTTPCodec encodes the body of a response in a media type, when it's the one that's preferred by the Accept header of a request.
+type StrictHTTPCodec interface {
+	Encode(w io.Writer, body interface{}) error
+}
+
+// StrictHTTPCodecFunc is a function which implements StrictHTTPCodec.
+type StrictHTTPCodecFunc func(w io.Writer, body interface{}) error
+
+// Encode calls f(w, body).
+func (f StrictHTTPCodecFunc) Encode(w io.Writer, body interface{}) error {
+	return f(w, body)
+}
+
+// DefaultStrictHTTPCodecs returns the codecs of the media types which are supported by default, which are JSON and XML. They can be added to, such as with a codec for `application/cbor`, and set as the Codecs of the StrictHTTPServerOptions.
+func DefaultStrictHTTPCodecs() map[string]StrictHTTPCodec {
+	encodeXML := StrictHTTPCodecFunc(func(w io.Writer, body interface{}) error {
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		return xml.NewEncoder(w).Encode(body)
+	})
+	return map[string]StrictHTTPCodec{
+		"application/json": StrictHTTPCodecFunc(func(w io.Writer, body interface{}) error {
+			return json.NewEncoder(w).Encode(body)
+		}),
+		"application/xml": encodeXML,
+		"text/xml":        encodeXML,
+	}
+}
+
+// defaultStrictHTTPCodecs are the codecs which are used when the StrictHTTPServerOptions have none.
+var defaultStrictHTTPCodecs = DefaultStrictHTTPCodecs()
+
+// codecs returns the codecs of the responses' media types.
+func (sh *strictHandler) codecs() map[string]StrictHTTPCodec {
+	if sh.options.Codecs == nil {
+		return defaultStrictHTTPCodecs
+	}
+	return sh.options.Codecs
+}
+
+// strictHTTPNegotiatedResponse is a response whose media type is negotiated with the Accept header of the request.
+type strictHTTPNegotiatedResponse interface {
+	visitNegotiated(w http.ResponseWriter, accept string, codecs map[string]StrictHTTPCodec) error
+}
+
+// negotiateStrictHTTPCodec chooses the media type of a response, from those it has which have a codec, that's preferred by the Accept header of the request. When several of them are equally acceptable, the first of them is chosen.
+func negotiateStrictHTTPCodec(accept string, contentTypes []string, codecs map[string]StrictHTTPCodec) (string, StrictHTTPCodec, bool) {
+	chosen, chosenQuality := -1, 0.0
+	for i, contentType := range contentTypes {
+		if strictHTTPCodecOf(codecs, contentType) == nil {
+			continue
+		}
+		if quality := strictHTTPAcceptQuality(accept, contentType); quality > chosenQuality {
+			chosen, chosenQuality = i, quality
+		}
+	}
+	if chosen < 0 {
+		return "", nil, false
+	}
+	return contentTypes[chosen], strictHTTPCodecOf(codecs, contentTypes[chosen]), true
+}
+
+// strictHTTPCodecOf returns the codec of a media type, which is the codec of its structured syntax suffix, such as `application/json` for `application/problem+json`, when it has no codec of its own.
+func strictHTTPCodecOf(codecs map[string]StrictHTTPCodec, contentType string) StrictHTTPCodec {
+	if codec, ok := codecs[contentType]; ok {
+		return codec
+	}
+	if i := strings.LastIndex(contentType, "+"); i >= 0 {
+		return codecs["application/"+contentType[i+1:]]
+	}
+	return nil
+}
+
+// strictHTTPAcceptQuality returns the quality of a media type, from the most specific of the Accept header's media ranges which matches it, or 1 when there's no Accept header, so that any media type is acceptable.
+func strictHTTPAcceptQuality(accept string, contentType string) float64 {
+	if strings.TrimSpace(accept) == "" {
+		return 1
+	}
+	contentType = strings.ToLower(contentType)
+	quality, specificity := 0.0, -1
+	for _, mediaRange := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		var rangeSpecificity int
+		switch {
+		case rangeType == contentType:
+			rangeSpecificity = 2
+		case strings.HasSuffix(rangeType, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(rangeType, "*")):
+			rangeSpecificity = 1
+		case rangeType == "*/*":
+			rangeSpecificity = 0
+		default:
+			continue
+		}
+		if rangeSpecificity <= specificity {
+			continue
+		}
+		rangeQuality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				rangeQuality = parsed
+			}
+		}
+		quality, specificity = rangeQuality, rangeSpecificity
+	}
+	return quality
+}
+
+// GetBook200NegotiatedResponse is the 200 response of GetBook, whose body is encoded in whichever of its media types, application/json, application/xml, text/csv, is preferred by the Accept header of the request.
+type GetBook200NegotiatedResponse struct {
+	Body    Book
+	Headers GetBook200ResponseHeaders
+}
+
+// VisitGetBookResponse encodes the body in the first of its media types which has a default codec, as there's no request to negotiate with.
+func (response GetBook200NegotiatedResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	return response.visitNegotiated(w, "", defaultStrictHTTPCodecs)
+}
+
+func (response GetBook200NegotiatedResponse) visitNegotiated(w http.ResponseWriter, accept string, codecs map[string]StrictHTTPCodec) error {
+	w.Header().Add("Vary", "Accept")
+	contentType, codec, ok := negotiateStrictHTTPCodec(accept, []string{"application/json", "application/xml", "text/csv"}, codecs)
+	if !ok {
+		http.Error(w, "the response can only be application/json, application/xml, text/csv", http.StatusNotAcceptable)
+		return nil
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Edition", fmt.Sprint(response.Headers.XEdition))
+	w.WriteHeader(200)
+	return codec.Encode(w, response.Body)
+}
+
+// GetBook404NegotiatedResponse is the 404 response of GetBook, whose body is encoded in whichever of its media types, application/problem+json, application/problem+xml, is preferred by the Accept header of the request.
+type GetBook404NegotiatedResponse struct {
+	Body Problem
+}
+
+// VisitGetBookResponse encodes the body in the first of its media types which has a default codec, as there's no request to negotiate with.
+func (response GetBook404NegotiatedResponse) VisitGetBookResponse(w http.ResponseWriter) error {
+	return response.visitNegotiated(w, "", defaultStrictHTTPCodecs)
+}
+
+func (response GetBook404NegotiatedResponse) visitNegotiated(w http.ResponseWriter, accept string, codecs map[string]StrictHTTPCodec) error {
+	w.Header().Add("Vary", "Accept")
+	contentType, codec, ok := negotiateStrictHTTPCodec(accept, []string{"application/problem+json", "application/problem+xml"}, codecs)
+	if !ok {
+		http.Error(w, "the response can only be application/problem+json, application/problem+xml", http.StatusNotAcceptable)
+		return nil
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(404)
+	return codec.Encode(w, response.Body)
+}
@@ -0,0 +1,143 @@
+package contentnegotiation
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dune = Book{Isbn: "9780441172719", Title: "Dune"}
+
+func get(t *testing.T, handler http.Handler, path string, accept string) *http.Response {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { rsp.Body.Close() })
+	return rsp
+}
+
+func body(t *testing.T, rsp *http.Response) string {
+	t.Helper()
+	data, err := io.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestGetBook(t *testing.T) {
+	handler := Handler(NewStrictHandler(NewServer(dune), nil))
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "without an Accept header",
+			contentType: "application/json",
+			body:        `{"isbn":"9780441172719","title":"Dune"}` + "\n",
+		},
+		{
+			name:        "XML",
+			accept:      "application/xml",
+			contentType: "application/xml",
+			body:        xml.Header + `<Book><Isbn>9780441172719</Isbn><Title>Dune</Title></Book>`,
+		},
+		{
+			name:        "the most preferred",
+			accept:      "application/json;q=0.5, application/xml",
+			contentType: "application/xml",
+		},
+		{
+			name:        "the most specific media range",
+			accept:      "application/*;q=0.1, application/json, */*;q=0.8",
+			contentType: "application/json",
+		},
+		{
+			name:        "any media type",
+			accept:      "*/*",
+			contentType: "application/json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rsp := get(t, handler, "/books/9780441172719", test.accept)
+			assert.Equal(t, http.StatusOK, rsp.StatusCode)
+			assert.Equal(t, test.contentType, rsp.Header.Get("Content-Type"))
+			assert.Equal(t, "Accept", rsp.Header.Get("Vary"))
+			assert.Equal(t, "1", rsp.Header.Get("X-Edition"))
+			if test.body != "" {
+				assert.Equal(t, test.body, body(t, rsp))
+			}
+		})
+	}
+}
+
+func TestGetBookNotAcceptable(t *testing.T) {
+	handler := Handler(NewStrictHandler(NewServer(dune), nil))
+
+	// there's no codec for CSV by default
+	rsp := get(t, handler, "/books/9780441172719", "text/csv")
+	assert.Equal(t, http.StatusNotAcceptable, rsp.StatusCode)
+	assert.Equal(t, "Accept", rsp.Header.Get("Vary"))
+
+	rsp = get(t, handler, "/books/9780441172719", "application/json;q=0")
+	assert.Equal(t, http.StatusNotAcceptable, rsp.StatusCode)
+}
+
+func TestGetBookWithCodecs(t *testing.T) {
+	handler := Handler(NewStrictHandlerWithOptions(NewServer(dune), nil, StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+		Codecs: Codecs(),
+	}))
+
+	rsp := get(t, handler, "/books/9780441172719", "text/csv")
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, "text/csv", rsp.Header.Get("Content-Type"))
+	assert.Equal(t, "isbn,title\n9780441172719,Dune\n", body(t, rsp))
+}
+
+func TestGetBookNotFound(t *testing.T) {
+	handler := Handler(NewStrictHandler(NewServer(), nil))
+
+	rsp := get(t, handler, "/books/9780441172719", "")
+	assert.Equal(t, http.StatusNotFound, rsp.StatusCode)
+	assert.Equal(t, "application/problem+json", rsp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"detail":"there's no book with the ISBN 9780441172719"}`+"\n", body(t, rsp))
+
+	// the problem is encoded with the codec of its structured syntax suffix
+	rsp = get(t, handler, "/books/9780441172719", "application/problem+xml")
+	assert.Equal(t, http.StatusNotFound, rsp.StatusCode)
+	assert.Equal(t, "application/problem+xml", rsp.Header.Get("Content-Type"))
+	assert.Equal(t, xml.Header+`<Problem><Detail>there&#39;s no book with the ISBN 9780441172719</Detail></Problem>`, body(t, rsp))
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(Handler(NewStrictHandler(NewServer(dune), nil)))
+	defer server.Close()
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	rsp, err := client.GetBookWithResponse(context.Background(), dune.Isbn)
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Equal(t, dune, *rsp.JSON200)
+}
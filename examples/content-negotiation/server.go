@@ -0,0 +1,56 @@
+package contentnegotiation
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Server serves books, in whichever of their media types each client
+// prefers.
+type Server struct {
+	books map[string]Book
+}
+
+var _ StrictServerInterface = (*Server)(nil)
+
+// NewServer returns a Server of the given books.
+func NewServer(books ...Book) *Server {
+	s := &Server{books: map[string]Book{}}
+	for _, book := range books {
+		s.books[book.Isbn] = book
+	}
+	return s
+}
+
+func (s *Server) GetBook(ctx context.Context, request GetBookRequestObject) (GetBookResponseObject, error) {
+	book, ok := s.books[request.Isbn]
+	if !ok {
+		return GetBook404NegotiatedResponse{
+			Body: Problem{Detail: fmt.Sprintf("there's no book with the ISBN %s", request.Isbn)},
+		}, nil
+	}
+	return GetBook200NegotiatedResponse{
+		Body:    book,
+		Headers: GetBook200ResponseHeaders{XEdition: 1},
+	}, nil
+}
+
+// Codecs returns the default codecs, with one for `text/csv`, which writes
+// a book as a header row and a row of its fields.
+func Codecs() map[string]StrictHTTPCodec {
+	codecs := DefaultStrictHTTPCodecs()
+	codecs["text/csv"] = StrictHTTPCodecFunc(func(w io.Writer, body interface{}) error {
+		book, ok := body.(Book)
+		if !ok {
+			return fmt.Errorf("can't encode %T as CSV", body)
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll([][]string{{"isbn", "title"}, {book.Isbn, book.Title}}); err != nil {
+			return err
+		}
+		return writer.Error()
+	})
+	return codecs
+}
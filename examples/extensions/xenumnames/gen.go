@@ -3,24 +3,118 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
 package xenumnames
 
+import (
+	"fmt"
+)
+
 // Defines values for ClientType.
 const (
 	ACT ClientType = "ACT"
 	EXP ClientType = "EXP"
 )
 
+// Valid indicates whether the value is one of the values defined for ClientType.
+func (e ClientType) Valid() bool {
+	switch e {
+	case ACT, EXP:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllClientTypeValues returns each of the values defined for ClientType.
+func AllClientTypeValues() []ClientType {
+	return []ClientType{
+		ACT,
+		EXP,
+	}
+}
+
+// ParseClientType returns the ClientType value which is represented by s, or an error if s isn't one of its values.
+func ParseClientType(s string) (ClientType, error) {
+	switch s {
+	case "ACT":
+		return ACT, nil
+	case "EXP":
+		return EXP, nil
+	}
+	var zero ClientType
+	return zero, fmt.Errorf("%q is not a valid ClientType, it must be one of %v", s, AllClientTypeValues())
+}
+
 // Defines values for ClientTypeWithNamesExtension.
 const (
 	ClientTypeWithNamesExtensionActive  ClientTypeWithNamesExtension = "ACT"
 	ClientTypeWithNamesExtensionExpired ClientTypeWithNamesExtension = "EXP"
 )
 
+// Valid indicates whether the value is one of the values defined for ClientTypeWithNamesExtension.
+func (e ClientTypeWithNamesExtension) Valid() bool {
+	switch e {
+	case ClientTypeWithNamesExtensionActive, ClientTypeWithNamesExtensionExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllClientTypeWithNamesExtensionValues returns each of the values defined for ClientTypeWithNamesExtension.
+func AllClientTypeWithNamesExtensionValues() []ClientTypeWithNamesExtension {
+	return []ClientTypeWithNamesExtension{
+		ClientTypeWithNamesExtensionActive,
+		ClientTypeWithNamesExtensionExpired,
+	}
+}
+
+// ParseClientTypeWithNamesExtension returns the ClientTypeWithNamesExtension value which is represented by s, or an error if s isn't one of its values.
+func ParseClientTypeWithNamesExtension(s string) (ClientTypeWithNamesExtension, error) {
+	switch s {
+	case "ACT":
+		return ClientTypeWithNamesExtensionActive, nil
+	case "EXP":
+		return ClientTypeWithNamesExtensionExpired, nil
+	}
+	var zero ClientTypeWithNamesExtension
+	return zero, fmt.Errorf("%q is not a valid ClientTypeWithNamesExtension, it must be one of %v", s, AllClientTypeWithNamesExtensionValues())
+}
+
 // Defines values for ClientTypeWithVarNamesExtension.
 const (
 	ClientTypeWithVarNamesExtensionActive  ClientTypeWithVarNamesExtension = "ACT"
 	ClientTypeWithVarNamesExtensionExpired ClientTypeWithVarNamesExtension = "EXP"
 )
 
+// Valid indicates whether the value is one of the values defined for ClientTypeWithVarNamesExtension.
+func (e ClientTypeWithVarNamesExtension) Valid() bool {
+	switch e {
+	case ClientTypeWithVarNamesExtensionActive, ClientTypeWithVarNamesExtensionExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllClientTypeWithVarNamesExtensionValues returns each of the values defined for ClientTypeWithVarNamesExtension.
+func AllClientTypeWithVarNamesExtensionValues() []ClientTypeWithVarNamesExtension {
+	return []ClientTypeWithVarNamesExtension{
+		ClientTypeWithVarNamesExtensionActive,
+		ClientTypeWithVarNamesExtensionExpired,
+	}
+}
+
+// ParseClientTypeWithVarNamesExtension returns the ClientTypeWithVarNamesExtension value which is represented by s, or an error if s isn't one of its values.
+func ParseClientTypeWithVarNamesExtension(s string) (ClientTypeWithVarNamesExtension, error) {
+	switch s {
+	case "ACT":
+		return ClientTypeWithVarNamesExtensionActive, nil
+	case "EXP":
+		return ClientTypeWithVarNamesExtensionExpired, nil
+	}
+	var zero ClientTypeWithVarNamesExtension
+	return zero, fmt.Errorf("%q is not a valid ClientTypeWithVarNamesExtension, it must be one of %v", s, AllClientTypeWithVarNamesExtensionValues())
+}
+
 // ClientType defines model for ClientType.
 type ClientType string
 
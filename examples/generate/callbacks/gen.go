@@ -0,0 +1,635 @@
+// Package callbacks provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Job defines model for Job.
+type Job struct {
+	Id string `json:"id"`
+}
+
+// JobRequest defines model for JobRequest.
+type JobRequest struct {
+	CallbackUrl string `json:"callbackUrl"`
+}
+
+// JobResult defines model for JobResult.
+type JobResult struct {
+	Id        string `json:"id"`
+	Succeeded bool   `json:"succeeded"`
+}
+
+// PutCreateJobOnProgressParams defines parameters for PutCreateJobOnProgress.
+type PutCreateJobOnProgressParams struct {
+	XJobProgress int `json:"X-Job-Progress"`
+}
+
+// CreateJobJSONRequestBody defines body for CreateJob for application/json ContentType.
+type CreateJobJSONRequestBody = JobRequest
+
+// JobCompletedJSONRequestBody defines body for JobCompleted for application/json ContentType.
+type JobCompletedJSONRequestBody = JobResult
+
+// CallbackServerInterface represents all the callbacks that can be received.
+type CallbackServerInterface interface {
+	// The job has completed
+	// (POST {$request.body#/callbackUrl}) callback onComplete of CreateJob
+	JobCompleted(w http.ResponseWriter, r *http.Request)
+
+	// (PUT {$request.body#/callbackUrl}/progress?job={$response.body#/id}) callback onProgress of CreateJob
+	PutCreateJobOnProgress(w http.ResponseWriter, r *http.Request, params PutCreateJobOnProgressParams)
+}
+
+// CallbackHandlerOptions configures the http.Handler for a received callback.
+type CallbackHandlerOptions struct {
+	Middlewares      []func(http.Handler) http.Handler
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// CallbackParamError is passed to the ErrorHandlerFunc when a parameter of a
+// received callback is missing, or can't be bound.
+type CallbackParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *CallbackParamError) Error() string {
+	return fmt.Sprintf("Invalid callback parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *CallbackParamError) Unwrap() error {
+	return e.Err
+}
+
+// callbackServerInterfaceWrapper converts requests to parameters.
+type callbackServerInterfaceWrapper struct {
+	Handler          CallbackServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// JobCompletedCallbackHandler returns an http.Handler that receives the onComplete callback of CreateJob.
+// As the URL of a callback is provided by the caller of CreateJob, it can be mounted on any route.
+func JobCompletedCallbackHandler(si CallbackServerInterface, options CallbackHandlerOptions) http.Handler {
+	return newCallbackHandler(si, options, (*callbackServerInterfaceWrapper).JobCompleted)
+}
+
+// JobCompleted callback middleware
+func (siw *callbackServerInterfaceWrapper) JobCompleted(w http.ResponseWriter, r *http.Request) {
+
+	siw.Handler.JobCompleted(w, r)
+}
+
+// PutCreateJobOnProgressCallbackHandler returns an http.Handler that receives the onProgress callback of CreateJob.
+// As the URL of a callback is provided by the caller of CreateJob, it can be mounted on any route.
+func PutCreateJobOnProgressCallbackHandler(si CallbackServerInterface, options CallbackHandlerOptions) http.Handler {
+	return newCallbackHandler(si, options, (*callbackServerInterfaceWrapper).PutCreateJobOnProgress)
+}
+
+// PutCreateJobOnProgress callback middleware
+func (siw *callbackServerInterfaceWrapper) PutCreateJobOnProgress(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the request
+	var params PutCreateJobOnProgressParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Job-Progress" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Job-Progress")]; found {
+		var XJobProgress int
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &CallbackParamError{ParamName: "X-Job-Progress", Err: fmt.Errorf("Expected one value for X-Job-Progress, got %d", n)})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Job-Progress", valueList[0], &XJobProgress, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &CallbackParamError{ParamName: "X-Job-Progress", Err: err})
+			return
+		}
+
+		params.XJobProgress = XJobProgress
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Job-Progress is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &CallbackParamError{ParamName: "X-Job-Progress", Err: err})
+		return
+	}
+
+	siw.Handler.PutCreateJobOnProgress(w, r, params)
+}
+
+func newCallbackHandler(si CallbackServerInterface, options CallbackHandlerOptions, receive func(*callbackServerInterfaceWrapper, http.ResponseWriter, *http.Request)) http.Handler {
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := &callbackServerInterfaceWrapper{
+		Handler:          si,
+		ErrorHandlerFunc: options.ErrorHandlerFunc,
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receive(wrapper, w, r)
+	}))
+	for _, middleware := range options.Middlewares {
+		handler = middleware(handler)
+	}
+	return handler
+}
+
+type JobCompletedRequestObject struct {
+	Body *JobCompletedJSONRequestBody
+}
+
+type JobCompletedResponseObject interface {
+	VisitJobCompletedResponse(w http.ResponseWriter) error
+}
+
+type JobCompleted200Response struct {
+}
+
+func (response JobCompleted200Response) VisitJobCompletedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PutCreateJobOnProgressRequestObject struct {
+	Params PutCreateJobOnProgressParams
+}
+
+type PutCreateJobOnProgressResponseObject interface {
+	VisitPutCreateJobOnProgressResponse(w http.ResponseWriter) error
+}
+
+type PutCreateJobOnProgress204Response struct {
+}
+
+func (response PutCreateJobOnProgress204Response) VisitPutCreateJobOnProgressResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+// CallbackStrictServerInterface represents all the callbacks that can be received.
+type CallbackStrictServerInterface interface {
+	// The job has completed
+	// (POST {$request.body#/callbackUrl}) callback onComplete of CreateJob
+	JobCompleted(ctx context.Context, request JobCompletedRequestObject) (JobCompletedResponseObject, error)
+
+	// (PUT {$request.body#/callbackUrl}/progress?job={$response.body#/id}) callback onProgress of CreateJob
+	PutCreateJobOnProgress(ctx context.Context, request PutCreateJobOnProgressRequestObject) (PutCreateJobOnProgressResponseObject, error)
+}
+
+type CallbackStrictHandlerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewCallbackStrictHandler(ssi CallbackStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc) CallbackServerInterface {
+	return &callbackStrictHandler{ssi: ssi, middlewares: middlewares, options: CallbackStrictHandlerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewCallbackStrictHandlerWithOptions(ssi CallbackStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc, options CallbackStrictHandlerOptions) CallbackServerInterface {
+	return &callbackStrictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type callbackStrictHandler struct {
+	ssi         CallbackStrictServerInterface
+	middlewares []strictnethttp.StrictHTTPMiddlewareFunc
+	options     CallbackStrictHandlerOptions
+}
+
+// JobCompleted callback middleware
+func (sh *callbackStrictHandler) JobCompleted(w http.ResponseWriter, r *http.Request) {
+	var request JobCompletedRequestObject
+
+	var body JobCompletedJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.JobCompleted(ctx, request.(JobCompletedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "JobCompleted")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(JobCompletedResponseObject); ok {
+		if err := validResponse.VisitJobCompletedResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutCreateJobOnProgress callback middleware
+func (sh *callbackStrictHandler) PutCreateJobOnProgress(w http.ResponseWriter, r *http.Request, params PutCreateJobOnProgressParams) {
+	var request PutCreateJobOnProgressRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutCreateJobOnProgress(ctx, request.(PutCreateJobOnProgressRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutCreateJobOnProgress")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutCreateJobOnProgressResponseObject); ok {
+		if err := validResponse.VisitPutCreateJobOnProgressResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// CallbackClient sends callbacks to the URLs that are resolved from the
+// requests, or responses, of the operations defining them.
+type CallbackClient struct {
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// CallbackClientOption allows setting custom parameters during construction
+type CallbackClientOption func(*CallbackClient) error
+
+// Creates a new CallbackClient, with reasonable defaults
+func NewCallbackClient(opts ...CallbackClientOption) (*CallbackClient, error) {
+	client := CallbackClient{}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithCallbackHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithCallbackHTTPClient(doer HttpRequestDoer) CallbackClientOption {
+	return func(c *CallbackClient) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithCallbackRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request,
+// for instance to sign it.
+func WithCallbackRequestEditorFn(fn RequestEditorFn) CallbackClientOption {
+	return func(c *CallbackClient) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// JobCompletedCallbackExpression is the runtime expression that the URL of the onComplete callback of CreateJob is resolved from.
+const JobCompletedCallbackExpression = "{$request.body#/callbackUrl}"
+
+// PutCreateJobOnProgressCallbackExpression is the runtime expression that the URL of the onProgress callback of CreateJob is resolved from.
+const PutCreateJobOnProgressCallbackExpression = "{$request.body#/callbackUrl}/progress?job={$response.body#/id}"
+
+// CallbackSource holds the request, and optionally the response, of the
+// operation that defines a callback, which are used to resolve the runtime
+// expressions of the callback's URL.
+//
+// See https://spec.openapis.org/oas/v3.0.3#runtime-expressions
+type CallbackSource struct {
+	// Request is the original request, which resolves `$url`, `$method`,
+	// `$request.query.*` and `$request.header.*`
+	Request *http.Request
+
+	// PathParams are the path parameters of the original request, which
+	// resolve `$request.path.*`
+	PathParams map[string]string
+
+	// RequestBody is the body of the original request, which resolves
+	// `$request.body`. It is marshaled as JSON to evaluate any JSON Pointer.
+	RequestBody interface{}
+
+	// StatusCode is the status code of the response, which resolves `$statusCode`
+	StatusCode int
+
+	// ResponseHeader are the headers of the response, which resolve `$response.header.*`
+	ResponseHeader http.Header
+
+	// ResponseBody is the body of the response, which resolves `$response.body`.
+	// It is marshaled as JSON to evaluate any JSON Pointer.
+	ResponseBody interface{}
+}
+
+// ResolveExpression resolves the runtime expressions in the given string, which
+// may either be a single expression, such as `$request.body#/callbackUrl`, or
+// a string with embedded expressions, such as
+// `https://example.com/events?id={$request.query.id}`.
+func (s CallbackSource) ResolveExpression(expression string) (string, error) {
+	if strings.HasPrefix(expression, "$") {
+		return s.resolveExpression(expression)
+	}
+
+	var resolved strings.Builder
+	for {
+		start := strings.Index(expression, "{")
+		if start == -1 {
+			resolved.WriteString(expression)
+			return resolved.String(), nil
+		}
+		end := strings.Index(expression[start:], "}")
+		if end == -1 {
+			return "", fmt.Errorf("unterminated runtime expression in %q", expression)
+		}
+		end += start
+
+		value, err := s.resolveExpression(expression[start+1 : end])
+		if err != nil {
+			return "", err
+		}
+		resolved.WriteString(expression[:start])
+		resolved.WriteString(value)
+		expression = expression[end+1:]
+	}
+}
+
+func (s CallbackSource) resolveExpression(expression string) (string, error) {
+	switch {
+	case expression == "$url":
+		if s.Request == nil {
+			return "", fmt.Errorf("runtime expression %s requires the original request", expression)
+		}
+		u := *s.Request.URL
+		if u.Host == "" {
+			u.Host = s.Request.Host
+			u.Scheme = "http"
+			if s.Request.TLS != nil {
+				u.Scheme = "https"
+			}
+		}
+		return u.String(), nil
+	case expression == "$method":
+		if s.Request == nil {
+			return "", fmt.Errorf("runtime expression %s requires the original request", expression)
+		}
+		return s.Request.Method, nil
+	case expression == "$statusCode":
+		return strconv.Itoa(s.StatusCode), nil
+	case strings.HasPrefix(expression, "$request.query."):
+		if s.Request == nil {
+			return "", fmt.Errorf("runtime expression %s requires the original request", expression)
+		}
+		return s.Request.URL.Query().Get(strings.TrimPrefix(expression, "$request.query.")), nil
+	case strings.HasPrefix(expression, "$request.header."):
+		if s.Request == nil {
+			return "", fmt.Errorf("runtime expression %s requires the original request", expression)
+		}
+		return s.Request.Header.Get(strings.TrimPrefix(expression, "$request.header.")), nil
+	case strings.HasPrefix(expression, "$request.path."):
+		name := strings.TrimPrefix(expression, "$request.path.")
+		if value, ok := s.PathParams[name]; ok {
+			return value, nil
+		}
+		return "", fmt.Errorf("runtime expression %s refers to an unknown path parameter", expression)
+	case expression == "$request.body" || strings.HasPrefix(expression, "$request.body#"):
+		return resolveCallbackBodyPointer(s.RequestBody, strings.TrimPrefix(expression, "$request.body"))
+	case strings.HasPrefix(expression, "$response.header."):
+		return s.ResponseHeader.Get(strings.TrimPrefix(expression, "$response.header.")), nil
+	case expression == "$response.body" || strings.HasPrefix(expression, "$response.body#"):
+		return resolveCallbackBodyPointer(s.ResponseBody, strings.TrimPrefix(expression, "$response.body"))
+	}
+	return "", fmt.Errorf("unsupported runtime expression %s", expression)
+}
+
+// resolveCallbackBodyPointer evaluates the JSON Pointer fragment, such as
+// `#/callbackUrl`, against the JSON representation of body.
+func resolveCallbackBodyPointer(body interface{}, fragment string) (string, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err := json.Unmarshal(buf, &value); err != nil {
+		return "", err
+	}
+
+	pointer := strings.TrimPrefix(fragment, "#")
+	if pointer != "" {
+		if !strings.HasPrefix(pointer, "/") {
+			return "", fmt.Errorf("invalid JSON Pointer %q", pointer)
+		}
+		for _, token := range strings.Split(pointer[1:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			switch v := value.(type) {
+			case map[string]interface{}:
+				field, ok := v[token]
+				if !ok {
+					return "", fmt.Errorf("JSON Pointer %q refers to an unknown field %q", pointer, token)
+				}
+				value = field
+			case []interface{}:
+				index, err := strconv.Atoi(token)
+				if err != nil || index < 0 || index >= len(v) {
+					return "", fmt.Errorf("JSON Pointer %q refers to an unknown index %q", pointer, token)
+				}
+				value = v[index]
+			default:
+				return "", fmt.Errorf("JSON Pointer %q can't be evaluated against %T", pointer, value)
+			}
+		}
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	buf, err = json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// The interface specification for the callback client above.
+type CallbackClientInterface interface {
+	// JobCompletedWithBody sends the onComplete callback of CreateJob to the URL resolved from source with any body
+	JobCompletedWithBody(ctx context.Context, source CallbackSource, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	JobCompleted(ctx context.Context, source CallbackSource, body JobCompletedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutCreateJobOnProgress sends the onProgress callback of CreateJob to the URL resolved from source
+	PutCreateJobOnProgress(ctx context.Context, source CallbackSource, params *PutCreateJobOnProgressParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *CallbackClient) JobCompletedWithBody(ctx context.Context, source CallbackSource, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	callbackURL, err := source.ResolveExpression(JobCompletedCallbackExpression)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewJobCompletedCallbackRequestWithBody(callbackURL, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *CallbackClient) JobCompleted(ctx context.Context, source CallbackSource, body JobCompletedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	callbackURL, err := source.ResolveExpression(JobCompletedCallbackExpression)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewJobCompletedCallbackRequest(callbackURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *CallbackClient) PutCreateJobOnProgress(ctx context.Context, source CallbackSource, params *PutCreateJobOnProgressParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	callbackURL, err := source.ResolveExpression(PutCreateJobOnProgressCallbackExpression)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewPutCreateJobOnProgressCallbackRequest(callbackURL, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewJobCompletedCallbackRequest calls the generic JobCompleted builder with application/json body
+func NewJobCompletedCallbackRequest(callbackURL string, body JobCompletedJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewJobCompletedCallbackRequestWithBody(callbackURL, "application/json", bodyReader)
+}
+
+// NewJobCompletedCallbackRequestWithBody generates requests sending the JobCompleted callback to callbackURL with any type of body
+func NewJobCompletedCallbackRequestWithBody(callbackURL string, contentType string, body io.Reader) (*http.Request, error) {
+	queryURL, err := url.Parse(callbackURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutCreateJobOnProgressCallbackRequest generates requests sending the PutCreateJobOnProgress callback to callbackURL
+func NewPutCreateJobOnProgressCallbackRequest(callbackURL string, params *PutCreateJobOnProgressParams) (*http.Request, error) {
+	queryURL, err := url.Parse(callbackURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Job-Progress", runtime.ParamLocationHeader, params.XJobProgress)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Job-Progress", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+func (c *CallbackClient) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package callbacks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiver struct {
+	results  []JobResult
+	progress []int
+}
+
+func (r *receiver) JobCompleted(ctx context.Context, request JobCompletedRequestObject) (JobCompletedResponseObject, error) {
+	r.results = append(r.results, *request.Body)
+	return JobCompleted200Response{}, nil
+}
+
+func (r *receiver) PutCreateJobOnProgress(ctx context.Context, request PutCreateJobOnProgressRequestObject) (PutCreateJobOnProgressResponseObject, error) {
+	r.progress = append(r.progress, request.Params.XJobProgress)
+	return PutCreateJobOnProgress204Response{}, nil
+}
+
+func TestCallbacks(t *testing.T) {
+	rcv := &receiver{}
+	si := NewCallbackStrictHandler(rcv, nil)
+
+	var progressJob string
+	mux := http.NewServeMux()
+	mux.Handle("POST /callbacks/jobs", JobCompletedCallbackHandler(si, CallbackHandlerOptions{}))
+	mux.Handle("PUT /callbacks/jobs/progress", PutCreateJobOnProgressCallbackHandler(si, CallbackHandlerOptions{
+		Middlewares: []func(http.Handler) http.Handler{
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					progressJob = r.URL.Query().Get("job")
+					next.ServeHTTP(w, r)
+				})
+			},
+		},
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewCallbackClient()
+	require.NoError(t, err)
+
+	// the original request to CreateJob, and its response
+	source := CallbackSource{
+		Request:      httptest.NewRequest(http.MethodPost, "/jobs", nil),
+		RequestBody:  CreateJobJSONRequestBody{CallbackUrl: server.URL + "/callbacks/jobs"},
+		StatusCode:   http.StatusAccepted,
+		ResponseBody: Job{Id: "job-1"},
+	}
+
+	t.Run("a callback is sent to the URL from the original request", func(t *testing.T) {
+		resp, err := client.JobCompleted(context.Background(), source, JobCompletedJSONRequestBody{Id: "job-1", Succeeded: true})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []JobResult{{Id: "job-1", Succeeded: true}}, rcv.results)
+	})
+
+	t.Run("a callback URL can be resolved from the request and response", func(t *testing.T) {
+		resp, err := client.PutCreateJobOnProgress(context.Background(), source, &PutCreateJobOnProgressParams{XJobProgress: 50})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, []int{50}, rcv.progress)
+		assert.Equal(t, "job-1", progressJob)
+	})
+
+	t.Run("a callback URL can't be resolved without the original request body", func(t *testing.T) {
+		_, err := client.JobCompleted(context.Background(), CallbackSource{}, JobCompletedJSONRequestBody{Id: "job-1"})
+		require.Error(t, err)
+	})
+}
+
+func TestCallbackSource_ResolveExpression(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/jobs?tenant=acme", nil)
+	req.Header.Set("X-Request-Id", "abc")
+
+	source := CallbackSource{
+		Request:     req,
+		PathParams:  map[string]string{"jobId": "job-1"},
+		RequestBody: map[string]interface{}{"urls": []string{"https://a.example.com", "https://b.example.com"}, "a/b": 1},
+		StatusCode:  http.StatusAccepted,
+	}
+
+	tests := map[string]string{
+		"$url":                         "http://example.com/jobs?tenant=acme",
+		"$method":                      "POST",
+		"$statusCode":                  "202",
+		"$request.body#/urls/1":        "https://b.example.com",
+		"$request.body#/a~1b":          "1",
+		"{$request.body#/urls/0}/jobs": "https://a.example.com/jobs",
+		"https://x.example.com/{$request.path.jobId}?t={$request.query.tenant}&r={$request.header.X-Request-Id}": "https://x.example.com/job-1?t=acme&r=abc",
+	}
+	for expression, expected := range tests {
+		t.Run(expression, func(t *testing.T) {
+			resolved, err := source.ResolveExpression(expression)
+			require.NoError(t, err)
+			assert.Equal(t, expected, resolved)
+		})
+	}
+
+	_, err := source.ResolveExpression("$request.body#/unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,758 @@
+// Package fakeclient provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package fakeclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListPetsParams defines parameters for ListPets.
+type ListPetsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = Pet
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListPets request
+	ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPetWithBody request with any body
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPet request
+	GetPet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListPets(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) GetPet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "GetPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string, params *ListPetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody generates requests for AddPet with any type of body
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListPetsWithResponse request
+	ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// AddPetWithBodyWithResponse request with any body
+	AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	// GetPetWithResponse request
+	GetPetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error)
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+// GetPetWithResponse request returning *GetPetResponse
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	rsp, err := c.GetPet(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPetResponse(rsp)
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseAddPetResponse(rsp *http.Response) (*AddPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParseGetPetResponse(rsp *http.Response) (*GetPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ErrFakeClientNotStubbed is returned by the FakeClientWithResponses, when one of its methods is called without a stub.
+var ErrFakeClientNotStubbed = errors.New("the method of the fake client isn't stubbed")
+
+// FakeClientWithResponses is an in-memory implementation of the ClientWithResponsesInterface, for testing code which uses the client without a server.
+//
+// Each method records the arguments it's called with, which are returned by its `<Method>Calls` method, and responds by calling its stub, the `<Method>Func` field, or with ErrFakeClientNotStubbed when it has none.
+type FakeClientWithResponses struct {
+	// ListPetsWithResponseFunc is called by ListPetsWithResponse, to respond to it
+	ListPetsWithResponseFunc func(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+	// AddPetWithBodyWithResponseFunc is called by AddPetWithBodyWithResponse, to respond to it
+	AddPetWithBodyWithResponseFunc func(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+	// AddPetWithResponseFunc is called by AddPetWithResponse, to respond to it
+	AddPetWithResponseFunc func(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+	// GetPetWithResponseFunc is called by GetPetWithResponse, to respond to it
+	GetPetWithResponseFunc func(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error)
+
+	mu                              sync.Mutex
+	listPetsWithResponseCalls       []FakeListPetsWithResponseCall
+	addPetWithBodyWithResponseCalls []FakeAddPetWithBodyWithResponseCall
+	addPetWithResponseCalls         []FakeAddPetWithResponseCall
+	getPetWithResponseCalls         []FakeGetPetWithResponseCall
+}
+
+var _ ClientWithResponsesInterface = (*FakeClientWithResponses)(nil)
+
+// FakeListPetsWithResponseCall holds the arguments of a call to the ListPetsWithResponse method of the FakeClientWithResponses.
+type FakeListPetsWithResponseCall struct {
+	Ctx        context.Context
+	Params     *ListPetsParams
+	ReqEditors []RequestEditorFn
+}
+
+// ListPetsWithResponse records the call, and responds with ListPetsWithResponseFunc.
+func (f *FakeClientWithResponses) ListPetsWithResponse(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	f.mu.Lock()
+	f.listPetsWithResponseCalls = append(f.listPetsWithResponseCalls, FakeListPetsWithResponseCall{
+		Ctx:        ctx,
+		Params:     params,
+		ReqEditors: reqEditors,
+	})
+	stub := f.ListPetsWithResponseFunc
+	f.mu.Unlock()
+
+	if stub == nil {
+		return nil, fmt.Errorf("%w: ListPetsWithResponse", ErrFakeClientNotStubbed)
+	}
+	return stub(ctx, params, reqEditors...)
+}
+
+// ListPetsWithResponseCalls returns the arguments of each of the calls to ListPetsWithResponse, in the order they were made.
+func (f *FakeClientWithResponses) ListPetsWithResponseCalls() []FakeListPetsWithResponseCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeListPetsWithResponseCall(nil), f.listPetsWithResponseCalls...)
+}
+
+// FakeAddPetWithBodyWithResponseCall holds the arguments of a call to the AddPetWithBodyWithResponse method of the FakeClientWithResponses.
+type FakeAddPetWithBodyWithResponseCall struct {
+	Ctx         context.Context
+	ContentType string
+	// Body is the content that was read from the body
+	Body       []byte
+	ReqEditors []RequestEditorFn
+}
+
+// AddPetWithBodyWithResponse records the call, and responds with AddPetWithBodyWithResponseFunc.
+func (f *FakeClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	// the body is read, so that it can be recorded, and then passed to the stub
+	var content []byte
+	if body != nil {
+		var err error
+		content, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f.mu.Lock()
+	f.addPetWithBodyWithResponseCalls = append(f.addPetWithBodyWithResponseCalls, FakeAddPetWithBodyWithResponseCall{
+		Ctx:         ctx,
+		ContentType: contentType,
+		Body:        content,
+		ReqEditors:  reqEditors,
+	})
+	stub := f.AddPetWithBodyWithResponseFunc
+	f.mu.Unlock()
+
+	if stub == nil {
+		return nil, fmt.Errorf("%w: AddPetWithBodyWithResponse", ErrFakeClientNotStubbed)
+	}
+	return stub(ctx, contentType, bytes.NewReader(content), reqEditors...)
+}
+
+// AddPetWithBodyWithResponseCalls returns the arguments of each of the calls to AddPetWithBodyWithResponse, in the order they were made.
+func (f *FakeClientWithResponses) AddPetWithBodyWithResponseCalls() []FakeAddPetWithBodyWithResponseCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeAddPetWithBodyWithResponseCall(nil), f.addPetWithBodyWithResponseCalls...)
+}
+
+// FakeAddPetWithResponseCall holds the arguments of a call to the AddPetWithResponse method of the FakeClientWithResponses.
+type FakeAddPetWithResponseCall struct {
+	Ctx        context.Context
+	Body       AddPetJSONRequestBody
+	ReqEditors []RequestEditorFn
+}
+
+// AddPetWithResponse records the call, and responds with AddPetWithResponseFunc.
+func (f *FakeClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	f.mu.Lock()
+	f.addPetWithResponseCalls = append(f.addPetWithResponseCalls, FakeAddPetWithResponseCall{
+		Ctx:        ctx,
+		Body:       body,
+		ReqEditors: reqEditors,
+	})
+	stub := f.AddPetWithResponseFunc
+	f.mu.Unlock()
+
+	if stub == nil {
+		return nil, fmt.Errorf("%w: AddPetWithResponse", ErrFakeClientNotStubbed)
+	}
+	return stub(ctx, body, reqEditors...)
+}
+
+// AddPetWithResponseCalls returns the arguments of each of the calls to AddPetWithResponse, in the order they were made.
+func (f *FakeClientWithResponses) AddPetWithResponseCalls() []FakeAddPetWithResponseCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeAddPetWithResponseCall(nil), f.addPetWithResponseCalls...)
+}
+
+// FakeGetPetWithResponseCall holds the arguments of a call to the GetPetWithResponse method of the FakeClientWithResponses.
+type FakeGetPetWithResponseCall struct {
+	Ctx        context.Context
+	Id         int64
+	ReqEditors []RequestEditorFn
+}
+
+// GetPetWithResponse records the call, and responds with GetPetWithResponseFunc.
+func (f *FakeClientWithResponses) GetPetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	f.mu.Lock()
+	f.getPetWithResponseCalls = append(f.getPetWithResponseCalls, FakeGetPetWithResponseCall{
+		Ctx:        ctx,
+		Id:         id,
+		ReqEditors: reqEditors,
+	})
+	stub := f.GetPetWithResponseFunc
+	f.mu.Unlock()
+
+	if stub == nil {
+		return nil, fmt.Errorf("%w: GetPetWithResponse", ErrFakeClientNotStubbed)
+	}
+	return stub(ctx, id, reqEditors...)
+}
+
+// GetPetWithResponseCalls returns the arguments of each of the calls to GetPetWithResponse, in the order they were made.
+func (f *FakeClientWithResponses) GetPetWithResponseCalls() []FakeGetPetWithResponseCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeGetPetWithResponseCall(nil), f.getPetWithResponseCalls...)
+}
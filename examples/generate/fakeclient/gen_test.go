@@ -0,0 +1,110 @@
+package fakeclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// petName is the code under test, which only depends on the ClientWithResponsesInterface.
+func petName(ctx context.Context, client ClientWithResponsesInterface, id int64) (string, error) {
+	response, err := client.GetPetWithResponse(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if response.JSON200 == nil {
+		return "", fmt.Errorf("unexpected status %d", response.StatusCode())
+	}
+	return response.JSON200.Name, nil
+}
+
+func TestFakeClientRespondsWithTheStub(t *testing.T) {
+	client := &FakeClientWithResponses{
+		GetPetWithResponseFunc: func(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+			if id != 1 {
+				return &GetPetResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNotFound}}, nil
+			}
+			return &GetPetResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200:      &Pet{Id: id, Name: "Tom"},
+			}, nil
+		},
+	}
+
+	name, err := petName(context.Background(), client, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Tom", name)
+
+	_, err = petName(context.Background(), client, 2)
+	assert.EqualError(t, err, "unexpected status 404")
+
+	calls := client.GetPetWithResponseCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, int64(1), calls[0].Id)
+	assert.Equal(t, int64(2), calls[1].Id)
+}
+
+func TestFakeClientRecordsTheArguments(t *testing.T) {
+	client := &FakeClientWithResponses{
+		ListPetsWithResponseFunc: func(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+			return &ListPetsResponse{JSON200: &[]Pet{}}, nil
+		},
+		AddPetWithResponseFunc: func(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+			return &AddPetResponse{JSON201: &body}, nil
+		},
+	}
+
+	limit := 10
+	editor := func(ctx context.Context, req *http.Request) error { return nil }
+	_, err := client.ListPetsWithResponse(context.Background(), &ListPetsParams{Limit: &limit}, editor)
+	require.NoError(t, err)
+	response, err := client.AddPetWithResponse(context.Background(), AddPetJSONRequestBody{Id: 3, Name: "Rex"})
+	require.NoError(t, err)
+	assert.Equal(t, "Rex", response.JSON201.Name)
+
+	listCalls := client.ListPetsWithResponseCalls()
+	require.Len(t, listCalls, 1)
+	assert.Equal(t, 10, *listCalls[0].Params.Limit)
+	assert.Len(t, listCalls[0].ReqEditors, 1)
+
+	addCalls := client.AddPetWithResponseCalls()
+	require.Len(t, addCalls, 1)
+	assert.Equal(t, Pet{Id: 3, Name: "Rex"}, addCalls[0].Body)
+}
+
+func TestFakeClientRecordsTheContentOfABody(t *testing.T) {
+	client := &FakeClientWithResponses{
+		AddPetWithBodyWithResponseFunc: func(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+			var pet Pet
+			if err := json.NewDecoder(body).Decode(&pet); err != nil {
+				return nil, err
+			}
+			return &AddPetResponse{JSON201: &pet}, nil
+		},
+	}
+
+	response, err := client.AddPetWithBodyWithResponse(context.Background(), "application/json", strings.NewReader(`{"id":3,"name":"Rex"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Rex", response.JSON201.Name)
+
+	calls := client.AddPetWithBodyWithResponseCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "application/json", calls[0].ContentType)
+	assert.JSONEq(t, `{"id":3,"name":"Rex"}`, string(calls[0].Body))
+}
+
+func TestFakeClientWithoutAStub(t *testing.T) {
+	client := &FakeClientWithResponses{}
+
+	_, err := client.GetPetWithResponse(context.Background(), 1)
+	assert.True(t, errors.Is(err, ErrFakeClientNotStubbed))
+	assert.Len(t, client.GetPetWithResponseCalls(), 1)
+}
@@ -0,0 +1,673 @@
+//go:build go1.22
+
+// Package mockserver provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for PetKind.
+const (
+	Cat PetKind = "cat"
+	Dog PetKind = "dog"
+)
+
+// Valid indicates whether the value is one of the values defined for PetKind.
+func (e PetKind) Valid() bool {
+	switch e {
+	case Cat, Dog:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllPetKindValues returns each of the values defined for PetKind.
+func AllPetKindValues() []PetKind {
+	return []PetKind{
+		Cat,
+		Dog,
+	}
+}
+
+// ParsePetKind returns the PetKind value which is represented by s, or an error if s isn't one of its values.
+func ParsePetKind(s string) (PetKind, error) {
+	switch s {
+	case "cat":
+		return Cat, nil
+	case "dog":
+		return Dog, nil
+	}
+	var zero PetKind
+	return zero, fmt.Errorf("%q is not a valid PetKind, it must be one of %v", s, AllPetKindValues())
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Born *openapi_types.Date `json:"born,omitempty"`
+	Id   int64               `json:"id"`
+	Kind *PetKind            `json:"kind,omitempty"`
+	Name string              `json:"name"`
+}
+
+// PetKind defines model for Pet.Kind.
+type PetKind string
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = Pet
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/{id})
+	GetPet(w http.ResponseWriter, r *http.Request, id int64)
+
+	// (GET /pets/{id}/name)
+	GetPetName(w http.ResponseWriter, r *http.Request, id int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPet operation middleware
+func (siw *ServerInterfaceWrapper) GetPet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPetName operation middleware
+func (siw *ServerInterfaceWrapper) GetPetName(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPetName(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/pets", wrapper.ListPets)
+	m.HandleFunc("POST "+options.BaseURL+"/pets", wrapper.AddPet)
+	m.HandleFunc("GET "+options.BaseURL+"/pets/{id}", wrapper.GetPet)
+	m.HandleFunc("GET "+options.BaseURL+"/pets/{id}/name", wrapper.GetPetName)
+
+	return m
+}
+
+type ListPetsRequestObject struct {
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200JSONResponse []Pet
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPetRequestObject struct {
+	Body *AddPetJSONRequestBody
+}
+
+type AddPetResponseObject interface {
+	VisitAddPetResponse(w http.ResponseWriter) error
+}
+
+type AddPet201ResponseHeaders struct {
+	Location string
+}
+
+type AddPet201Response struct {
+	Headers AddPet201ResponseHeaders
+}
+
+func (response AddPet201Response) VisitAddPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+	return nil
+}
+
+type GetPetRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetPetResponseObject interface {
+	VisitGetPetResponse(w http.ResponseWriter) error
+}
+
+type GetPet200JSONResponse Pet
+
+func (response GetPet200JSONResponse) VisitGetPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPet404JSONResponse Error
+
+func (response GetPet404JSONResponse) VisitGetPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPetNameRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetPetNameResponseObject interface {
+	VisitGetPetNameResponse(w http.ResponseWriter) error
+}
+
+type GetPetName200TextResponse string
+
+func (response GetPetName200TextResponse) VisitGetPetNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error)
+
+	// (GET /pets/{id})
+	GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error)
+
+	// (GET /pets/{id}/name)
+	GetPetName(ctx context.Context, request GetPetNameRequestObject) (GetPetNameResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(w http.ResponseWriter, r *http.Request) {
+	var request ListPetsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPets(ctx, request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		if err := validResponse.VisitListPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPet operation middleware
+func (sh *strictHandler) AddPet(w http.ResponseWriter, r *http.Request) {
+	var request AddPetRequestObject
+
+	var body AddPetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPet(ctx, request.(AddPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPetResponseObject); ok {
+		if err := validResponse.VisitAddPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPet operation middleware
+func (sh *strictHandler) GetPet(w http.ResponseWriter, r *http.Request, id int64) {
+	var request GetPetRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPet(ctx, request.(GetPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPetResponseObject); ok {
+		if err := validResponse.VisitGetPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPetName operation middleware
+func (sh *strictHandler) GetPetName(w http.ResponseWriter, r *http.Request, id int64) {
+	var request GetPetNameRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPetName(ctx, request.(GetPetNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPetName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPetNameResponseObject); ok {
+		if err := validResponse.VisitGetPetNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MockServer implements the StrictServerInterface by responding to each request with the examples of its operation's responses, or with values built from their schemas where they have no examples.
+//
+// An operation responds with its first successful response, unless the request prefers one of its examples by name, with the `Prefer: example=<name>` header, which is read by the MockServerMiddleware, or with WithMockExample.
+type MockServer struct{}
+
+var _ StrictServerInterface = (*MockServer)(nil)
+
+// NewMockServer returns a MockServer, which can be used as a stand-in for the real implementation of the StrictServerInterface.
+func NewMockServer() *MockServer {
+	return &MockServer{}
+}
+
+type mockExampleContextKey struct{}
+
+// WithMockExample returns a copy of ctx, which makes the MockServer respond with the example that has the given name, when its operation has one.
+func WithMockExample(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, mockExampleContextKey{}, name)
+}
+
+// mockPreferredExample returns the name of the example that's preferred by the `Prefer` header, such as `Prefer: example=notFound`.
+func mockPreferredExample(prefer string) (string, bool) {
+	for _, preference := range strings.Split(prefer, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(preference), "=")
+		if found && strings.EqualFold(strings.TrimSpace(name), "example") {
+			return strings.Trim(strings.TrimSpace(value), `"`), true
+		}
+	}
+	return "", false
+}
+
+// MockServerMiddleware makes the MockServer respond with the example that's preferred by the `Prefer` header of each request.
+func MockServerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := mockPreferredExample(r.Header.Get("Prefer")); ok {
+			r = r.WithContext(WithMockExample(r.Context(), name))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// mockResponse is a response of the MockServer, which implements each of the operations' response objects.
+type mockResponse struct {
+	example     string
+	statusCode  int
+	contentType string
+	headers     map[string]string
+	body        string
+}
+
+// mockSelectResponse returns the response with the example that the request prefers, or otherwise the first response.
+func mockSelectResponse(ctx context.Context, operationID string, responses []mockResponse) (mockResponse, error) {
+	if len(responses) == 0 {
+		return mockResponse{}, fmt.Errorf("the operation %s has no responses that can be mocked", operationID)
+	}
+	if name, ok := ctx.Value(mockExampleContextKey{}).(string); ok {
+		for _, response := range responses {
+			if response.example == name {
+				return response, nil
+			}
+		}
+	}
+	return responses[0], nil
+}
+
+func (response mockResponse) write(w http.ResponseWriter) error {
+	for name, value := range response.headers {
+		w.Header().Set(name, value)
+	}
+	if response.contentType != "" {
+		w.Header().Set("Content-Type", response.contentType)
+	}
+	w.WriteHeader(response.statusCode)
+	_, err := io.WriteString(w, response.body)
+	return err
+}
+
+// mockListPetsResponses are the responses of the ListPets operation, in order of preference.
+var mockListPetsResponses = []mockResponse{
+	{example: "cats", statusCode: 200, contentType: "application/json", body: `[{"id":1,"kind":"cat","name":"Tom"},{"id":2,"kind":"cat","name":"Felix"}]`},
+	{example: "none", statusCode: 200, contentType: "application/json", body: `[]`},
+}
+
+// ListPets responds with one of the mockListPetsResponses.
+func (m *MockServer) ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error) {
+	return mockSelectResponse(ctx, "ListPets", mockListPetsResponses)
+}
+
+func (response mockResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+// mockAddPetResponses are the responses of the AddPet operation, in order of preference.
+var mockAddPetResponses = []mockResponse{
+	{statusCode: 201, headers: map[string]string{"Location": "/pets/3"}},
+}
+
+// AddPet responds with one of the mockAddPetResponses.
+func (m *MockServer) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return mockSelectResponse(ctx, "AddPet", mockAddPetResponses)
+}
+
+func (response mockResponse) VisitAddPetResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+// mockGetPetResponses are the responses of the GetPet operation, in order of preference.
+var mockGetPetResponses = []mockResponse{
+	{statusCode: 200, contentType: "application/json", body: `{"born":"2024-01-01","id":1,"kind":"cat","name":"string"}`},
+	{example: "notFound", statusCode: 404, contentType: "application/json", body: `{"message":"There's no pet with that ID"}`},
+}
+
+// GetPet responds with one of the mockGetPetResponses.
+func (m *MockServer) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	return mockSelectResponse(ctx, "GetPet", mockGetPetResponses)
+}
+
+func (response mockResponse) VisitGetPetResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+// mockGetPetNameResponses are the responses of the GetPetName operation, in order of preference.
+var mockGetPetNameResponses = []mockResponse{
+	{statusCode: 200, contentType: "text/plain", body: `Tom`},
+}
+
+// GetPetName responds with one of the mockGetPetNameResponses.
+func (m *MockServer) GetPetName(ctx context.Context, request GetPetNameRequestObject) (GetPetNameResponseObject, error) {
+	return mockSelectResponse(ctx, "GetPetName", mockGetPetNameResponses)
+}
+
+func (response mockResponse) VisitGetPetNameResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
@@ -0,0 +1,97 @@
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve makes the request to the mock server, with the Prefer header when prefer isn't empty.
+func serve(t *testing.T, method string, target string, body string, prefer string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := MockServerMiddleware(Handler(NewStrictHandler(NewMockServer(), nil)))
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if prefer != "" {
+		req.Header.Set("Prefer", prefer)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMockServerRespondsWithTheFirstExample(t *testing.T) {
+	rec := serve(t, http.MethodGet, "/pets", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var pets []Pet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pets))
+	require.Len(t, pets, 2)
+	assert.Equal(t, "Tom", pets[0].Name)
+	assert.Equal(t, "Felix", pets[1].Name)
+}
+
+func TestMockServerRespondsWithThePreferredExample(t *testing.T) {
+	rec := serve(t, http.MethodGet, "/pets", "", "example=none")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	rec = serve(t, http.MethodGet, "/pets/1", "", `respond-async, example="notFound"`)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message":"There's no pet with that ID"}`, rec.Body.String())
+}
+
+func TestMockServerIgnoresAnUnknownExample(t *testing.T) {
+	rec := serve(t, http.MethodGet, "/pets", "", "example=unknown")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var pets []Pet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pets))
+	assert.Len(t, pets, 2)
+}
+
+func TestMockServerBuildsResponsesFromTheSchema(t *testing.T) {
+	rec := serve(t, http.MethodGet, "/pets/1", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var pet Pet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pet))
+	assert.Equal(t, int64(1), pet.Id)
+	assert.Equal(t, "string", pet.Name)
+	require.NotNil(t, pet.Kind)
+	assert.True(t, pet.Kind.Valid())
+	require.NotNil(t, pet.Born)
+	assert.Equal(t, "2024-01-01", pet.Born.String())
+}
+
+func TestMockServerRespondsWithHeaders(t *testing.T) {
+	rec := serve(t, http.MethodPost, "/pets", `{"id":3,"name":"Rex"}`, "")
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/pets/3", rec.Header().Get("Location"))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestMockServerRespondsWithText(t *testing.T) {
+	rec := serve(t, http.MethodGet, "/pets/1/name", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Tom", rec.Body.String())
+}
+
+func TestWithMockExample(t *testing.T) {
+	response, err := NewMockServer().GetPet(WithMockExample(context.Background(), "notFound"), GetPetRequestObject{Id: 1})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, response.VisitGetPetResponse(rec))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -47,6 +47,32 @@ const ServerUrlTheProductionAPIServerPortVariable8443 ServerUrlTheProductionAPIS
 // ServerUrlTheProductionAPIServerPortVariable443 is one of the accepted values for the `port` variable for ServerUrlTheProductionAPIServer
 const ServerUrlTheProductionAPIServerPortVariable443 ServerUrlTheProductionAPIServerPortVariable = "443"
 
+// Valid indicates whether the value is one of the accepted values for the `port` variable for ServerUrlTheProductionAPIServer
+func (e ServerUrlTheProductionAPIServerPortVariable) Valid() bool {
+	switch e {
+	case ServerUrlTheProductionAPIServerPortVariable8443, ServerUrlTheProductionAPIServerPortVariable443:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllServerUrlTheProductionAPIServerPortVariableValues returns each of the accepted values for the `port` variable for ServerUrlTheProductionAPIServer
+func AllServerUrlTheProductionAPIServerPortVariableValues() []ServerUrlTheProductionAPIServerPortVariable {
+	return []ServerUrlTheProductionAPIServerPortVariable{
+		ServerUrlTheProductionAPIServerPortVariable8443,
+		ServerUrlTheProductionAPIServerPortVariable443,
+	}
+}
+
+// ParseServerUrlTheProductionAPIServerPortVariable returns the ServerUrlTheProductionAPIServerPortVariable which is represented by s, or an error if s isn't one of the accepted values for the `port` variable for ServerUrlTheProductionAPIServer
+func ParseServerUrlTheProductionAPIServerPortVariable(s string) (ServerUrlTheProductionAPIServerPortVariable, error) {
+	if v := ServerUrlTheProductionAPIServerPortVariable(s); v.Valid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("%q is not a valid ServerUrlTheProductionAPIServerPortVariable, it must be one of %v", s, AllServerUrlTheProductionAPIServerPortVariableValues())
+}
+
 // ServerUrlTheProductionAPIServerPortVariableDefault is the default choice, for the accepted values for the `port` variable for ServerUrlTheProductionAPIServer
 const ServerUrlTheProductionAPIServerPortVariableDefault ServerUrlTheProductionAPIServerPortVariable = ServerUrlTheProductionAPIServerPortVariable8443
 
@@ -62,7 +88,9 @@ func NewServerUrlTheProductionAPIServer(basePath ServerUrlTheProductionAPIServer
 
 	u = strings.ReplaceAll(u, "{basePath}", string(basePath))
 	u = strings.ReplaceAll(u, "{noDefault}", string(noDefault))
-	// TODO in the future, this will validate that the value is part of the ServerUrlTheProductionAPIServerPortVariable enum
+	if !port.Valid() {
+		return "", fmt.Errorf("%q is not an accepted value for the `port` variable, it must be one of %v", port, AllServerUrlTheProductionAPIServerPortVariableValues())
+	}
 	u = strings.ReplaceAll(u, "{port}", string(port))
 	u = strings.ReplaceAll(u, "{username}", string(username))
 
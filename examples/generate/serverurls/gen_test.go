@@ -9,29 +9,31 @@ import (
 )
 
 func TestServerUrlTheProductionAPIServer(t *testing.T) {
-	t.Run("when no values are provided, it does not error", func(t *testing.T) {
-		serverUrl, err := NewServerUrlTheProductionAPIServer("", "", "", "")
+	t.Run("when no values are provided for the variables without an enum, it does not error", func(t *testing.T) {
+		serverUrl, err := NewServerUrlTheProductionAPIServer("", "", ServerUrlTheProductionAPIServerPortVariable443, "")
 		require.NoError(t, err)
 
-		assert.Equal(t, "https://.gigantic-server.com:/", serverUrl)
+		assert.Equal(t, "https://.gigantic-server.com:443/", serverUrl)
 
 		// NOTE that ideally this should fail as it doesn't /seem/ to provide a valid URL, but it does seem to be valid
 		_, err = url.Parse(serverUrl)
 		require.NoError(t, err)
 	})
 
-	// TODO:when we validate enums, this will need more testing https://github.com/oapi-codegen/oapi-codegen/issues/2006
-	t.Run("when values that are not part of the enum are provided, it does not error", func(t *testing.T) {
+	t.Run("when no value is provided for a variable with an enum, it errors", func(t *testing.T) {
+		_, err := NewServerUrlTheProductionAPIServer("", "", "", "")
+		require.EqualError(t, err, "\"\" is not an accepted value for the `port` variable, it must be one of [8443 443]")
+	})
+
+	t.Run("when values that are not part of the enum are provided, it errors", func(t *testing.T) {
 		invalidPort := ServerUrlTheProductionAPIServerPortVariable("12345")
-		serverUrl, err := NewServerUrlTheProductionAPIServer(
+		_, err := NewServerUrlTheProductionAPIServer(
 			ServerUrlTheProductionAPIServerBasePathVariableDefault,
 			ServerUrlTheProductionAPIServerNoDefaultVariable(""),
 			invalidPort,
 			ServerUrlTheProductionAPIServerUsernameVariableDefault,
 		)
-		require.NoError(t, err)
-
-		assert.Equal(t, "https://demo.gigantic-server.com:12345/v2", serverUrl)
+		require.EqualError(t, err, "\"12345\" is not an accepted value for the `port` variable, it must be one of [8443 443]")
 	})
 
 	t.Run("when default values are provided, it does not error", func(t *testing.T) {
@@ -46,3 +48,21 @@ func TestServerUrlTheProductionAPIServer(t *testing.T) {
 		assert.Equal(t, "https://demo.gigantic-server.com:8443/v2", serverUrl)
 	})
 }
+
+func TestServerUrlTheProductionAPIServerPortVariable(t *testing.T) {
+	t.Run("the values of the enum are valid", func(t *testing.T) {
+		for _, port := range AllServerUrlTheProductionAPIServerPortVariableValues() {
+			assert.True(t, port.Valid(), port)
+		}
+		assert.False(t, ServerUrlTheProductionAPIServerPortVariable("12345").Valid())
+	})
+
+	t.Run("a value of the enum can be parsed", func(t *testing.T) {
+		port, err := ParseServerUrlTheProductionAPIServerPortVariable("443")
+		require.NoError(t, err)
+		assert.Equal(t, ServerUrlTheProductionAPIServerPortVariable443, port)
+
+		_, err = ParseServerUrlTheProductionAPIServerPortVariable("12345")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,719 @@
+//go:build go1.22
+
+// Package embeddedspec provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package embeddedspec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// NewPet defines model for NewPet.
+type NewPet struct {
+	Age  *int      `json:"age,omitempty"`
+	Name string    `json:"name"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Age  *int      `json:"age,omitempty"`
+	Id   int64     `json:"id"`
+	Name string    `json:"name"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// ListPetsParams defines parameters for ListPets.
+type ListPetsParams struct {
+	Limit *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Tag   *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = NewPet
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/{id})
+	GetPet(w http.ResponseWriter, r *http.Request, id int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPetsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPet operation middleware
+func (siw *ServerInterfaceWrapper) GetPet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/pets", wrapper.ListPets)
+	m.HandleFunc("POST "+options.BaseURL+"/pets", wrapper.AddPet)
+	m.HandleFunc("GET "+options.BaseURL+"/pets/{id}", wrapper.GetPet)
+
+	return m
+}
+
+type ListPetsRequestObject struct {
+	Params ListPetsParams
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200JSONResponse []Pet
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPetRequestObject struct {
+	Body *AddPetJSONRequestBody
+}
+
+type AddPetResponseObject interface {
+	VisitAddPetResponse(w http.ResponseWriter) error
+}
+
+type AddPet201JSONResponse Pet
+
+func (response AddPet201JSONResponse) VisitAddPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPetRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetPetResponseObject interface {
+	VisitGetPetResponse(w http.ResponseWriter) error
+}
+
+type GetPet200JSONResponse Pet
+
+func (response GetPet200JSONResponse) VisitGetPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error)
+
+	// (GET /pets/{id})
+	GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams) {
+	var request ListPetsRequestObject
+
+	request.Params = params
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "ListPets", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPets(ctx, request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		if err := validResponse.VisitListPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPet operation middleware
+func (sh *strictHandler) AddPet(w http.ResponseWriter, r *http.Request) {
+	var request AddPetRequestObject
+
+	var body AddPetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "AddPet", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPet(ctx, request.(AddPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPetResponseObject); ok {
+		if err := validResponse.VisitAddPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPet operation middleware
+func (sh *strictHandler) GetPet(w http.ResponseWriter, r *http.Request, id int64) {
+	var request GetPetRequestObject
+
+	request.Id = id
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "GetPet", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPet(ctx, request.(GetPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPetResponseObject); ok {
+		if err := validResponse.VisitGetPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestValidationError is passed to the RequestErrorHandlerFunc, or returned to the ErrorHandler of the framework, when the parameters or body of a request don't satisfy the constraints of their schemas.
+type RequestValidationError struct {
+	// OperationID is the ID of the operation which the request was made to
+	OperationID string
+	// Errors describes each of the constraints that aren't satisfied
+	Errors ValidationErrors
+}
+
+func (e *RequestValidationError) Error() string {
+	return fmt.Sprintf("request to %s is invalid: %s", e.OperationID, e.Errors.Error())
+}
+
+func (e *RequestValidationError) Unwrap() error {
+	return e.Errors
+}
+
+// validate checks that the parameters and body of the ListPetsRequestObject satisfy the constraints of their schemas.
+func (request ListPetsRequestObject) validate() ValidationErrors {
+	return validateRequestWithSpec("GET", "/pets", map[string]interface{}{
+		"query.limit": request.Params.Limit,
+		"query.tag":   request.Params.Tag,
+	}, map[string]interface{}{})
+}
+
+// validate checks that the parameters and body of the AddPetRequestObject satisfy the constraints of their schemas.
+func (request AddPetRequestObject) validate() ValidationErrors {
+	return validateRequestWithSpec("POST", "/pets", map[string]interface{}{}, map[string]interface{}{
+		"application/json": request.Body,
+	})
+}
+
+// validate checks that the parameters and body of the GetPetRequestObject satisfy the constraints of their schemas.
+func (request GetPetRequestObject) validate() ValidationErrors {
+	return validateRequestWithSpec("GET", "/pets/{id}", map[string]interface{}{
+		"path.id": request.Id,
+	}, map[string]interface{}{})
+}
+
+// ValidationError describes a value which doesn't satisfy a constraint of its schema.
+type ValidationError struct {
+	// Field is the path to the value, such as `pets[0].name`, or is empty for the value that was validated
+	Field string
+	// Message describes the constraint that isn't satisfied
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is returned by the Validate methods, and describes each of the constraints that aren't satisfied.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+var requestValidationSpec struct {
+	once    sync.Once
+	swagger *openapi3.T
+	err     error
+}
+
+// validateRequestWithSpec validates each of the parameters and bodies of a request, by the key of where they're found, such as `query.limit`, or their content type, against the schemas of the operation in the embedded specification.
+func validateRequestWithSpec(method string, path string, params map[string]interface{}, bodies map[string]interface{}) ValidationErrors {
+	requestValidationSpec.once.Do(func() {
+		requestValidationSpec.swagger, requestValidationSpec.err = GetSwagger()
+	})
+	if requestValidationSpec.err != nil {
+		return ValidationErrors{{Message: fmt.Sprintf("can't load the embedded specification: %s", requestValidationSpec.err)}}
+	}
+
+	pathItem := requestValidationSpec.swagger.Paths.Find(path)
+	if pathItem == nil || pathItem.GetOperation(method) == nil {
+		return ValidationErrors{{Message: fmt.Sprintf("can't find the operation %s %s in the embedded specification", method, path)}}
+	}
+	operation := pathItem.GetOperation(method)
+
+	// parameters of the operation override those of its path
+	schemas := make(map[string]*openapi3.Parameter)
+	for _, parameters := range []openapi3.Parameters{pathItem.Parameters, operation.Parameters} {
+		for _, parameter := range parameters {
+			if parameter.Value != nil {
+				schemas[parameter.Value.In+"."+parameter.Value.Name] = parameter.Value
+			}
+		}
+	}
+
+	var errs ValidationErrors
+	for _, key := range sortedRequestValidationKeys(params) {
+		if parameter, found := schemas[key]; found && parameter.Schema != nil {
+			errs = appendSpecValidationErrors(errs, parameter.Name, parameter.Schema.Value, params[key])
+		}
+	}
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		for _, contentType := range sortedRequestValidationKeys(bodies) {
+			if mediaType := operation.RequestBody.Value.Content.Get(contentType); mediaType != nil && mediaType.Schema != nil {
+				errs = appendSpecValidationErrors(errs, "body", mediaType.Schema.Value, bodies[contentType])
+			}
+		}
+	}
+	return errs
+}
+
+// appendSpecValidationErrors appends the errors from validating value, found at field, against schema to errs.
+func appendSpecValidationErrors(errs ValidationErrors, field string, schema *openapi3.Schema, value interface{}) ValidationErrors {
+	if schema == nil {
+		return errs
+	}
+	if v := reflect.ValueOf(value); !v.IsValid() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return errs
+	}
+
+	// the schema is validated against the JSON representation of the value
+	data, err := json.Marshal(value)
+	if err != nil {
+		return append(errs, ValidationError{Field: field, Message: err.Error()})
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return append(errs, ValidationError{Field: field, Message: err.Error()})
+	}
+
+	return appendSchemaErrors(errs, field, schema.VisitJSON(decoded, openapi3.MultiErrors()))
+}
+
+// appendSchemaErrors appends each of the errors returned by validating a schema to errs, with the path to the value that they're for.
+func appendSchemaErrors(errs ValidationErrors, field string, err error) ValidationErrors {
+	switch e := err.(type) {
+	case nil:
+		return errs
+	case openapi3.MultiError:
+		for _, err := range e {
+			errs = appendSchemaErrors(errs, field, err)
+		}
+		return errs
+	case *openapi3.SchemaError:
+		path := field
+		for _, segment := range e.JSONPointer() {
+			if _, err := strconv.Atoi(segment); err == nil {
+				path += "[" + segment + "]"
+			} else {
+				path += "." + segment
+			}
+		}
+		return append(errs, ValidationError{Field: path, Message: e.Reason})
+	default:
+		return append(errs, ValidationError{Field: field, Message: err.Error()})
+	}
+}
+
+// sortedRequestValidationKeys returns the keys of m, in order, so that errors are reported consistently.
+func sortedRequestValidationKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/6yTTW/bPAyA/4rBt7fXq5122MG37TIUKLYC263IAM1iHBa2pEpM2izQfx8oucmSeMG+",
+	"LrFsURT58MkWWjs4a9BwgGYLoV3ioNLyAz7dIcvKeevQM2H6rjqUx0CGhtUATV0CbxxCA2QYO/QQSzBq",
+	"yFHq+RZNx0torupSDr28znbHAnsynZxi1aUriHEI4yXn4gf1fJNDr3e7ynu1gRhL8Pi4Io8amvtcz3wX",
+	"ZL8+YMuSYmxR9f3HBTT3W7jwuIAG/qv2YKqRSjUiieUxE9Lyu7B+UJxBvHkNp1yOiiI9UdI8ShiZhZWU",
+	"TNzL7if21HIR0K/RF5IEAxdr1ZNWTNZACWv0QVYNzC7ry1qasw6NcgQNXKdPJTjFy1Rx5TBPvMsApJuU",
+	"6UZDA7cU+E4C5IRXAzL6kPCQXPC4Qr+BlylDTwMxlKM849izHLM6T318m0IynZNVd5DRKWb0EvjlXr36",
+	"Nv//Ak6EiHPhG5w1IY/lqq7l0VrDaPKcneupTY1WD8GavfMH3p2TIBtwrFssQWNoPTnOQ/i8xCIxTnvO",
+	"hgnMb7WWbNkKDPzO6s1vFfwrsh5ax36F8QTT7J/durtykkbBS8XFkwqF0hq1RMYyu1htScefCvkeOZOa",
+	"0lGk3ptDGo4b/lGkkz/pWTv/1qg/R5XYxPh9ADaFEhKhBQAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
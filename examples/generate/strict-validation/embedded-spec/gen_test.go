@@ -0,0 +1,102 @@
+package embeddedspec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error) {
+	return ListPets200JSONResponse{}, nil
+}
+
+func (server) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	return AddPet201JSONResponse{Id: 1, Name: request.Body.Name}, nil
+}
+
+func (server) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	return GetPet200JSONResponse{Id: request.Id, Name: "Fido"}, nil
+}
+
+// serve makes the request to the strict server, returning the response, and the error that was passed to the RequestErrorHandlerFunc.
+func serve(t *testing.T, method string, target string, body string) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+
+	var requestErr error
+	handler := Handler(NewStrictHandlerWithOptions(server{}, nil, StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			requestErr = err
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			t.Errorf("unexpected response error: %s", err)
+		},
+	}))
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, requestErr
+}
+
+func TestValidRequests(t *testing.T) {
+	rec, err := serve(t, http.MethodGet, "/pets?limit=10&tag=dog", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec, err = serve(t, http.MethodPost, "/pets", `{"name": "Fido", "tags": ["good"]}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec, err = serve(t, http.MethodGet, "/pets/1", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestInvalidParameters(t *testing.T) {
+	rec, err := serve(t, http.MethodGet, "/pets?limit=1000&tag=Dog", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var validationErr *RequestValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "ListPets", validationErr.OperationID)
+	assert.Equal(t, ValidationErrors{
+		{Field: "limit", Message: "number must be at most 100"},
+		{Field: "tag", Message: `string doesn't match the regular expression "^[a-z]+$"`},
+	}, validationErr.Errors)
+}
+
+func TestInvalidPathParameter(t *testing.T) {
+	rec, err := serve(t, http.MethodGet, "/pets/0", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var validationErrs ValidationErrors
+	require.True(t, errors.As(err, &validationErrs))
+	assert.Equal(t, ValidationErrors{
+		{Field: "id", Message: "number must be at least 1"},
+	}, validationErrs)
+}
+
+func TestInvalidBody(t *testing.T) {
+	rec, err := serve(t, http.MethodPost, "/pets", `{"name": "", "age": -1, "tags": ["", "a", "b", "c"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var validationErr *RequestValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "AddPet", validationErr.OperationID)
+	assert.Equal(t, ValidationErrors{
+		{Field: "body.age", Message: "number must be at least 0"},
+		{Field: "body.name", Message: "minimum string length is 1"},
+		{Field: "body.tags", Message: "maximum number of items is 3"},
+		{Field: "body.tags[0]", Message: "minimum string length is 1"},
+	}, validationErr.Errors)
+}
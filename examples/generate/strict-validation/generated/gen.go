@@ -0,0 +1,677 @@
+//go:build go1.22
+
+// Package generated provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package generated
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// NewPet defines model for NewPet.
+type NewPet struct {
+	Age  *int      `json:"age,omitempty"`
+	Name string    `json:"name"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Age  *int      `json:"age,omitempty"`
+	Id   int64     `json:"id"`
+	Name string    `json:"name"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// ListPetsParams defines parameters for ListPets.
+type ListPetsParams struct {
+	Limit *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Tag   *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = NewPet
+
+// ValidationError describes a value which doesn't satisfy a constraint of its schema.
+type ValidationError struct {
+	// Field is the path to the value, such as `pets[0].name`, or is empty for the value that was validated
+	Field string
+	// Message describes the constraint that isn't satisfied
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is returned by the Validate methods, and describes each of the constraints that aren't satisfied.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// appendValidationErrors appends the errors from validating the value at field to errs, prefixing their paths with field.
+func appendValidationErrors(errs ValidationErrors, field string, err error) ValidationErrors {
+	if err == nil {
+		return errs
+	}
+	var nested ValidationErrors
+	if !errors.As(err, &nested) {
+		return append(errs, ValidationError{Field: field, Message: err.Error()})
+	}
+	for _, e := range nested {
+		switch {
+		case e.Field == "":
+			e.Field = field
+		case field == "":
+		case strings.HasPrefix(e.Field, "["):
+			e.Field = field + e.Field
+		default:
+			e.Field = field + "." + e.Field
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// validateValue validates value, if it implements a Validate method.
+func validateValue(value interface{}) error {
+	if validator, ok := value.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// validateUnionElement returns whether an element of a union could be retrieved, and is valid.
+func validateUnionElement[T any](value T, err error) bool {
+	return err == nil && validateValue(value) == nil
+}
+
+var validationPatterns sync.Map
+
+// validatePattern returns whether s matches the regular expression pattern, which is only compiled once.
+func validatePattern(pattern string, s string) bool {
+	re, ok := validationPatterns.Load(pattern)
+	if !ok {
+		re, _ = validationPatterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
+
+// validateMultipleOf returns whether value is a multiple of multipleOf, allowing for the imprecision of floating point numbers.
+func validateMultipleOf(value float64, multipleOf float64) bool {
+	quotient := value / multipleOf
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+
+// validateUniqueItems returns whether none of the items are equal to each other.
+func validateUniqueItems[T any](items []T) bool {
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if reflect.DeepEqual(items[i], items[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Validate checks that the NewPet satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v NewPet) Validate() error {
+	var errs ValidationErrors
+	if v.Age != nil {
+		if *v.Age < 0 {
+			errs = append(errs, ValidationError{Field: "age", Message: "must be greater than or equal to 0"})
+		}
+	}
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at least 1"})
+	}
+	if utf8.RuneCountInString(v.Name) > 20 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at most 20"})
+	}
+	if v.Tags != nil {
+		if len(*v.Tags) > 3 {
+			errs = append(errs, ValidationError{Field: "tags", Message: "must have at most 3 items"})
+		}
+		for i1, v2 := range *v.Tags {
+			if utf8.RuneCountInString(v2) < 1 {
+				errs = append(errs, ValidationError{Field: "tags[" + strconv.Itoa(i1) + "]", Message: "length must be at least 1"})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Pet satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Pet) Validate() error {
+	var errs ValidationErrors
+	if v.Age != nil {
+		if *v.Age < 0 {
+			errs = append(errs, ValidationError{Field: "age", Message: "must be greater than or equal to 0"})
+		}
+	}
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at least 1"})
+	}
+	if utf8.RuneCountInString(v.Name) > 20 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at most 20"})
+	}
+	if v.Tags != nil {
+		if len(*v.Tags) > 3 {
+			errs = append(errs, ValidationError{Field: "tags", Message: "must have at most 3 items"})
+		}
+		for i1, v2 := range *v.Tags {
+			if utf8.RuneCountInString(v2) < 1 {
+				errs = append(errs, ValidationError{Field: "tags[" + strconv.Itoa(i1) + "]", Message: "length must be at least 1"})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the ListPetsParams satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v ListPetsParams) Validate() error {
+	var errs ValidationErrors
+	if v.Limit != nil {
+		if *v.Limit < 1 {
+			errs = append(errs, ValidationError{Field: "limit", Message: "must be greater than or equal to 1"})
+		}
+		if *v.Limit > 100 {
+			errs = append(errs, ValidationError{Field: "limit", Message: "must be less than or equal to 100"})
+		}
+	}
+	if v.Tag != nil {
+		if !validatePattern("^[a-z]+$", *v.Tag) {
+			errs = append(errs, ValidationError{Field: "tag", Message: "must match the pattern ^[a-z]+$"})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/{id})
+	GetPet(w http.ResponseWriter, r *http.Request, id int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPetsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPet operation middleware
+func (siw *ServerInterfaceWrapper) GetPet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/pets", wrapper.ListPets)
+	m.HandleFunc("POST "+options.BaseURL+"/pets", wrapper.AddPet)
+	m.HandleFunc("GET "+options.BaseURL+"/pets/{id}", wrapper.GetPet)
+
+	return m
+}
+
+type ListPetsRequestObject struct {
+	Params ListPetsParams
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200JSONResponse []Pet
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPetRequestObject struct {
+	Body *AddPetJSONRequestBody
+}
+
+type AddPetResponseObject interface {
+	VisitAddPetResponse(w http.ResponseWriter) error
+}
+
+type AddPet201JSONResponse Pet
+
+func (response AddPet201JSONResponse) VisitAddPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPetRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetPetResponseObject interface {
+	VisitGetPetResponse(w http.ResponseWriter) error
+}
+
+type GetPet200JSONResponse Pet
+
+func (response GetPet200JSONResponse) VisitGetPetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error)
+
+	// (GET /pets/{id})
+	GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(w http.ResponseWriter, r *http.Request, params ListPetsParams) {
+	var request ListPetsRequestObject
+
+	request.Params = params
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "ListPets", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPets(ctx, request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		if err := validResponse.VisitListPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPet operation middleware
+func (sh *strictHandler) AddPet(w http.ResponseWriter, r *http.Request) {
+	var request AddPetRequestObject
+
+	var body AddPetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "AddPet", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPet(ctx, request.(AddPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPetResponseObject); ok {
+		if err := validResponse.VisitAddPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPet operation middleware
+func (sh *strictHandler) GetPet(w http.ResponseWriter, r *http.Request, id int64) {
+	var request GetPetRequestObject
+
+	request.Id = id
+
+	if errs := request.validate(); len(errs) != 0 {
+		sh.options.RequestErrorHandlerFunc(w, r, &RequestValidationError{OperationID: "GetPet", Errors: errs})
+		return
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPet(ctx, request.(GetPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPetResponseObject); ok {
+		if err := validResponse.VisitGetPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestValidationError is passed to the RequestErrorHandlerFunc, or returned to the ErrorHandler of the framework, when the parameters or body of a request don't satisfy the constraints of their schemas.
+type RequestValidationError struct {
+	// OperationID is the ID of the operation which the request was made to
+	OperationID string
+	// Errors describes each of the constraints that aren't satisfied
+	Errors ValidationErrors
+}
+
+func (e *RequestValidationError) Error() string {
+	return fmt.Sprintf("request to %s is invalid: %s", e.OperationID, e.Errors.Error())
+}
+
+func (e *RequestValidationError) Unwrap() error {
+	return e.Errors
+}
+
+// validate checks that the parameters and body of the ListPetsRequestObject satisfy the constraints of their schemas.
+func (request ListPetsRequestObject) validate() ValidationErrors {
+	var errs ValidationErrors
+	errs = appendValidationErrors(errs, "", request.Params.Validate())
+	return errs
+}
+
+// validate checks that the parameters and body of the AddPetRequestObject satisfy the constraints of their schemas.
+func (request AddPetRequestObject) validate() ValidationErrors {
+	var errs ValidationErrors
+	if request.Body != nil {
+		errs = appendValidationErrors(errs, "body", (*request.Body).Validate())
+	}
+	return errs
+}
+
+// validate checks that the parameters and body of the GetPetRequestObject satisfy the constraints of their schemas.
+func (request GetPetRequestObject) validate() ValidationErrors {
+	var errs ValidationErrors
+	if request.Id < 1 {
+		errs = append(errs, ValidationError{Field: "id", Message: "must be greater than or equal to 1"})
+	}
+	return errs
+}
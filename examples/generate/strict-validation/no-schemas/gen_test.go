@@ -0,0 +1,70 @@
+package noschemas
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (server) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	return GetPet204Response{}, nil
+}
+
+// serve makes the request to the strict server, returning the response, and the error that was passed to the app's ErrorHandler.
+func serve(t *testing.T, errorHandler fiber.ErrorHandler, target string) (*http.Response, error) {
+	t.Helper()
+
+	var requestErr error
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			requestErr = err
+			return errorHandler(c, err)
+		},
+	})
+	RegisterHandlers(app, NewStrictHandler(server{}, nil))
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil))
+	require.NoError(t, err)
+	return res, requestErr
+}
+
+func TestValidRequest(t *testing.T) {
+	res, err := serve(t, fiber.DefaultErrorHandler, "/pets/1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestInvalidPathParameter(t *testing.T) {
+	res, err := serve(t, fiber.DefaultErrorHandler, "/pets/0")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	body, readErr := io.ReadAll(res.Body)
+	require.NoError(t, readErr)
+	assert.Equal(t, "request to GetPet is invalid: id: must be greater than or equal to 1", string(body))
+
+	var validationErr *RequestValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "GetPet", validationErr.OperationID)
+	assert.Equal(t, ValidationErrors{
+		{Field: "id", Message: "must be greater than or equal to 1"},
+	}, validationErr.Errors)
+}
+
+func TestInvalidPathParameterWithErrorHandler(t *testing.T) {
+	res, _ := serve(t, func(c *fiber.Ctx, err error) error {
+		var validationErr *RequestValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(http.StatusUnprocessableEntity).JSON(validationErr.Errors)
+		}
+		return fiber.DefaultErrorHandler(c, err)
+	}, "/pets/0")
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+}
@@ -0,0 +1,669 @@
+// Package validation provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for Level.
+const (
+	N1 Level = 1
+	N2 Level = 2
+	N3 Level = 3
+)
+
+// Valid indicates whether the value is one of the values defined for Level.
+func (e Level) Valid() bool {
+	switch e {
+	case N1, N2, N3:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllLevelValues returns each of the values defined for Level.
+func AllLevelValues() []Level {
+	return []Level{
+		N1,
+		N2,
+		N3,
+	}
+}
+
+// ParseLevel returns the Level value which is represented by s, or an error if s isn't one of its values.
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "1":
+		return N1, nil
+	case "2":
+		return N2, nil
+	case "3":
+		return N3, nil
+	}
+	var zero Level
+	return zero, fmt.Errorf("%q is not a valid Level, it must be one of %v", s, AllLevelValues())
+}
+
+// Defines values for PetKind.
+const (
+	Cat PetKind = "cat"
+	Dog PetKind = "dog"
+)
+
+// Valid indicates whether the value is one of the values defined for PetKind.
+func (e PetKind) Valid() bool {
+	switch e {
+	case Cat, Dog:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllPetKindValues returns each of the values defined for PetKind.
+func AllPetKindValues() []PetKind {
+	return []PetKind{
+		Cat,
+		Dog,
+	}
+}
+
+// ParsePetKind returns the PetKind value which is represented by s, or an error if s isn't one of its values.
+func ParsePetKind(s string) (PetKind, error) {
+	switch s {
+	case "cat":
+		return Cat, nil
+	case "dog":
+		return Dog, nil
+	}
+	var zero PetKind
+	return zero, fmt.Errorf("%q is not a valid PetKind, it must be one of %v", s, AllPetKindValues())
+}
+
+// Defines values for Status.
+const (
+	Available Status = "available"
+	Sold      Status = "sold"
+)
+
+// Valid indicates whether the value is one of the values defined for Status.
+func (e Status) Valid() bool {
+	switch e {
+	case Available, Sold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllStatusValues returns each of the values defined for Status.
+func AllStatusValues() []Status {
+	return []Status{
+		Available,
+		Sold,
+	}
+}
+
+// ParseStatus returns the Status value which is represented by s, or an error if s isn't one of its values.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "available":
+		return Available, nil
+	case "sold":
+		return Sold, nil
+	}
+	var zero Status
+	return zero, fmt.Errorf("%q is not a valid Status, it must be one of %v", s, AllStatusValues())
+}
+
+// Animal defines model for Animal.
+type Animal struct {
+	union json.RawMessage
+}
+
+// Ball defines model for Ball.
+type Ball struct {
+	Kind string `json:"kind"`
+	Size int    `json:"size"`
+}
+
+// Level defines model for Level.
+type Level int
+
+// Name defines model for Name.
+type Name = string
+
+// Pet defines model for Pet.
+type Pet struct {
+	Age        *int               `json:"age,omitempty"`
+	Attributes *map[string]string `json:"attributes,omitempty"`
+	Kind       *PetKind           `json:"kind,omitempty"`
+	Legs       *int               `json:"legs,omitempty"`
+	Lookahead  *string            `json:"lookahead,omitempty"`
+	Name       Name               `json:"name"`
+	Nickname   *string            `json:"nickname"`
+	Owner      *struct {
+		Email    *openapi_types.Email `json:"email,omitempty"`
+		Nickname *string              `json:"nickname,omitempty"`
+	} `json:"owner,omitempty"`
+	Status Status   `json:"status"`
+	Tags   []string `json:"tags"`
+	Toys   *[]Toy   `json:"toys,omitempty"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// PetKind defines model for Pet.Kind.
+type PetKind string
+
+// Rope defines model for Rope.
+type Rope struct {
+	Kind   string  `json:"kind"`
+	Length float32 `json:"length"`
+}
+
+// Scores defines model for Scores.
+type Scores = []int
+
+// Status defines model for Status.
+type Status string
+
+// Toy defines model for Toy.
+type Toy struct {
+	union json.RawMessage
+}
+
+// FindPetsParams defines parameters for FindPets.
+type FindPetsParams struct {
+	Limit *int32    `form:"limit,omitempty" json:"limit,omitempty"`
+	Tags  *[]string `form:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// AddPetJSONBody defines parameters for AddPet.
+type AddPetJSONBody struct {
+	Note *string `json:"note,omitempty"`
+	Pet  Pet     `json:"pet"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody AddPetJSONBody
+
+// AsBall returns the union data inside the Animal as a Ball
+func (t Animal) AsBall() (Ball, error) {
+	var body Ball
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromBall overwrites any union data inside the Animal as the provided Ball
+func (t *Animal) FromBall(v Ball) error {
+	v.Kind = "Ball"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeBall performs a merge with any union data inside the Animal, using the provided Ball
+func (t *Animal) MergeBall(v Ball) error {
+	v.Kind = "Ball"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRope returns the union data inside the Animal as a Rope
+func (t Animal) AsRope() (Rope, error) {
+	var body Rope
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRope overwrites any union data inside the Animal as the provided Rope
+func (t *Animal) FromRope(v Rope) error {
+	v.Kind = "Rope"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRope performs a merge with any union data inside the Animal, using the provided Rope
+func (t *Animal) MergeRope(v Rope) error {
+	v.Kind = "Rope"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Animal) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"kind"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t Animal) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "Ball":
+		return t.AsBall()
+	case "Rope":
+		return t.AsRope()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t Animal) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Animal) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsBall returns the union data inside the Toy as a Ball
+func (t Toy) AsBall() (Ball, error) {
+	var body Ball
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromBall overwrites any union data inside the Toy as the provided Ball
+func (t *Toy) FromBall(v Ball) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeBall performs a merge with any union data inside the Toy, using the provided Ball
+func (t *Toy) MergeBall(v Ball) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRope returns the union data inside the Toy as a Rope
+func (t Toy) AsRope() (Rope, error) {
+	var body Rope
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRope overwrites any union data inside the Toy as the provided Rope
+func (t *Toy) FromRope(v Rope) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRope performs a merge with any union data inside the Toy, using the provided Rope
+func (t *Toy) MergeRope(v Rope) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Toy) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Toy) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// ValidationError describes a value which doesn't satisfy a constraint of its schema.
+type ValidationError struct {
+	// Field is the path to the value, such as `pets[0].name`, or is empty for the value that was validated
+	Field string
+	// Message describes the constraint that isn't satisfied
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors is returned by the Validate methods, and describes each of the constraints that aren't satisfied.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// appendValidationErrors appends the errors from validating the value at field to errs, prefixing their paths with field.
+func appendValidationErrors(errs ValidationErrors, field string, err error) ValidationErrors {
+	if err == nil {
+		return errs
+	}
+	var nested ValidationErrors
+	if !errors.As(err, &nested) {
+		return append(errs, ValidationError{Field: field, Message: err.Error()})
+	}
+	for _, e := range nested {
+		switch {
+		case e.Field == "":
+			e.Field = field
+		case field == "":
+		case strings.HasPrefix(e.Field, "["):
+			e.Field = field + e.Field
+		default:
+			e.Field = field + "." + e.Field
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// validateValue validates value, if it implements a Validate method.
+func validateValue(value interface{}) error {
+	if validator, ok := value.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// validateUnionElement returns whether an element of a union could be retrieved, and is valid.
+func validateUnionElement[T any](value T, err error) bool {
+	return err == nil && validateValue(value) == nil
+}
+
+var validationPatterns sync.Map
+
+// validatePattern returns whether s matches the regular expression pattern, which is only compiled once.
+func validatePattern(pattern string, s string) bool {
+	re, ok := validationPatterns.Load(pattern)
+	if !ok {
+		re, _ = validationPatterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
+
+// validateMultipleOf returns whether value is a multiple of multipleOf, allowing for the imprecision of floating point numbers.
+func validateMultipleOf(value float64, multipleOf float64) bool {
+	quotient := value / multipleOf
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+
+// validateUniqueItems returns whether none of the items are equal to each other.
+func validateUniqueItems[T any](items []T) bool {
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if reflect.DeepEqual(items[i], items[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Validate checks that the Animal satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Animal) Validate() error {
+	var errs ValidationErrors
+	if value, err := v.ValueByDiscriminator(); err != nil {
+		errs = append(errs, ValidationError{Message: err.Error()})
+	} else {
+		errs = appendValidationErrors(errs, "", validateValue(value))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Ball satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Ball) Validate() error {
+	var errs ValidationErrors
+	if v.Size < 1 {
+		errs = append(errs, ValidationError{Field: "size", Message: "must be greater than or equal to 1"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Level satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Level) Validate() error {
+	var errs ValidationErrors
+	switch v {
+	case 1, 2, 3:
+	default:
+		errs = append(errs, ValidationError{Message: "must be one of: 1, 2, 3"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Pet satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Pet) Validate() error {
+	var errs ValidationErrors
+	if v.Age != nil {
+		if *v.Age < 0 {
+			errs = append(errs, ValidationError{Field: "age", Message: "must be greater than or equal to 0"})
+		}
+		if *v.Age >= 50 {
+			errs = append(errs, ValidationError{Field: "age", Message: "must be less than 50"})
+		}
+	}
+	if v.Attributes != nil {
+		for k1, v2 := range *v.Attributes {
+			if utf8.RuneCountInString(v2) < 1 {
+				errs = append(errs, ValidationError{Field: "attributes[" + strconv.Quote(k1) + "]", Message: "length must be at least 1"})
+			}
+		}
+	}
+	if v.Kind != nil {
+		errs = appendValidationErrors(errs, "kind", (*v.Kind).Validate())
+	}
+	if v.Legs != nil {
+		if *v.Legs%2 != 0 {
+			errs = append(errs, ValidationError{Field: "legs", Message: "must be a multiple of 2"})
+		}
+	}
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at least 1"})
+	}
+	if utf8.RuneCountInString(v.Name) > 20 {
+		errs = append(errs, ValidationError{Field: "name", Message: "length must be at most 20"})
+	}
+	if !validatePattern("^[A-Za-z ]+$", v.Name) {
+		errs = append(errs, ValidationError{Field: "name", Message: "must match the pattern ^[A-Za-z ]+$"})
+	}
+	if v.Nickname != nil {
+		if utf8.RuneCountInString(*v.Nickname) < 1 {
+			errs = append(errs, ValidationError{Field: "nickname", Message: "length must be at least 1"})
+		}
+	}
+	if v.Owner != nil {
+		if (*v.Owner).Nickname != nil {
+			if utf8.RuneCountInString(*(*v.Owner).Nickname) < 2 {
+				errs = append(errs, ValidationError{Field: "owner.nickname", Message: "length must be at least 2"})
+			}
+		}
+	}
+	errs = appendValidationErrors(errs, "status", v.Status.Validate())
+	if v.Tags == nil {
+		errs = append(errs, ValidationError{Field: "tags", Message: "is required"})
+	}
+	if len(v.Tags) < 1 {
+		errs = append(errs, ValidationError{Field: "tags", Message: "must have at least 1 items"})
+	}
+	if !validateUniqueItems(v.Tags) {
+		errs = append(errs, ValidationError{Field: "tags", Message: "items must be unique"})
+	}
+	for i3, v4 := range v.Tags {
+		if utf8.RuneCountInString(v4) > 5 {
+			errs = append(errs, ValidationError{Field: "tags[" + strconv.Itoa(i3) + "]", Message: "length must be at most 5"})
+		}
+	}
+	if v.Toys != nil {
+		for i5, v6 := range *v.Toys {
+			errs = appendValidationErrors(errs, "toys["+strconv.Itoa(i5)+"]", v6.Validate())
+		}
+	}
+	if v.Weight != nil {
+		if !validateMultipleOf(float64(*v.Weight), 0.5) {
+			errs = append(errs, ValidationError{Field: "weight", Message: "must be a multiple of 0.5"})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the PetKind satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v PetKind) Validate() error {
+	var errs ValidationErrors
+	switch v {
+	case "dog", "cat":
+	default:
+		errs = append(errs, ValidationError{Message: "must be one of: dog, cat"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Rope satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Rope) Validate() error {
+	var errs ValidationErrors
+	if v.Length < 0.5 {
+		errs = append(errs, ValidationError{Field: "length", Message: "must be greater than or equal to 0.5"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Status satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Status) Validate() error {
+	var errs ValidationErrors
+	switch v {
+	case "available", "sold":
+	default:
+		errs = append(errs, ValidationError{Message: "must be one of: available, sold"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the Toy satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v Toy) Validate() error {
+	var errs ValidationErrors
+	if !(validateUnionElement(v.AsBall()) ||
+		validateUnionElement(v.AsRope())) {
+		errs = append(errs, ValidationError{Message: "must match one of the schemas in oneOf"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the FindPetsParams satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v FindPetsParams) Validate() error {
+	var errs ValidationErrors
+	if v.Limit != nil {
+		if *v.Limit < 1 {
+			errs = append(errs, ValidationError{Field: "limit", Message: "must be greater than or equal to 1"})
+		}
+		if *v.Limit > 100 {
+			errs = append(errs, ValidationError{Field: "limit", Message: "must be less than or equal to 100"})
+		}
+	}
+	if v.Tags != nil {
+		if len(*v.Tags) > 3 {
+			errs = append(errs, ValidationError{Field: "tags", Message: "must have at most 3 items"})
+		}
+		for i1, v2 := range *v.Tags {
+			if utf8.RuneCountInString(v2) < 1 {
+				errs = append(errs, ValidationError{Field: "tags[" + strconv.Itoa(i1) + "]", Message: "length must be at least 1"})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the AddPetJSONBody satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v AddPetJSONBody) Validate() error {
+	var errs ValidationErrors
+	if v.Note != nil {
+		if utf8.RuneCountInString(*v.Note) > 10 {
+			errs = append(errs, ValidationError{Field: "note", Message: "length must be at most 10"})
+		}
+	}
+	errs = appendValidationErrors(errs, "pet", v.Pet.Validate())
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate checks that the AddPetJSONRequestBody satisfies the constraints of its schema, returning ValidationErrors if it doesn't.
+func (v AddPetJSONRequestBody) Validate() error {
+	var errs ValidationErrors
+	errs = appendValidationErrors(errs, "", AddPetJSONBody(v).Validate())
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func validPet() Pet {
+	return Pet{
+		Name:   "Fido",
+		Status: Available,
+		Tags:   []string{"good"},
+	}
+}
+
+func TestValidPet(t *testing.T) {
+	pet := validPet()
+	pet.Age = ptr(3)
+	pet.Weight = ptr(12.5)
+	pet.Kind = ptr(Dog)
+
+	assert.NoError(t, pet.Validate())
+}
+
+func TestInvalidPet(t *testing.T) {
+	var toy Toy
+	require.NoError(t, toy.FromBall(Ball{Size: 0}))
+
+	pet := Pet{
+		Name:       "Fido2",
+		Status:     "lost",
+		Tags:       []string{"good", "good", "too long"},
+		Age:        ptr(50),
+		Legs:       ptr(3),
+		Weight:     ptr(12.2),
+		Attributes: &map[string]string{"colour": ""},
+		Toys:       &[]Toy{toy},
+	}
+
+	err := pet.Validate()
+	require.Error(t, err)
+
+	var errs ValidationErrors
+	require.True(t, errors.As(err, &errs))
+
+	assert.ElementsMatch(t, ValidationErrors{
+		{Field: "age", Message: "must be less than 50"},
+		{Field: "attributes[\"colour\"]", Message: "length must be at least 1"},
+		{Field: "legs", Message: "must be a multiple of 2"},
+		{Field: "name", Message: "must match the pattern ^[A-Za-z ]+$"},
+		{Field: "status", Message: "must be one of: available, sold"},
+		{Field: "tags", Message: "items must be unique"},
+		{Field: "tags[2]", Message: "length must be at most 5"},
+		{Field: "toys[0]", Message: "must match one of the schemas in oneOf"},
+		{Field: "weight", Message: "must be a multiple of 0.5"},
+	}, errs)
+}
+
+func TestRequiredArray(t *testing.T) {
+	var pet Pet
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Fido", "status": "sold"}`), &pet))
+
+	err := pet.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tags: is required")
+}
+
+func TestNestedObject(t *testing.T) {
+	var body AddPetJSONRequestBody
+	require.NoError(t, json.Unmarshal([]byte(`{"pet": {"name": "Fido", "status": "sold", "tags": ["a"], "owner": {"nickname": "x"}}, "note": "a long note"}`), &body))
+
+	err := body.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "note: length must be at most 10; pet.owner.nickname: length must be at least 2", err.Error())
+}
+
+func TestUnion(t *testing.T) {
+	var toy Toy
+	require.NoError(t, toy.FromRope(Rope{Length: 1.5}))
+	assert.NoError(t, toy.Validate())
+
+	var animal Animal
+	require.NoError(t, animal.FromBall(Ball{Size: 0}))
+	assert.EqualError(t, animal.Validate(), "size: must be greater than or equal to 1")
+
+	require.NoError(t, animal.FromBall(Ball{Size: 1}))
+	assert.NoError(t, animal.Validate())
+}
+
+func TestParams(t *testing.T) {
+	params := FindPetsParams{
+		Limit: ptr(int32(0)),
+		Tags:  &[]string{"a", "", "b", "c"},
+	}
+
+	assert.EqualError(t, params.Validate(), "limit: must be greater than or equal to 1; tags: must have at most 3 items; tags[1]: length must be at least 1")
+}
+
+func TestEnum(t *testing.T) {
+	assert.NoError(t, N2.Validate())
+	assert.EqualError(t, Level(4).Validate(), "must be one of: 1, 2, 3")
+}
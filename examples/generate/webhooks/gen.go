@@ -0,0 +1,528 @@
+// Package webhooks provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// PetRemoval defines model for PetRemoval.
+type PetRemoval struct {
+	Id     int64   `json:"id"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// NewPetParams defines parameters for NewPet.
+type NewPetParams struct {
+	Attempt           *int   `form:"attempt,omitempty" json:"attempt,omitempty"`
+	XWebhookSignature string `json:"X-Webhook-Signature"`
+}
+
+// NewPetJSONRequestBody defines body for NewPet for application/json ContentType.
+type NewPetJSONRequestBody = Pet
+
+// PetRemovedJSONRequestBody defines body for PetRemoved for application/json ContentType.
+type PetRemovedJSONRequestBody = PetRemoval
+
+// WebhookServerInterface represents all the webhooks that can be received.
+type WebhookServerInterface interface {
+	// A new pet has been added to the store
+	// (POST webhook newPet)
+	NewPet(w http.ResponseWriter, r *http.Request, params NewPetParams)
+	// A pet has been removed from the store
+	// (DELETE webhook petRemoved)
+	PetRemoved(w http.ResponseWriter, r *http.Request)
+}
+
+// WebhookHandlerOptions configures the http.Handler for a received webhook.
+type WebhookHandlerOptions struct {
+	Middlewares      []func(http.Handler) http.Handler
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// WebhookParamError is passed to the ErrorHandlerFunc when a parameter of a
+// received webhook is missing, or can't be bound.
+type WebhookParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *WebhookParamError) Error() string {
+	return fmt.Sprintf("Invalid webhook parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *WebhookParamError) Unwrap() error {
+	return e.Err
+}
+
+// webhookServerInterfaceWrapper converts requests to parameters.
+type webhookServerInterfaceWrapper struct {
+	Handler          WebhookServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// NewPetWebhookHandler returns an http.Handler that receives the newPet webhook.
+// As the URL of a webhook is chosen by its subscriber, it can be mounted on any route.
+func NewPetWebhookHandler(si WebhookServerInterface, options WebhookHandlerOptions) http.Handler {
+	return newWebhookHandler(si, options, (*webhookServerInterfaceWrapper).NewPet)
+}
+
+// NewPet webhook middleware
+func (siw *webhookServerInterfaceWrapper) NewPet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the request
+	var params NewPetParams
+
+	// ------------- Optional query parameter "attempt" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "attempt", r.URL.Query(), &params.Attempt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &WebhookParamError{ParamName: "attempt", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Webhook-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Webhook-Signature")]; found {
+		var XWebhookSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &WebhookParamError{ParamName: "X-Webhook-Signature", Err: fmt.Errorf("Expected one value for X-Webhook-Signature, got %d", n)})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Webhook-Signature", valueList[0], &XWebhookSignature, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &WebhookParamError{ParamName: "X-Webhook-Signature", Err: err})
+			return
+		}
+
+		params.XWebhookSignature = XWebhookSignature
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Webhook-Signature is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &WebhookParamError{ParamName: "X-Webhook-Signature", Err: err})
+		return
+	}
+
+	siw.Handler.NewPet(w, r, params)
+}
+
+// PetRemovedWebhookHandler returns an http.Handler that receives the petRemoved webhook.
+// As the URL of a webhook is chosen by its subscriber, it can be mounted on any route.
+func PetRemovedWebhookHandler(si WebhookServerInterface, options WebhookHandlerOptions) http.Handler {
+	return newWebhookHandler(si, options, (*webhookServerInterfaceWrapper).PetRemoved)
+}
+
+// PetRemoved webhook middleware
+func (siw *webhookServerInterfaceWrapper) PetRemoved(w http.ResponseWriter, r *http.Request) {
+
+	siw.Handler.PetRemoved(w, r)
+}
+
+func newWebhookHandler(si WebhookServerInterface, options WebhookHandlerOptions, receive func(*webhookServerInterfaceWrapper, http.ResponseWriter, *http.Request)) http.Handler {
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := &webhookServerInterfaceWrapper{
+		Handler:          si,
+		ErrorHandlerFunc: options.ErrorHandlerFunc,
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receive(wrapper, w, r)
+	}))
+	for _, middleware := range options.Middlewares {
+		handler = middleware(handler)
+	}
+	return handler
+}
+
+type NewPetRequestObject struct {
+	Params NewPetParams
+	Body   *NewPetJSONRequestBody
+}
+
+type NewPetResponseObject interface {
+	VisitNewPetResponse(w http.ResponseWriter) error
+}
+
+type NewPet200Response struct {
+}
+
+func (response NewPet200Response) VisitNewPetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type NewPet410Response struct {
+}
+
+func (response NewPet410Response) VisitNewPetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(410)
+	return nil
+}
+
+type PetRemovedRequestObject struct {
+	Body *PetRemovedJSONRequestBody
+}
+
+type PetRemovedResponseObject interface {
+	VisitPetRemovedResponse(w http.ResponseWriter) error
+}
+
+type PetRemoved204Response struct {
+}
+
+func (response PetRemoved204Response) VisitPetRemovedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+// WebhookStrictServerInterface represents all the webhooks that can be received.
+type WebhookStrictServerInterface interface {
+	// A new pet has been added to the store
+	// (POST webhook newPet)
+	NewPet(ctx context.Context, request NewPetRequestObject) (NewPetResponseObject, error)
+	// A pet has been removed from the store
+	// (DELETE webhook petRemoved)
+	PetRemoved(ctx context.Context, request PetRemovedRequestObject) (PetRemovedResponseObject, error)
+}
+
+type WebhookStrictHandlerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewWebhookStrictHandler(ssi WebhookStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc) WebhookServerInterface {
+	return &webhookStrictHandler{ssi: ssi, middlewares: middlewares, options: WebhookStrictHandlerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewWebhookStrictHandlerWithOptions(ssi WebhookStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc, options WebhookStrictHandlerOptions) WebhookServerInterface {
+	return &webhookStrictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type webhookStrictHandler struct {
+	ssi         WebhookStrictServerInterface
+	middlewares []strictnethttp.StrictHTTPMiddlewareFunc
+	options     WebhookStrictHandlerOptions
+}
+
+// NewPet webhook middleware
+func (sh *webhookStrictHandler) NewPet(w http.ResponseWriter, r *http.Request, params NewPetParams) {
+	var request NewPetRequestObject
+
+	request.Params = params
+
+	var body NewPetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.NewPet(ctx, request.(NewPetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "NewPet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(NewPetResponseObject); ok {
+		if err := validResponse.VisitNewPetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PetRemoved webhook middleware
+func (sh *webhookStrictHandler) PetRemoved(w http.ResponseWriter, r *http.Request) {
+	var request PetRemovedRequestObject
+
+	var body PetRemovedJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PetRemoved(ctx, request.(PetRemovedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PetRemoved")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PetRemovedResponseObject); ok {
+		if err := validResponse.VisitPetRemovedResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookClient delivers webhooks to the URLs that their subscribers have
+// registered.
+type WebhookClient struct {
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// WebhookClientOption allows setting custom parameters during construction
+type WebhookClientOption func(*WebhookClient) error
+
+// Creates a new WebhookClient, with reasonable defaults
+func NewWebhookClient(opts ...WebhookClientOption) (*WebhookClient, error) {
+	client := WebhookClient{}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithWebhookHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithWebhookHTTPClient(doer HttpRequestDoer) WebhookClientOption {
+	return func(c *WebhookClient) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithWebhookRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request,
+// for instance to sign it.
+func WithWebhookRequestEditorFn(fn RequestEditorFn) WebhookClientOption {
+	return func(c *WebhookClient) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the webhook client above.
+type WebhookClientInterface interface {
+	// NewPetWithBody delivers the newPet webhook to targetURL with any body
+	NewPetWithBody(ctx context.Context, targetURL string, params *NewPetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	NewPet(ctx context.Context, targetURL string, params *NewPetParams, body NewPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PetRemovedWithBody delivers the petRemoved webhook to targetURL with any body
+	PetRemovedWithBody(ctx context.Context, targetURL string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PetRemoved(ctx context.Context, targetURL string, body PetRemovedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *WebhookClient) NewPetWithBody(ctx context.Context, targetURL string, params *NewPetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewNewPetWebhookRequestWithBody(targetURL, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *WebhookClient) NewPet(ctx context.Context, targetURL string, params *NewPetParams, body NewPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewNewPetWebhookRequest(targetURL, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *WebhookClient) PetRemovedWithBody(ctx context.Context, targetURL string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPetRemovedWebhookRequestWithBody(targetURL, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *WebhookClient) PetRemoved(ctx context.Context, targetURL string, body PetRemovedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPetRemovedWebhookRequest(targetURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewNewPetWebhookRequest calls the generic NewPet builder with application/json body
+func NewNewPetWebhookRequest(targetURL string, params *NewPetParams, body NewPetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewNewPetWebhookRequestWithBody(targetURL, params, "application/json", bodyReader)
+}
+
+// NewNewPetWebhookRequestWithBody generates requests delivering the NewPet webhook to targetURL with any type of body
+func NewNewPetWebhookRequestWithBody(targetURL string, params *NewPetParams, contentType string, body io.Reader) (*http.Request, error) {
+	queryURL, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Attempt != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "attempt", runtime.ParamLocationQuery, *params.Attempt); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Webhook-Signature", runtime.ParamLocationHeader, params.XWebhookSignature)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Webhook-Signature", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewPetRemovedWebhookRequest calls the generic PetRemoved builder with application/json body
+func NewPetRemovedWebhookRequest(targetURL string, body PetRemovedJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPetRemovedWebhookRequestWithBody(targetURL, "application/json", bodyReader)
+}
+
+// NewPetRemovedWebhookRequestWithBody generates requests delivering the PetRemoved webhook to targetURL with any type of body
+func NewPetRemovedWebhookRequestWithBody(targetURL string, contentType string, body io.Reader) (*http.Request, error) {
+	queryURL, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *WebhookClient) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
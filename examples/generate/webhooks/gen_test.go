@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiver struct {
+	pets []Pet
+}
+
+func (r *receiver) NewPet(ctx context.Context, request NewPetRequestObject) (NewPetResponseObject, error) {
+	if request.Params.XWebhookSignature != "signed" {
+		return NewPet410Response{}, nil
+	}
+	r.pets = append(r.pets, *request.Body)
+	return NewPet200Response{}, nil
+}
+
+func (r *receiver) PetRemoved(ctx context.Context, request PetRemovedRequestObject) (PetRemovedResponseObject, error) {
+	return PetRemoved204Response{}, nil
+}
+
+func TestWebhooks(t *testing.T) {
+	rcv := &receiver{}
+	si := NewWebhookStrictHandler(rcv, nil)
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /hooks/pets", NewPetWebhookHandler(si, WebhookHandlerOptions{}))
+	mux.Handle("DELETE /hooks/pets", PetRemovedWebhookHandler(si, WebhookHandlerOptions{}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewWebhookClient()
+	require.NoError(t, err)
+
+	t.Run("a webhook is delivered to the subscriber's URL", func(t *testing.T) {
+		attempt := 1
+		resp, err := client.NewPet(context.Background(), server.URL+"/hooks/pets", &NewPetParams{
+			Attempt:           &attempt,
+			XWebhookSignature: "signed",
+		}, NewPetJSONRequestBody{Id: 1, Name: "Fido"})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []Pet{{Id: 1, Name: "Fido"}}, rcv.pets)
+	})
+
+	t.Run("the receiver's response is returned to the sender", func(t *testing.T) {
+		resp, err := client.NewPet(context.Background(), server.URL+"/hooks/pets", &NewPetParams{
+			XWebhookSignature: "not-signed",
+		}, NewPetJSONRequestBody{Id: 2, Name: "Rex"})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusGone, resp.StatusCode)
+	})
+
+	t.Run("a missing required parameter is rejected", func(t *testing.T) {
+		resp, err := client.NewPetWithBody(context.Background(), server.URL+"/hooks/pets", nil, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("webhooks without parameters can be delivered", func(t *testing.T) {
+		resp, err := client.PetRemoved(context.Background(), server.URL+"/hooks/pets", PetRemovedJSONRequestBody{Id: 1})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
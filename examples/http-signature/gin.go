@@ -0,0 +1,27 @@
+// Package httpsignature shows how requests signed with an HTTP message
+// signature, by securityprovider.SecurityProviderHTTPSignature, can be
+// verified by each of the generated servers.
+package httpsignature
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+)
+
+// GinMiddleware verifies the signature of each request to a gin server, as
+// gin's middleware can't wrap an http.Handler. Like the verifier's Middleware,
+// it adds the ID of the key which signed the request to the request's context,
+// for securityprovider.HTTPSignatureKeyIDFromContext. As securityprovider
+// doesn't depend on gin, this is meant to be copied into a gin server.
+func GinMiddleware(verifier *securityprovider.HTTPSignatureVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keyID, err := verifier.Verify(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		c.Request = c.Request.WithContext(securityprovider.ContextWithHTTPSignatureKeyID(c.Request.Context(), keyID))
+	}
+}
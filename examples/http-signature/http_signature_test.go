@@ -0,0 +1,108 @@
+package httpsignature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/labstack/echo/v4"
+	stdhttpapi "github.com/oapi-codegen/oapi-codegen/v2/examples/http-signature/api"
+	chiapi "github.com/oapi-codegen/oapi-codegen/v2/examples/minimal-server/chi/api"
+	echoapi "github.com/oapi-codegen/oapi-codegen/v2/examples/minimal-server/echo/api"
+	ginapi "github.com/oapi-codegen/oapi-codegen/v2/examples/minimal-server/gin/api"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := securityprovider.NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := securityprovider.NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(echo.WrapMiddleware(verifier.Middleware))
+	echoapi.RegisterHandlers(e, echoapi.NewServer())
+
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	ginapi.RegisterHandlersWithOptions(g, ginapi.NewServer(), ginapi.GinServerOptions{
+		Middlewares: []ginapi.MiddlewareFunc{ginapi.MiddlewareFunc(GinMiddleware(verifier))},
+	})
+
+	servers := map[string]http.Handler{
+		"std-http": stdhttpapi.HandlerWithOptions(stdhttpapi.NewServer(), stdhttpapi.StdHTTPServerOptions{
+			BaseRouter:  http.NewServeMux(),
+			Middlewares: []stdhttpapi.MiddlewareFunc{verifier.Middleware},
+		}),
+		"chi": chiapi.HandlerWithOptions(chiapi.NewServer(), chiapi.ChiServerOptions{
+			BaseRouter:  chi.NewRouter(),
+			Middlewares: []chiapi.MiddlewareFunc{verifier.Middleware},
+		}),
+		"echo": e,
+		"gin":  g,
+	}
+
+	for name, handler := range servers {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			get := func(sign bool) int {
+				req, err := http.NewRequest(http.MethodGet, server.URL+"/ping", nil)
+				require.NoError(t, err)
+				if sign {
+					require.NoError(t, signer.Intercept(context.Background(), req))
+				}
+				rsp, err := server.Client().Do(req)
+				require.NoError(t, err)
+				defer func() { _ = rsp.Body.Close() }()
+				_, _ = io.Copy(io.Discard, rsp.Body)
+				return rsp.StatusCode
+			}
+
+			assert.Equal(t, http.StatusOK, get(true))
+			assert.Equal(t, http.StatusUnauthorized, get(false))
+		})
+	}
+}
+
+func TestGinMiddleware(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := securityprovider.NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := securityprovider.NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	g.Use(GinMiddleware(verifier))
+	g.GET("/ping", func(c *gin.Context) {
+		keyID, ok := securityprovider.HTTPSignatureKeyIDFromContext(c.Request.Context())
+		assert.True(t, ok)
+		c.String(http.StatusOK, keyID)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/ping", nil)
+	require.NoError(t, signer.Intercept(context.Background(), req))
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "client", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://example.com/ping", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+}
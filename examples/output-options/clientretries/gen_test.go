@@ -0,0 +1,177 @@
+package clientretries
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attempts is a server which responds to each attempt with the next of its status codes, and records the requests that it's sent.
+type attempts struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  []http.Header
+	bodies   []string
+}
+
+func (a *attempts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	a.headers = append(a.headers, r.Header.Clone())
+	a.bodies = append(a.bodies, string(body))
+
+	status := a.statuses[0]
+	if len(a.statuses) > 1 {
+		a.statuses = a.statuses[1:]
+	}
+	if status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "0")
+	}
+	w.WriteHeader(status)
+}
+
+func newClient(t *testing.T, server *attempts, opts ...ClientOption) *ClientWithResponses {
+	t.Helper()
+	s := httptest.NewServer(server)
+	t.Cleanup(s.Close)
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client, err := NewClientWithResponses(s.URL, append([]ClientOption{WithRetryPolicy(policy)}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestSafeMethodsAreRetried(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}}
+	client := newClient(t, server)
+
+	response, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode())
+	assert.Len(t, server.headers, 3)
+}
+
+func TestRetriesStopAfterMaxAttempts(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusServiceUnavailable}}
+	client := newClient(t, server)
+
+	response, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	assert.Len(t, server.headers, 3)
+}
+
+func TestOtherStatusCodesAreNotRetried(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusInternalServerError, http.StatusOK}}
+	client := newClient(t, server)
+
+	response, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode())
+	assert.Len(t, server.headers, 1)
+}
+
+func TestUnsafeMethodsAreNotRetried(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusServiceUnavailable, http.StatusCreated}}
+	client := newClient(t, server)
+
+	response, err := client.AddPetWithResponse(context.Background(), AddPetJSONRequestBody{Id: 1, Name: "Tom"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	assert.Len(t, server.headers, 1)
+	assert.Empty(t, server.headers[0].Get("Idempotency-Key"))
+}
+
+func TestRequestsWithAnIdempotencyKeyAreRetried(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusServiceUnavailable, http.StatusCreated}}
+	client := newClient(t, server, WithIdempotencyKeys(nil))
+
+	response, err := client.AddPetWithResponse(context.Background(), AddPetJSONRequestBody{Id: 1, Name: "Tom"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode())
+
+	require.Len(t, server.headers, 2)
+	key := server.headers[0].Get("Idempotency-Key")
+	assert.Len(t, key, 36)
+	assert.Equal(t, key, server.headers[1].Get("Idempotency-Key"))
+	// the body is sent with each attempt
+	assert.JSONEq(t, `{"id":1,"name":"Tom"}`, server.bodies[0])
+	assert.Equal(t, server.bodies[0], server.bodies[1])
+}
+
+func TestRetryableOperationsAreRetried(t *testing.T) {
+	server := &attempts{statuses: []int{http.StatusTooManyRequests, http.StatusNoContent}}
+	client := newClient(t, server)
+
+	response, err := client.FeedPetWithResponse(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode())
+	assert.Len(t, server.headers, 2)
+}
+
+func TestRetryAfterIsHonoured(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer s.Close()
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+	client, err := NewClientWithResponses(s.URL, WithRetryPolicy(policy))
+	require.NoError(t, err)
+
+	start := time.Now()
+	response, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	t.Run("isn't retried when it's longer than the MaxBackoff", func(t *testing.T) {
+		policy.MaxBackoff = 10 * time.Millisecond
+		client, err := NewClientWithResponses(s.URL, WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		start := time.Now()
+		response, err := client.ListPetsWithResponse(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode())
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 100, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+	backoff := policy.InitialBackoff
+	for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+		backoff = policy.nextBackoff(backoff)
+		require.Positive(t, backoff)
+		require.LessOrEqual(t, backoff, policy.MaxBackoff)
+	}
+	assert.Equal(t, policy.MaxBackoff, backoff)
+
+	t.Run("doesn't overflow without a MaxBackoff", func(t *testing.T) {
+		policy.MaxBackoff = 0
+		backoff := policy.InitialBackoff
+		for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+			backoff = policy.nextBackoff(backoff)
+			require.Positive(t, backoff)
+		}
+		delay, ok := policy.delay(backoff, nil)
+		assert.True(t, ok)
+		assert.Positive(t, delay)
+	})
+}
+
+func TestWithRetryPolicyNeedsAnAttempt(t *testing.T) {
+	_, err := NewClient("http://localhost", WithRetryPolicy(RetryPolicy{}))
+	assert.Error(t, err)
+}
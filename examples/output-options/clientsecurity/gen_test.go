@@ -0,0 +1,124 @@
+package clientsecurity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/securityprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client for a server which records the headers of the
+// last request.
+func newClient(t *testing.T, providers SecurityProviders) (*Client, *http.Header) {
+	t.Helper()
+	headers := &http.Header{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*headers = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := NewClient(s.URL, WithSecurityProviders(providers))
+	require.NoError(t, err)
+	return client, headers
+}
+
+func apiKey(t *testing.T, name, value string) RequestEditorFn {
+	t.Helper()
+	provider, err := securityprovider.NewSecurityProviderApiKey("header", name, value)
+	require.NoError(t, err)
+	return provider.Intercept
+}
+
+func bearer(t *testing.T, token string) RequestEditorFn {
+	t.Helper()
+	provider, err := securityprovider.NewSecurityProviderBearerToken(token)
+	require.NoError(t, err)
+	return provider.Intercept
+}
+
+func TestTopLevelSecurityRequirement(t *testing.T) {
+	client, headers := newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+		"oauth2": bearer(t, "token"),
+	})
+
+	_, err := client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key", headers.Get("X-API-Key"))
+	assert.Empty(t, headers.Get("Authorization"))
+}
+
+func TestAlternativeSecurityRequirements(t *testing.T) {
+	// the first requirement which can be met is used
+	client, headers := newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+		"tenant": apiKey(t, "X-Tenant-ID", "tenant"),
+		"oauth2": bearer(t, "token"),
+	})
+	_, err := client.AddPet(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+	assert.Empty(t, headers.Get("X-API-Key"))
+
+	// each of the schemes of a requirement are used together
+	client, headers = newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+		"tenant": apiKey(t, "X-Tenant-ID", "tenant"),
+	})
+	_, err = client.AddPet(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key", headers.Get("X-API-Key"))
+	assert.Equal(t, "tenant", headers.Get("X-Tenant-ID"))
+	assert.Empty(t, headers.Get("Authorization"))
+}
+
+func TestUnmetSecurityRequirements(t *testing.T) {
+	client, _ := newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+	})
+
+	_, err := client.AddPet(context.Background())
+	assert.True(t, errors.Is(err, ErrNoSecurityProvider))
+	assert.EqualError(t, err, "there are no security providers for the operation's security requirements: AddPet needs oauth2, or apiKey and tenant")
+}
+
+func TestOptionalSecurityRequirement(t *testing.T) {
+	client, headers := newClient(t, SecurityProviders{})
+	_, err := client.CountPets(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, headers.Get("X-API-Key"))
+
+	client, headers = newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+	})
+	_, err = client.CountPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key", headers.Get("X-API-Key"))
+}
+
+func TestOperationWithoutSecurity(t *testing.T) {
+	client, headers := newClient(t, SecurityProviders{
+		"apiKey": apiKey(t, "X-API-Key", "key"),
+	})
+
+	_, err := client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, headers.Get("X-API-Key"))
+}
+
+func TestSecurityProviderErrors(t *testing.T) {
+	client, _ := newClient(t, SecurityProviders{
+		"apiKey": func(ctx context.Context, req *http.Request) error {
+			return errors.New("the key has expired")
+		},
+	})
+
+	_, err := client.ListPets(context.Background())
+	assert.EqualError(t, err, "can't apply the credentials of the apiKey security scheme: the key has expired")
+}
@@ -0,0 +1,780 @@
+// Package clienttypedmethods provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package clienttypedmethods
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// NotFound defines model for NotFound.
+type NotFound struct {
+	Id int64 `json:"id"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = Pet
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// DeletePets request
+	DeletePets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPetWithBody request with any body
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPet request
+	GetPet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) DeletePets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "DeletePets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "AddPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) GetPet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "GetPet")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewDeletePetsRequest generates requests for DeletePets
+func NewDeletePetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody generates requests for AddPet with any type of body
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// DeletePetsWithResponse request
+	DeletePetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeletePetsResponse, error)
+
+	// AddPetWithBodyWithResponse request with any body
+	AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error)
+
+	// GetPetWithResponse request
+	GetPetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error)
+}
+
+type DeletePetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Pet
+	JSON5XX      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pet
+	JSON404      *NotFound
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// DeletePetsWithResponse request returning *DeletePetsResponse
+func (c *ClientWithResponses) DeletePetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeletePetsResponse, error) {
+	rsp, err := c.DeletePets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePetsResponse(rsp)
+}
+
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPetResponse(rsp)
+}
+
+// GetPetWithResponse request returning *GetPetResponse
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetPetResponse, error) {
+	rsp, err := c.GetPet(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPetResponse(rsp)
+}
+
+// ParseDeletePetsResponse parses an HTTP response from a DeletePetsWithResponse call
+func ParseDeletePetsResponse(rsp *http.Response) (*DeletePetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeletePetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseAddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseAddPetResponse(rsp *http.Response) (*AddPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode/100 == 5:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON5XX = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParseGetPetResponse(rsp *http.Response) (*GetPetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// UnexpectedStatusError is returned by the `<Operation>Typed` methods of the ClientWithResponses, when the response has a status code which the operation doesn't describe.
+type UnexpectedStatusError struct {
+	// OperationID is the ID of the operation which was called
+	OperationID  string
+	HTTPResponse *http.Response
+	// Body is the body of the response
+	Body []byte
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("the %s operation responded with the unexpected status %s", e.OperationID, e.HTTPResponse.Status)
+}
+
+// StatusCode returns the status code of the response.
+func (e *UnexpectedStatusError) StatusCode() int {
+	return e.HTTPResponse.StatusCode
+}
+
+// DeletePetsTyped calls DeletePetsWithResponse, and returns nil when its response is successful, or an error for any other response.
+func (c *ClientWithResponses) DeletePetsTyped(ctx context.Context, reqEditors ...RequestEditorFn) error {
+	response, err := c.DeletePetsWithResponse(ctx, reqEditors...)
+	if err != nil {
+		return err
+	}
+	return DeletePetsTypedResult(response)
+}
+
+// DeletePetsTypedResult returns nil when the response to the DeletePets operation is successful, or an error for any other response.
+func DeletePetsTypedResult(response *DeletePetsResponse) error {
+	if code := response.StatusCode(); code >= 200 && code <= 299 {
+		return nil
+	}
+	return &UnexpectedStatusError{OperationID: "DeletePets", HTTPResponse: response.HTTPResponse, Body: response.Body}
+}
+
+// AddPet409Error is returned by the `AddPet...Typed` methods of the ClientWithResponses, when the response has the 409 status code.
+type AddPet409Error struct {
+	Response *AddPetResponse
+}
+
+func (e *AddPet409Error) Error() string {
+	return fmt.Sprintf("the AddPet operation responded with %s", e.Response.Status())
+}
+
+// StatusCode returns the status code of the response.
+func (e *AddPet409Error) StatusCode() int {
+	return e.Response.StatusCode()
+}
+
+// AddPet5XXError is returned by the `AddPet...Typed` methods of the ClientWithResponses, when the response has a 5xx status code.
+type AddPet5XXError struct {
+	Response *AddPetResponse
+	// Body is the decoded JSON body of the response, which is nil when it doesn't have one
+	Body *Error
+}
+
+func (e *AddPet5XXError) Error() string {
+	return fmt.Sprintf("the AddPet operation responded with %s", e.Response.Status())
+}
+
+// StatusCode returns the status code of the response.
+func (e *AddPet5XXError) StatusCode() int {
+	return e.Response.StatusCode()
+}
+
+// Unwrap returns the Body, when its type is an error, so that it can be found with errors.As.
+func (e *AddPet5XXError) Unwrap() error {
+	if e.Body == nil {
+		return nil
+	}
+	if err, ok := interface{}(e.Body).(error); ok {
+		return err
+	}
+	return nil
+}
+
+// AddPetWithBodyTyped calls AddPetWithBodyWithResponse, and returns the body of its response when it's successful, or an error, such as AddPet409Error, for any other response.
+func (c *ClientWithResponses) AddPetWithBodyTyped(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*Pet, error) {
+	response, err := c.AddPetWithBodyWithResponse(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return AddPetTypedResult(response)
+}
+
+// AddPetTyped calls AddPetWithResponse, and returns the body of its response when it's successful, or an error, such as AddPet409Error, for any other response.
+func (c *ClientWithResponses) AddPetTyped(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*Pet, error) {
+	response, err := c.AddPetWithResponse(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return AddPetTypedResult(response)
+}
+
+// AddPetTypedResult returns the body of the response to the AddPet operation when it's successful, or an error for any other response.
+func AddPetTypedResult(response *AddPetResponse) (*Pet, error) {
+	if code := response.StatusCode(); code >= 200 && code <= 299 {
+		if response.JSON201 != nil {
+			return response.JSON201, nil
+		}
+		return nil, nil
+	}
+	switch {
+	case response.StatusCode() == 409:
+		return nil, &AddPet409Error{Response: response}
+	case response.StatusCode()/100 == 5:
+		return nil, &AddPet5XXError{Response: response, Body: response.JSON5XX}
+	}
+	return nil, &UnexpectedStatusError{OperationID: "AddPet", HTTPResponse: response.HTTPResponse, Body: response.Body}
+}
+
+// GetPet404Error is returned by the `GetPet...Typed` methods of the ClientWithResponses, when the response has the 404 status code.
+type GetPet404Error struct {
+	Response *GetPetResponse
+	// Body is the decoded JSON body of the response, which is nil when it doesn't have one
+	Body *NotFound
+}
+
+func (e *GetPet404Error) Error() string {
+	return fmt.Sprintf("the GetPet operation responded with %s", e.Response.Status())
+}
+
+// StatusCode returns the status code of the response.
+func (e *GetPet404Error) StatusCode() int {
+	return e.Response.StatusCode()
+}
+
+// Unwrap returns the Body, when its type is an error, so that it can be found with errors.As.
+func (e *GetPet404Error) Unwrap() error {
+	if e.Body == nil {
+		return nil
+	}
+	if err, ok := interface{}(e.Body).(error); ok {
+		return err
+	}
+	return nil
+}
+
+// GetPetDefaultError is returned by the `GetPet...Typed` methods of the ClientWithResponses, when the response has a status code which isn't otherwise described.
+type GetPetDefaultError struct {
+	Response *GetPetResponse
+	// Body is the decoded JSON body of the response, which is nil when it doesn't have one
+	Body *Error
+}
+
+func (e *GetPetDefaultError) Error() string {
+	return fmt.Sprintf("the GetPet operation responded with %s", e.Response.Status())
+}
+
+// StatusCode returns the status code of the response.
+func (e *GetPetDefaultError) StatusCode() int {
+	return e.Response.StatusCode()
+}
+
+// Unwrap returns the Body, when its type is an error, so that it can be found with errors.As.
+func (e *GetPetDefaultError) Unwrap() error {
+	if e.Body == nil {
+		return nil
+	}
+	if err, ok := interface{}(e.Body).(error); ok {
+		return err
+	}
+	return nil
+}
+
+// GetPetTyped calls GetPetWithResponse, and returns the body of its response when it's successful, or an error, such as GetPet404Error, for any other response.
+func (c *ClientWithResponses) GetPetTyped(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*Pet, error) {
+	response, err := c.GetPetWithResponse(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return GetPetTypedResult(response)
+}
+
+// GetPetTypedResult returns the body of the response to the GetPet operation when it's successful, or an error for any other response.
+func GetPetTypedResult(response *GetPetResponse) (*Pet, error) {
+	if code := response.StatusCode(); code >= 200 && code <= 299 {
+		if response.JSON200 != nil {
+			return response.JSON200, nil
+		}
+		return nil, nil
+	}
+	switch {
+	case response.StatusCode() == 404:
+		return nil, &GetPet404Error{Response: response, Body: response.JSON404}
+	default:
+		return nil, &GetPetDefaultError{Response: response, Body: response.JSONDefault}
+	}
+}
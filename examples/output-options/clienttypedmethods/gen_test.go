@@ -0,0 +1,121 @@
+package clienttypedmethods
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Error is the error schema of the API, which can be found with errors.As
+// because it implements error.
+func (e Error) Error() string {
+	return e.Message
+}
+
+func newClient(t *testing.T, status int, body string) *ClientWithResponses {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+
+	client, err := NewClientWithResponses(s.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func TestTypedMethodReturnsTheSuccessfulBody(t *testing.T) {
+	client := newClient(t, http.StatusOK, `{"id":1,"name":"Tom"}`)
+
+	pet, err := client.GetPetTyped(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &Pet{Id: 1, Name: "Tom"}, pet)
+}
+
+func TestTypedMethodReturnsAnErrorForTheStatusCode(t *testing.T) {
+	client := newClient(t, http.StatusNotFound, `{"id":1}`)
+
+	pet, err := client.GetPetTyped(context.Background(), 1)
+	assert.Nil(t, pet)
+
+	var notFound *GetPet404Error
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode())
+	assert.Equal(t, &NotFound{Id: 1}, notFound.Body)
+	assert.EqualError(t, err, "the GetPet operation responded with 404 Not Found")
+}
+
+func TestTypedMethodReturnsTheDefaultError(t *testing.T) {
+	client := newClient(t, http.StatusTeapot, `{"message":"I'm a teapot"}`)
+
+	_, err := client.GetPetTyped(context.Background(), 1)
+
+	var defaultErr *GetPetDefaultError
+	require.ErrorAs(t, err, &defaultErr)
+	assert.Equal(t, http.StatusTeapot, defaultErr.StatusCode())
+
+	// the body implements error, so is wrapped by the error
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "I'm a teapot", apiErr.Message)
+}
+
+func TestTypedMethodWithBody(t *testing.T) {
+	client := newClient(t, http.StatusCreated, `{"id":2,"name":"Jerry"}`)
+
+	pet, err := client.AddPetTyped(context.Background(), AddPetJSONRequestBody{Id: 2, Name: "Jerry"})
+	require.NoError(t, err)
+	assert.Equal(t, &Pet{Id: 2, Name: "Jerry"}, pet)
+}
+
+func TestTypedMethodReturnsAnErrorForTheRangeOfStatusCodes(t *testing.T) {
+	client := newClient(t, http.StatusBadGateway, `{"message":"the upstream failed"}`)
+
+	_, err := client.AddPetTyped(context.Background(), AddPetJSONRequestBody{Id: 2, Name: "Jerry"})
+
+	var serverErr *AddPet5XXError
+	require.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, http.StatusBadGateway, serverErr.StatusCode())
+	assert.Equal(t, "the upstream failed", serverErr.Body.Message)
+}
+
+func TestTypedMethodReturnsAnErrorWithoutABody(t *testing.T) {
+	client := newClient(t, http.StatusConflict, "")
+
+	_, err := client.AddPetTyped(context.Background(), AddPetJSONRequestBody{Id: 2, Name: "Jerry"})
+
+	var conflict *AddPet409Error
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, http.StatusConflict, conflict.StatusCode())
+}
+
+func TestTypedMethodReturnsAnErrorForAnUnexpectedStatusCode(t *testing.T) {
+	client := newClient(t, http.StatusBadRequest, `{"message":"bad request"}`)
+
+	_, err := client.AddPetTyped(context.Background(), AddPetJSONRequestBody{Id: 2, Name: "Jerry"})
+
+	var unexpected *UnexpectedStatusError
+	require.ErrorAs(t, err, &unexpected)
+	assert.Equal(t, "AddPet", unexpected.OperationID)
+	assert.Equal(t, http.StatusBadRequest, unexpected.StatusCode())
+	assert.JSONEq(t, `{"message":"bad request"}`, string(unexpected.Body))
+}
+
+func TestTypedMethodWithoutASuccessfulBody(t *testing.T) {
+	client := newClient(t, http.StatusNoContent, "")
+	assert.NoError(t, client.DeletePetsTyped(context.Background()))
+
+	client = newClient(t, http.StatusForbidden, "")
+	err := client.DeletePetsTyped(context.Background())
+	var unexpected *UnexpectedStatusError
+	assert.True(t, errors.As(err, &unexpected))
+}
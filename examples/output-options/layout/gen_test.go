@@ -0,0 +1,60 @@
+package layout
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/layout/client"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/layout/models"
+)
+
+type server struct {
+	pets []models.Pet
+}
+
+func (s *server) FindPets(ctx context.Context, request FindPetsRequestObject) (FindPetsResponseObject, error) {
+	var pets []Pet
+	for _, pet := range s.pets {
+		if request.Params.Status == nil || *request.Params.Status == pet.Status {
+			pets = append(pets, pet)
+		}
+	}
+	return FindPets200JSONResponse(pets), nil
+}
+
+func (s *server) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {
+	s.pets = append(s.pets, *request.Body)
+	return AddPet201JSONResponse(*request.Body), nil
+}
+
+func TestLayout(t *testing.T) {
+	srv := httptest.NewServer(Handler(NewStrictHandler(&server{}, nil)))
+	defer srv.Close()
+
+	c, err := client.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+
+	// the client, server and models packages all share the same types
+	added, err := c.AddPetWithResponse(context.Background(), models.Pet{Name: "Fido", Status: models.Available})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, added.StatusCode())
+	assert.Equal(t, &Pet{Name: "Fido", Status: Available}, added.JSON201)
+
+	_, err = c.AddPetWithResponse(context.Background(), client.Pet{Name: "Rex", Status: client.Sold})
+	require.NoError(t, err)
+
+	status := models.Sold
+	found, err := c.FindPetsWithResponse(context.Background(), &client.FindPetsParams{Status: &status})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, found.StatusCode())
+	assert.Equal(t, &[]models.Pet{{Name: "Rex", Status: models.Sold}}, found.JSON200)
+
+	swagger, err := GetSwagger()
+	require.NoError(t, err)
+	assert.NotNil(t, swagger.Paths.Find("/pets"))
+}
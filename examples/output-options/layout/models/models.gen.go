@@ -0,0 +1,63 @@
+//go:build go1.22
+
+// Package models provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package models
+
+import (
+	"fmt"
+)
+
+// Defines values for PetStatus.
+const (
+	Available PetStatus = "available"
+	Sold      PetStatus = "sold"
+)
+
+// Valid indicates whether the value is one of the values defined for PetStatus.
+func (e PetStatus) Valid() bool {
+	switch e {
+	case Available, Sold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllPetStatusValues returns each of the values defined for PetStatus.
+func AllPetStatusValues() []PetStatus {
+	return []PetStatus{
+		Available,
+		Sold,
+	}
+}
+
+// ParsePetStatus returns the PetStatus value which is represented by s, or an error if s isn't one of its values.
+func ParsePetStatus(s string) (PetStatus, error) {
+	switch s {
+	case "available":
+		return Available, nil
+	case "sold":
+		return Sold, nil
+	}
+	var zero PetStatus
+	return zero, fmt.Errorf("%q is not a valid PetStatus, it must be one of %v", s, AllPetStatusValues())
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Name   string    `json:"name"`
+	Status PetStatus `json:"status"`
+}
+
+// PetStatus defines model for PetStatus.
+type PetStatus string
+
+// FindPetsParams defines parameters for FindPets.
+type FindPetsParams struct {
+	Status *PetStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody = Pet
@@ -0,0 +1,102 @@
+//go:build go1.22
+
+// Package layout provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package layout
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/6ySQW8TMRCF/4o1cFx1U7j5BgcQt0hwq3qY2C+Jy67temaLotX+d2Rv0oCoBJU42bLn",
+	"zXv6ZmZyacwpIqqQnUncESO36xZaj1xSRtGA9hh5RD31lEGWREuIB1o6EmWdWsnbgj1ZetNfO/fntv0W",
+	"+nUtXJaOCh6nUODJ3q2Nn9vcdxeHtHuA0+pw1dqZEKexyviJw8C7oWnT4H9RXrJVpxD3qcUOOtS/z4go",
+	"rPDGJQ/jOJodjOQhqGFXkojZhwFiOHqT2X3nA4Q6ekKRkCJZur3Z3GxqrJQROQey9L49dZRZjy1kn7FS",
+	"PawoK0jWkOIXT5Y+hei3taAqCo9QFCF7N1OoBo8Tyom6M/ELmO48odeAvq+kJaco6wzfbTb1cCkqYgvG",
+	"OQ/BtWj9g6R43YR6C4rxXyZLyzN7LoVPK3oPcSVkXbF9O8I0Ku0vJ3kBzAdfudC6HxD9mPzpVYH/mvP3",
+	"5dMyYfmD0e3/t3wRhfnBYth7+Fq0LMvPAQAZczdgkwMAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,133 @@
+// Package rejectunknownenumvalues provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package rejectunknownenumvalues
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Defines values for Size.
+const (
+	N1 Size = 1
+	N2 Size = 2
+	N3 Size = 3
+)
+
+// Valid indicates whether the value is one of the values defined for Size.
+func (e Size) Valid() bool {
+	switch e {
+	case N1, N2, N3:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllSizeValues returns each of the values defined for Size.
+func AllSizeValues() []Size {
+	return []Size{
+		N1,
+		N2,
+		N3,
+	}
+}
+
+// ParseSize returns the Size value which is represented by s, or an error if s isn't one of its values.
+func ParseSize(s string) (Size, error) {
+	switch s {
+	case "1":
+		return N1, nil
+	case "2":
+		return N2, nil
+	case "3":
+		return N3, nil
+	}
+	var zero Size
+	return zero, fmt.Errorf("%q is not a valid Size, it must be one of %v", s, AllSizeValues())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, returning an error if the value isn't one of the values defined for Size.
+func (e *Size) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Size(value).Valid() {
+		return fmt.Errorf("%s is not a valid Size, it must be one of %v", data, AllSizeValues())
+	}
+	*e = Size(value)
+	return nil
+}
+
+// Defines values for Status.
+const (
+	Available Status = "available"
+	Pending   Status = "pending"
+	Sold      Status = "sold"
+)
+
+// Valid indicates whether the value is one of the values defined for Status.
+func (e Status) Valid() bool {
+	switch e {
+	case Available, Pending, Sold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllStatusValues returns each of the values defined for Status.
+func AllStatusValues() []Status {
+	return []Status{
+		Available,
+		Pending,
+		Sold,
+	}
+}
+
+// ParseStatus returns the Status value which is represented by s, or an error if s isn't one of its values.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "available":
+		return Available, nil
+	case "pending":
+		return Pending, nil
+	case "sold":
+		return Sold, nil
+	}
+	var zero Status
+	return zero, fmt.Errorf("%q is not a valid Status, it must be one of %v", s, AllStatusValues())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, returning an error if the value isn't one of the values defined for Status.
+func (e *Status) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !Status(value).Valid() {
+		return fmt.Errorf("%s is not a valid Status, it must be one of %v", data, AllStatusValues())
+	}
+	*e = Status(value)
+	return nil
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Size   *Size  `json:"size,omitempty"`
+	Status Status `json:"status"`
+}
+
+// Size defines model for Size.
+type Size int
+
+// Status defines model for Status.
+type Status string
@@ -0,0 +1,75 @@
+package rejectunknownenumvalues
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	t.Run("each of the values is valid", func(t *testing.T) {
+		assert.Equal(t, []Status{Available, Pending, Sold}, AllStatusValues())
+		for _, status := range AllStatusValues() {
+			assert.True(t, status.Valid(), status)
+		}
+	})
+
+	t.Run("an unknown value is not valid", func(t *testing.T) {
+		assert.False(t, Status("lost").Valid())
+	})
+
+	t.Run("a value can be parsed", func(t *testing.T) {
+		status, err := ParseStatus("pending")
+		require.NoError(t, err)
+		assert.Equal(t, Pending, status)
+	})
+
+	t.Run("an unknown value can't be parsed", func(t *testing.T) {
+		_, err := ParseStatus("lost")
+		require.EqualError(t, err, `"lost" is not a valid Status, it must be one of [available pending sold]`)
+	})
+}
+
+func TestSize(t *testing.T) {
+	size, err := ParseSize("2")
+	require.NoError(t, err)
+	assert.Equal(t, N2, size)
+
+	assert.True(t, size.Valid())
+	assert.False(t, Size(4).Valid())
+
+	_, err = ParseSize("4")
+	require.Error(t, err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("known values are decoded", func(t *testing.T) {
+		var pet Pet
+		require.NoError(t, json.Unmarshal([]byte(`{"status": "sold", "size": 3}`), &pet))
+
+		assert.Equal(t, Sold, pet.Status)
+		require.NotNil(t, pet.Size)
+		assert.Equal(t, N3, *pet.Size)
+	})
+
+	t.Run("null is ignored", func(t *testing.T) {
+		var pet Pet
+		require.NoError(t, json.Unmarshal([]byte(`{"status": "sold", "size": null}`), &pet))
+
+		assert.Nil(t, pet.Size)
+	})
+
+	t.Run("an unknown string is rejected", func(t *testing.T) {
+		var pet Pet
+		err := json.Unmarshal([]byte(`{"status": "lost"}`), &pet)
+		require.EqualError(t, err, `"lost" is not a valid Status, it must be one of [available pending sold]`)
+	})
+
+	t.Run("an unknown integer is rejected", func(t *testing.T) {
+		var pet Pet
+		err := json.Unmarshal([]byte(`{"status": "sold", "size": 4}`), &pet)
+		require.EqualError(t, err, `4 is not a valid Size, it must be one of [1 2 3]`)
+	})
+}
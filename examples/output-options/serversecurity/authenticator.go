@@ -0,0 +1,77 @@
+// Package serversecurity shows the SecurityHandler, which is generated with
+// the `server-security` output option, with each of the servers.
+package serversecurity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidCredentials is returned by the Authenticator for credentials
+// which it doesn't know.
+var ErrInvalidCredentials = errors.New("the credentials aren't valid")
+
+type userContextKey struct{}
+
+// User returns the user which a request was authenticated as, or
+// `anonymous` when it wasn't authenticated.
+func User(ctx context.Context) string {
+	if user, ok := ctx.Value(userContextKey{}).(string); ok {
+		return user
+	}
+	return "anonymous"
+}
+
+// Authenticator is the SecurityHandler of each of the servers, which knows a
+// user for each of the security schemes.
+type Authenticator struct{}
+
+func (Authenticator) HandleBearerAuth(ctx context.Context, token string, scopes []string) (context.Context, error) {
+	users := map[string]struct {
+		name   string
+		scopes []string
+	}{
+		"alice-token": {name: "alice", scopes: []string{"pets:write"}},
+		"bob-token":   {name: "bob"},
+	}
+	user, ok := users[token]
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(user.scopes, scope) {
+			return nil, fmt.Errorf("%s doesn't have the %s scope", user.name, scope)
+		}
+	}
+	return context.WithValue(ctx, userContextKey{}, user.name), nil
+}
+
+func (Authenticator) HandleBasicAuth(ctx context.Context, username, password string, scopes []string) (context.Context, error) {
+	if username != "carol" || password != "secret" {
+		return nil, ErrInvalidCredentials
+	}
+	return context.WithValue(ctx, userContextKey{}, username), nil
+}
+
+func (Authenticator) HandleApiKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error) {
+	if apiKey != "tenant-key" {
+		return nil, ErrInvalidCredentials
+	}
+	return ctx, nil
+}
+
+func (Authenticator) HandleSession(ctx context.Context, apiKey string, scopes []string) (context.Context, error) {
+	if apiKey != "dave-session" {
+		return nil, ErrInvalidCredentials
+	}
+	return context.WithValue(ctx, userContextKey{}, "dave"), nil
+}
+
+func (Authenticator) HandleQueryKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error) {
+	if apiKey != "erin-key" {
+		return nil, ErrInvalidCredentials
+	}
+	return context.WithValue(ctx, userContextKey{}, "erin"), nil
+}
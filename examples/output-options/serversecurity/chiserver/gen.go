@@ -0,0 +1,525 @@
+// Package chiserver provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package chiserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	ApiKeyScopes     = "apiKey.Scopes"
+	BasicAuthScopes  = "basicAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+	QueryKeyScopes   = "queryKey.Scopes"
+	SessionScopes    = "session.Scopes"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /health)
+	Health(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request)
+
+	// (POST /pets)
+	AddPet(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/count)
+	CountPets(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/search)
+	SearchPets(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// (GET /health)
+func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /pets)
+func (_ Unimplemented) ListPets(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /pets)
+func (_ Unimplemented) AddPet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /pets/count)
+func (_ Unimplemented) CountPets(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /pets/search)
+func (_ Unimplemented) SearchPets(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	SecurityHandler    SecurityHandler
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx, authErr := authenticate(ctx, siw.SecurityHandler, "ListPets", httpRequestSecurityCredentials(r))
+	if authErr != nil {
+		siw.ErrorHandlerFunc(w, r, authErr)
+		return
+	}
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"pets:write"})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx, authErr := authenticate(ctx, siw.SecurityHandler, "AddPet", httpRequestSecurityCredentials(r))
+	if authErr != nil {
+		siw.ErrorHandlerFunc(w, r, authErr)
+		return
+	}
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CountPets operation middleware
+func (siw *ServerInterfaceWrapper) CountPets(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionScopes, []string{})
+
+	ctx, authErr := authenticate(ctx, siw.SecurityHandler, "CountPets", httpRequestSecurityCredentials(r))
+	if authErr != nil {
+		siw.ErrorHandlerFunc(w, r, authErr)
+		return
+	}
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CountPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchPets operation middleware
+func (siw *ServerInterfaceWrapper) SearchPets(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, QueryKeyScopes, []string{})
+
+	ctx, authErr := authenticate(ctx, siw.SecurityHandler, "SearchPets", httpRequestSecurityCredentials(r))
+	if authErr != nil {
+		siw.ErrorHandlerFunc(w, r, authErr)
+		return
+	}
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// SecurityHandler authenticates the credentials of each request, for its operation's security requirements
+	SecurityHandler SecurityHandler
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			var securityErr *SecurityError
+			if errors.As(err, &securityErr) {
+				// why the request isn't authenticated isn't sent to the client
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+		SecurityHandler:    options.SecurityHandler,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets", wrapper.ListPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pets", wrapper.AddPet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets/count", wrapper.CountPets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pets/search", wrapper.SearchPets)
+	})
+
+	return r
+}
+
+// SecurityHandler authenticates the credentials of each of the security schemes which the operations' security requirements use.
+//
+// Each method is given the scopes of the operation's security requirement, and returns the context for the request, such as with the authenticated user, or an error when the credentials aren't valid.
+type SecurityHandler interface {
+	// HandleApiKey authenticates the API key, from the "X-API-Key" header, of the "apiKey" security scheme.
+	HandleApiKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+	// HandleBasicAuth authenticates the username and password of the "basicAuth" security scheme.
+	HandleBasicAuth(ctx context.Context, username, password string, scopes []string) (context.Context, error)
+	// HandleBearerAuth authenticates the token, from the `Authorization: Bearer` header, of the "bearerAuth" security scheme.
+	HandleBearerAuth(ctx context.Context, token string, scopes []string) (context.Context, error)
+	// HandleQueryKey authenticates the API key, from the "api_key" query, of the "queryKey" security scheme.
+	HandleQueryKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+	// HandleSession authenticates the API key, from the "session" cookie, of the "session" security scheme.
+	HandleSession(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+}
+
+// ErrSecurityCredentialsMissing is the error of a security requirement when the request doesn't have the credentials of one of its security schemes.
+var ErrSecurityCredentialsMissing = errors.New("the request has no credentials")
+
+// SecurityError is returned when none of the security requirements of an operation are met by a request, with the error of each requirement, which can be found with `errors.Is` and `errors.As`.
+type SecurityError struct {
+	OperationID string
+	Errors      []error
+}
+
+func (e *SecurityError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("the request for %s isn't authenticated: %s", e.OperationID, strings.Join(messages, ", or "))
+}
+
+func (e *SecurityError) Unwrap() []error {
+	return e.Errors
+}
+
+// securityRequirement is one of the security schemes of a security requirement, with the scopes that it needs.
+type securityRequirement struct {
+	scheme string
+	scopes []string
+}
+
+// serverSecurityRequirements are the alternative security requirements of each operation, any one of which needs to be met, where each is the security schemes whose credentials are authenticated together. An empty requirement can be met without any credentials, and an operation without any requirements doesn't need credentials.
+var serverSecurityRequirements = map[string][][]securityRequirement{
+	"ListPets": {
+		{{scheme: "bearerAuth", scopes: []string{}}},
+	},
+	"AddPet": {
+		{{scheme: "bearerAuth", scopes: []string{"pets:write"}}},
+		{{scheme: "apiKey", scopes: []string{}}, {scheme: "basicAuth", scopes: []string{}}},
+	},
+	"CountPets": {
+		{},
+		{{scheme: "session", scopes: []string{}}},
+	},
+	"SearchPets": {
+		{{scheme: "queryKey", scopes: []string{}}},
+	},
+}
+
+// securityCredentials looks up the credentials of a request, which are empty when the request doesn't have them.
+type securityCredentials struct {
+	header func(name string) string
+	query  func(name string) string
+	cookie func(name string) string
+}
+
+// httpRequestSecurityCredentials looks up the credentials of an http.Request.
+func httpRequestSecurityCredentials(r *http.Request) securityCredentials {
+	query := r.URL.Query()
+	return securityCredentials{
+		header: r.Header.Get,
+		query:  query.Get,
+		cookie: func(name string) string {
+			cookie, err := r.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		},
+	}
+}
+
+// authenticate evaluates the security requirements of an operation, and returns the context from the SecurityHandler for the first requirement which is met. A requirement which doesn't need any credentials is only met when the request doesn't have credentials for the others, rather than when its credentials aren't valid.
+func authenticate(ctx context.Context, handler SecurityHandler, operationID string, credentials securityCredentials) (context.Context, error) {
+	requirements := serverSecurityRequirements[operationID]
+	if len(requirements) == 0 {
+		return ctx, nil
+	}
+	if handler == nil {
+		return nil, &SecurityError{OperationID: operationID, Errors: []error{errors.New("the server has no SecurityHandler")}}
+	}
+
+	var errs []error
+	anonymous, rejected := false, false
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			anonymous = true
+			continue
+		}
+		authenticated, err := authenticateRequirement(ctx, handler, requirement, credentials)
+		if err == nil {
+			return authenticated, nil
+		}
+		if !errors.Is(err, ErrSecurityCredentialsMissing) {
+			rejected = true
+		}
+		errs = append(errs, err)
+	}
+	if anonymous && !rejected {
+		return ctx, nil
+	}
+	return nil, &SecurityError{OperationID: operationID, Errors: errs}
+}
+
+// authenticateRequirement authenticates the credentials of each of the security schemes of a requirement.
+func authenticateRequirement(ctx context.Context, handler SecurityHandler, requirement []securityRequirement, credentials securityCredentials) (context.Context, error) {
+	for _, r := range requirement {
+		var err error
+		switch r.scheme {
+		case "apiKey":
+			value := credentials.header("X-API-Key")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleApiKey(ctx, value, r.scopes)
+		case "basicAuth":
+			value, ok := securityAuthorization(credentials.header("Authorization"), "Basic")
+			if !ok {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			decoded, decodeErr := base64.StdEncoding.DecodeString(value)
+			username, password, found := strings.Cut(string(decoded), ":")
+			if decodeErr != nil || !found {
+				err = errors.New("the Basic credentials aren't a base64 encoded username and password")
+				break
+			}
+			ctx, err = handler.HandleBasicAuth(ctx, username, password, r.scopes)
+		case "bearerAuth":
+			value, ok := securityAuthorization(credentials.header("Authorization"), "Bearer")
+			if !ok {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleBearerAuth(ctx, value, r.scopes)
+		case "queryKey":
+			value := credentials.query("api_key")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleQueryKey(ctx, value, r.scopes)
+		case "session":
+			value := credentials.cookie("session")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleSession(ctx, value, r.scopes)
+		default:
+			err = errors.New("the security scheme isn't known")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.scheme, err)
+		}
+	}
+	return ctx, nil
+}
+
+// securityAuthorization returns the credentials of an `Authorization` header with the scheme, which is case-insensitive.
+func securityAuthorization(header string, scheme string) (string, bool) {
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) || header[len(scheme)] != ' ' {
+		return "", false
+	}
+	credentials := strings.TrimSpace(header[len(scheme)+1:])
+	return credentials, credentials != ""
+}
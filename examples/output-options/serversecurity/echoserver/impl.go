@@ -0,0 +1,23 @@
+package echoserver
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+)
+
+// Server responds with the user that each request was authenticated as.
+type Server struct{}
+
+var _ ServerInterface = Server{}
+
+func (Server) ListPets(ctx echo.Context) error   { return writeUser(ctx) }
+func (Server) AddPet(ctx echo.Context) error     { return writeUser(ctx) }
+func (Server) CountPets(ctx echo.Context) error  { return writeUser(ctx) }
+func (Server) SearchPets(ctx echo.Context) error { return writeUser(ctx) }
+func (Server) Health(ctx echo.Context) error     { return writeUser(ctx) }
+
+func writeUser(ctx echo.Context) error {
+	return ctx.String(http.StatusOK, serversecurity.User(ctx.Request().Context()))
+}
@@ -0,0 +1,21 @@
+package fiberserver
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+)
+
+// Server responds with the user that each request was authenticated as.
+type Server struct{}
+
+var _ ServerInterface = Server{}
+
+func (Server) ListPets(c *fiber.Ctx) error   { return writeUser(c) }
+func (Server) AddPet(c *fiber.Ctx) error     { return writeUser(c) }
+func (Server) CountPets(c *fiber.Ctx) error  { return writeUser(c) }
+func (Server) SearchPets(c *fiber.Ctx) error { return writeUser(c) }
+func (Server) Health(c *fiber.Ctx) error     { return writeUser(c) }
+
+func writeUser(c *fiber.Ctx) error {
+	return c.SendString(serversecurity.User(c.UserContext()))
+}
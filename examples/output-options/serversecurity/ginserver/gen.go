@@ -0,0 +1,381 @@
+// Package ginserver provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package ginserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ApiKeyScopes     = "apiKey.Scopes"
+	BasicAuthScopes  = "basicAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+	QueryKeyScopes   = "queryKey.Scopes"
+	SessionScopes    = "session.Scopes"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /health)
+	Health(c *gin.Context)
+
+	// (GET /pets)
+	ListPets(c *gin.Context)
+
+	// (POST /pets)
+	AddPet(c *gin.Context)
+
+	// (GET /pets/count)
+	CountPets(c *gin.Context)
+
+	// (GET /pets/search)
+	SearchPets(c *gin.Context)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+	SecurityHandler    SecurityHandler
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Health(c)
+}
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	authenticated, authErr := authenticate(c.Request.Context(), siw.SecurityHandler, "ListPets", httpRequestSecurityCredentials(c.Request))
+	if authErr != nil {
+		siw.ErrorHandler(c, authErr, http.StatusUnauthorized)
+		return
+	}
+	c.Request = c.Request.WithContext(authenticated)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListPets(c)
+}
+
+// AddPet operation middleware
+func (siw *ServerInterfaceWrapper) AddPet(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"pets:write"})
+
+	c.Set(ApiKeyScopes, []string{})
+
+	c.Set(BasicAuthScopes, []string{})
+
+	authenticated, authErr := authenticate(c.Request.Context(), siw.SecurityHandler, "AddPet", httpRequestSecurityCredentials(c.Request))
+	if authErr != nil {
+		siw.ErrorHandler(c, authErr, http.StatusUnauthorized)
+		return
+	}
+	c.Request = c.Request.WithContext(authenticated)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddPet(c)
+}
+
+// CountPets operation middleware
+func (siw *ServerInterfaceWrapper) CountPets(c *gin.Context) {
+
+	c.Set(SessionScopes, []string{})
+
+	authenticated, authErr := authenticate(c.Request.Context(), siw.SecurityHandler, "CountPets", httpRequestSecurityCredentials(c.Request))
+	if authErr != nil {
+		siw.ErrorHandler(c, authErr, http.StatusUnauthorized)
+		return
+	}
+	c.Request = c.Request.WithContext(authenticated)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CountPets(c)
+}
+
+// SearchPets operation middleware
+func (siw *ServerInterfaceWrapper) SearchPets(c *gin.Context) {
+
+	c.Set(QueryKeyScopes, []string{})
+
+	authenticated, authErr := authenticate(c.Request.Context(), siw.SecurityHandler, "SearchPets", httpRequestSecurityCredentials(c.Request))
+	if authErr != nil {
+		siw.ErrorHandler(c, authErr, http.StatusUnauthorized)
+		return
+	}
+	c.Request = c.Request.WithContext(authenticated)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SearchPets(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+	// SecurityHandler authenticates the credentials of each request, for its operation's security requirements
+	SecurityHandler SecurityHandler
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			var securityErr *SecurityError
+			if errors.As(err, &securityErr) {
+				// why the request isn't authenticated isn't sent to the client
+				c.JSON(statusCode, gin.H{"msg": http.StatusText(statusCode)})
+				return
+			}
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+		SecurityHandler:    options.SecurityHandler,
+	}
+
+	router.GET(options.BaseURL+"/health", wrapper.Health)
+	router.GET(options.BaseURL+"/pets", wrapper.ListPets)
+	router.POST(options.BaseURL+"/pets", wrapper.AddPet)
+	router.GET(options.BaseURL+"/pets/count", wrapper.CountPets)
+	router.GET(options.BaseURL+"/pets/search", wrapper.SearchPets)
+}
+
+// SecurityHandler authenticates the credentials of each of the security schemes which the operations' security requirements use.
+//
+// Each method is given the scopes of the operation's security requirement, and returns the context for the request, such as with the authenticated user, or an error when the credentials aren't valid.
+type SecurityHandler interface {
+	// HandleApiKey authenticates the API key, from the "X-API-Key" header, of the "apiKey" security scheme.
+	HandleApiKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+	// HandleBasicAuth authenticates the username and password of the "basicAuth" security scheme.
+	HandleBasicAuth(ctx context.Context, username, password string, scopes []string) (context.Context, error)
+	// HandleBearerAuth authenticates the token, from the `Authorization: Bearer` header, of the "bearerAuth" security scheme.
+	HandleBearerAuth(ctx context.Context, token string, scopes []string) (context.Context, error)
+	// HandleQueryKey authenticates the API key, from the "api_key" query, of the "queryKey" security scheme.
+	HandleQueryKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+	// HandleSession authenticates the API key, from the "session" cookie, of the "session" security scheme.
+	HandleSession(ctx context.Context, apiKey string, scopes []string) (context.Context, error)
+}
+
+// ErrSecurityCredentialsMissing is the error of a security requirement when the request doesn't have the credentials of one of its security schemes.
+var ErrSecurityCredentialsMissing = errors.New("the request has no credentials")
+
+// SecurityError is returned when none of the security requirements of an operation are met by a request, with the error of each requirement, which can be found with `errors.Is` and `errors.As`.
+type SecurityError struct {
+	OperationID string
+	Errors      []error
+}
+
+func (e *SecurityError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("the request for %s isn't authenticated: %s", e.OperationID, strings.Join(messages, ", or "))
+}
+
+func (e *SecurityError) Unwrap() []error {
+	return e.Errors
+}
+
+// securityRequirement is one of the security schemes of a security requirement, with the scopes that it needs.
+type securityRequirement struct {
+	scheme string
+	scopes []string
+}
+
+// serverSecurityRequirements are the alternative security requirements of each operation, any one of which needs to be met, where each is the security schemes whose credentials are authenticated together. An empty requirement can be met without any credentials, and an operation without any requirements doesn't need credentials.
+var serverSecurityRequirements = map[string][][]securityRequirement{
+	"ListPets": {
+		{{scheme: "bearerAuth", scopes: []string{}}},
+	},
+	"AddPet": {
+		{{scheme: "bearerAuth", scopes: []string{"pets:write"}}},
+		{{scheme: "apiKey", scopes: []string{}}, {scheme: "basicAuth", scopes: []string{}}},
+	},
+	"CountPets": {
+		{},
+		{{scheme: "session", scopes: []string{}}},
+	},
+	"SearchPets": {
+		{{scheme: "queryKey", scopes: []string{}}},
+	},
+}
+
+// securityCredentials looks up the credentials of a request, which are empty when the request doesn't have them.
+type securityCredentials struct {
+	header func(name string) string
+	query  func(name string) string
+	cookie func(name string) string
+}
+
+// httpRequestSecurityCredentials looks up the credentials of an http.Request.
+func httpRequestSecurityCredentials(r *http.Request) securityCredentials {
+	query := r.URL.Query()
+	return securityCredentials{
+		header: r.Header.Get,
+		query:  query.Get,
+		cookie: func(name string) string {
+			cookie, err := r.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		},
+	}
+}
+
+// authenticate evaluates the security requirements of an operation, and returns the context from the SecurityHandler for the first requirement which is met. A requirement which doesn't need any credentials is only met when the request doesn't have credentials for the others, rather than when its credentials aren't valid.
+func authenticate(ctx context.Context, handler SecurityHandler, operationID string, credentials securityCredentials) (context.Context, error) {
+	requirements := serverSecurityRequirements[operationID]
+	if len(requirements) == 0 {
+		return ctx, nil
+	}
+	if handler == nil {
+		return nil, &SecurityError{OperationID: operationID, Errors: []error{errors.New("the server has no SecurityHandler")}}
+	}
+
+	var errs []error
+	anonymous, rejected := false, false
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			anonymous = true
+			continue
+		}
+		authenticated, err := authenticateRequirement(ctx, handler, requirement, credentials)
+		if err == nil {
+			return authenticated, nil
+		}
+		if !errors.Is(err, ErrSecurityCredentialsMissing) {
+			rejected = true
+		}
+		errs = append(errs, err)
+	}
+	if anonymous && !rejected {
+		return ctx, nil
+	}
+	return nil, &SecurityError{OperationID: operationID, Errors: errs}
+}
+
+// authenticateRequirement authenticates the credentials of each of the security schemes of a requirement.
+func authenticateRequirement(ctx context.Context, handler SecurityHandler, requirement []securityRequirement, credentials securityCredentials) (context.Context, error) {
+	for _, r := range requirement {
+		var err error
+		switch r.scheme {
+		case "apiKey":
+			value := credentials.header("X-API-Key")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleApiKey(ctx, value, r.scopes)
+		case "basicAuth":
+			value, ok := securityAuthorization(credentials.header("Authorization"), "Basic")
+			if !ok {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			decoded, decodeErr := base64.StdEncoding.DecodeString(value)
+			username, password, found := strings.Cut(string(decoded), ":")
+			if decodeErr != nil || !found {
+				err = errors.New("the Basic credentials aren't a base64 encoded username and password")
+				break
+			}
+			ctx, err = handler.HandleBasicAuth(ctx, username, password, r.scopes)
+		case "bearerAuth":
+			value, ok := securityAuthorization(credentials.header("Authorization"), "Bearer")
+			if !ok {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleBearerAuth(ctx, value, r.scopes)
+		case "queryKey":
+			value := credentials.query("api_key")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleQueryKey(ctx, value, r.scopes)
+		case "session":
+			value := credentials.cookie("session")
+			if value == "" {
+				err = ErrSecurityCredentialsMissing
+				break
+			}
+			ctx, err = handler.HandleSession(ctx, value, r.scopes)
+		default:
+			err = errors.New("the security scheme isn't known")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.scheme, err)
+		}
+	}
+	return ctx, nil
+}
+
+// securityAuthorization returns the credentials of an `Authorization` header with the scheme, which is case-insensitive.
+func securityAuthorization(header string, scheme string) (string, bool) {
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) || header[len(scheme)] != ' ' {
+		return "", false
+	}
+	credentials := strings.TrimSpace(header[len(scheme)+1:])
+	return credentials, credentials != ""
+}
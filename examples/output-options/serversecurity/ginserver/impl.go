@@ -0,0 +1,23 @@
+package ginserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+)
+
+// Server responds with the user that each request was authenticated as.
+type Server struct{}
+
+var _ ServerInterface = Server{}
+
+func (Server) ListPets(c *gin.Context)   { writeUser(c) }
+func (Server) AddPet(c *gin.Context)     { writeUser(c) }
+func (Server) CountPets(c *gin.Context)  { writeUser(c) }
+func (Server) SearchPets(c *gin.Context) { writeUser(c) }
+func (Server) Health(c *gin.Context)     { writeUser(c) }
+
+func writeUser(c *gin.Context) {
+	c.String(http.StatusOK, serversecurity.User(c.Request.Context()))
+}
@@ -0,0 +1,21 @@
+package irisserver
+
+import (
+	"github.com/kataras/iris/v12"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+)
+
+// Server responds with the user that each request was authenticated as.
+type Server struct{}
+
+var _ ServerInterface = Server{}
+
+func (Server) ListPets(ctx iris.Context)   { writeUser(ctx) }
+func (Server) AddPet(ctx iris.Context)     { writeUser(ctx) }
+func (Server) CountPets(ctx iris.Context)  { writeUser(ctx) }
+func (Server) SearchPets(ctx iris.Context) { writeUser(ctx) }
+func (Server) Health(ctx iris.Context)     { writeUser(ctx) }
+
+func writeUser(ctx iris.Context) {
+	_, _ = ctx.WriteString(serversecurity.User(ctx.Request().Context()))
+}
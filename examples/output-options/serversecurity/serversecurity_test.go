@@ -0,0 +1,201 @@
+package serversecurity_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gorilla/mux"
+	"github.com/kataras/iris/v12"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/chiserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/echoserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/fiberserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/ginserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/gorillaserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/irisserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity/stdhttpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func servers() map[string]http.Handler {
+	authenticator := serversecurity.Authenticator{}
+
+	e := echo.New()
+	echoserver.RegisterHandlersWithOptions(e, echoserver.Server{}, echoserver.EchoServerOptions{SecurityHandler: authenticator})
+
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	ginserver.RegisterHandlersWithOptions(g, ginserver.Server{}, ginserver.GinServerOptions{SecurityHandler: authenticator})
+
+	f := fiber.New()
+	fiberserver.RegisterHandlersWithOptions(f, fiberserver.Server{}, fiberserver.FiberServerOptions{SecurityHandler: authenticator})
+
+	i := iris.New()
+	irisserver.RegisterHandlersWithOptions(i, irisserver.Server{}, irisserver.IrisServerOptions{SecurityHandler: authenticator})
+
+	return map[string]http.Handler{
+		"std-http": stdhttpserver.HandlerWithOptions(stdhttpserver.Server{}, stdhttpserver.StdHTTPServerOptions{
+			BaseRouter:      http.NewServeMux(),
+			SecurityHandler: authenticator,
+		}),
+		"chi": chiserver.HandlerWithOptions(chiserver.Server{}, chiserver.ChiServerOptions{
+			BaseRouter:      chi.NewRouter(),
+			SecurityHandler: authenticator,
+		}),
+		"gorilla": gorillaserver.HandlerWithOptions(gorillaserver.Server{}, gorillaserver.GorillaServerOptions{
+			BaseRouter:      mux.NewRouter(),
+			SecurityHandler: authenticator,
+		}),
+		"echo":  e,
+		"gin":   g,
+		"fiber": adaptor.FiberApp(f),
+		"iris":  i,
+	}
+}
+
+func TestServerSecurity(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header map[string]string
+		status int
+		user   string
+	}{
+		{
+			name:   "the top-level requirement is met by a bearer token",
+			path:   "/pets",
+			header: map[string]string{"Authorization": "Bearer bob-token"},
+			status: http.StatusOK,
+			user:   "bob",
+		},
+		{
+			name:   "the authorization scheme is case-insensitive",
+			path:   "/pets",
+			header: map[string]string{"Authorization": "bearer bob-token"},
+			status: http.StatusOK,
+			user:   "bob",
+		},
+		{
+			name:   "the top-level requirement needs credentials",
+			path:   "/pets",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "a token which isn't valid is rejected",
+			path:   "/pets",
+			header: map[string]string{"Authorization": "Bearer mallory-token"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "the first requirement is met by a token with the scope",
+			method: http.MethodPost,
+			path:   "/pets",
+			header: map[string]string{"Authorization": "Bearer alice-token"},
+			status: http.StatusOK,
+			user:   "alice",
+		},
+		{
+			name:   "a token without the scope doesn't meet the requirement",
+			method: http.MethodPost,
+			path:   "/pets",
+			header: map[string]string{"Authorization": "Bearer bob-token"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "the second requirement is met by an API key and a username and password",
+			method: http.MethodPost,
+			path:   "/pets",
+			header: map[string]string{"X-API-Key": "tenant-key", "Authorization": "Basic Y2Fyb2w6c2VjcmV0"},
+			status: http.StatusOK,
+			user:   "carol",
+		},
+		{
+			name:   "the second requirement needs both of its security schemes",
+			method: http.MethodPost,
+			path:   "/pets",
+			header: map[string]string{"X-API-Key": "tenant-key"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "an optional requirement doesn't need credentials",
+			path:   "/pets/count",
+			status: http.StatusOK,
+			user:   "anonymous",
+		},
+		{
+			name:   "an optional requirement uses a session cookie",
+			path:   "/pets/count",
+			header: map[string]string{"Cookie": "session=dave-session"},
+			status: http.StatusOK,
+			user:   "dave",
+		},
+		{
+			name:   "an optional requirement rejects a session cookie which isn't valid",
+			path:   "/pets/count",
+			header: map[string]string{"Cookie": "session=expired"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "an API key in the query",
+			path:   "/pets/search?api_key=erin-key",
+			status: http.StatusOK,
+			user:   "erin",
+		},
+		{
+			name:   "no security requirements",
+			path:   "/health",
+			status: http.StatusOK,
+			user:   "anonymous",
+		},
+	}
+
+	for name, handler := range servers() {
+		t.Run(name, func(t *testing.T) {
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					method := test.method
+					if method == "" {
+						method = http.MethodGet
+					}
+					req := httptest.NewRequest(method, test.path, nil)
+					for k, v := range test.header {
+						req.Header.Set(k, v)
+					}
+					rec := httptest.NewRecorder()
+					handler.ServeHTTP(rec, req)
+
+					body, err := io.ReadAll(rec.Result().Body)
+					require.NoError(t, err)
+					require.Equal(t, test.status, rec.Code, string(body))
+					if test.user != "" {
+						assert.Equal(t, test.user, string(body))
+					}
+					// why the request isn't authenticated isn't sent to the client
+					assert.NotContains(t, string(body), "isn't authenticated")
+				})
+			}
+		})
+	}
+}
+
+func TestServerSecurityNeedsASecurityHandler(t *testing.T) {
+	handler := stdhttpserver.Handler(stdhttpserver.Server{})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Unauthorized\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,22 @@
+package stdhttpserver
+
+import (
+	"net/http"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/output-options/serversecurity"
+)
+
+// Server responds with the user that each request was authenticated as.
+type Server struct{}
+
+var _ ServerInterface = Server{}
+
+func (Server) ListPets(w http.ResponseWriter, r *http.Request)   { writeUser(w, r) }
+func (Server) AddPet(w http.ResponseWriter, r *http.Request)     { writeUser(w, r) }
+func (Server) CountPets(w http.ResponseWriter, r *http.Request)  { writeUser(w, r) }
+func (Server) SearchPets(w http.ResponseWriter, r *http.Request) { writeUser(w, r) }
+func (Server) Health(w http.ResponseWriter, r *http.Request)     { writeUser(w, r) }
+
+func writeUser(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(serversecurity.User(r.Context())))
+}
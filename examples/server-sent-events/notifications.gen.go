@@ -0,0 +1,1240 @@
+//go:build go1.22
+
+// Package serversentevents provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package serversentevents
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Defines values for NotificationSeverity.
+const (
+	Info    NotificationSeverity = "info"
+	Warning NotificationSeverity = "warning"
+)
+
+// Valid indicates whether the value is one of the values defined for NotificationSeverity.
+func (e NotificationSeverity) Valid() bool {
+	switch e {
+	case Info, Warning:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllNotificationSeverityValues returns each of the values defined for NotificationSeverity.
+func AllNotificationSeverityValues() []NotificationSeverity {
+	return []NotificationSeverity{
+		Info,
+		Warning,
+	}
+}
+
+// ParseNotificationSeverity returns the NotificationSeverity value which is represented by s, or an error if s isn't one of its values.
+func ParseNotificationSeverity(s string) (NotificationSeverity, error) {
+	switch s {
+	case "info":
+		return Info, nil
+	case "warning":
+		return Warning, nil
+	}
+	var zero NotificationSeverity
+	return zero, fmt.Errorf("%q is not a valid NotificationSeverity, it must be one of %v", s, AllNotificationSeverityValues())
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	Message  string                `json:"message"`
+	Severity *NotificationSeverity `json:"severity,omitempty"`
+}
+
+// NotificationSeverity defines model for Notification.Severity.
+type NotificationSeverity string
+
+// WatchNotificationsParams defines parameters for WatchNotifications.
+type WatchNotificationsParams struct {
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// PublishNotificationJSONRequestBody defines body for PublishNotification for application/json ContentType.
+type PublishNotificationJSONRequestBody = Notification
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// TailLogs request
+	TailLogs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// WatchNotifications request
+	WatchNotifications(ctx context.Context, params *WatchNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PublishNotificationWithBody request with any body
+	PublishNotificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PublishNotification(ctx context.Context, body PublishNotificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) TailLogs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTailLogsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "TailLogs")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) WatchNotifications(ctx context.Context, params *WatchNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWatchNotificationsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "WatchNotifications")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) PublishNotificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPublishNotificationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "PublishNotification")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) PublishNotification(ctx context.Context, body PublishNotificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPublishNotificationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "PublishNotification")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewTailLogsRequest generates requests for TailLogs
+func NewTailLogsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/logs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewWatchNotificationsRequest generates requests for WatchNotifications
+func NewWatchNotificationsRequest(server string, params *WatchNotificationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPublishNotificationRequest calls the generic PublishNotification builder with application/json body
+func NewPublishNotificationRequest(server string, body PublishNotificationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPublishNotificationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPublishNotificationRequestWithBody generates requests for PublishNotification with any type of body
+func NewPublishNotificationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/notifications")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// TailLogsWithResponse request
+	TailLogsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TailLogsResponse, error)
+
+	// WatchNotificationsWithResponse request
+	WatchNotificationsWithResponse(ctx context.Context, params *WatchNotificationsParams, reqEditors ...RequestEditorFn) (*WatchNotificationsResponse, error)
+
+	// PublishNotificationWithBodyWithResponse request with any body
+	PublishNotificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PublishNotificationResponse, error)
+
+	PublishNotificationWithResponse(ctx context.Context, body PublishNotificationJSONRequestBody, reqEditors ...RequestEditorFn) (*PublishNotificationResponse, error)
+}
+
+type TailLogsResponse struct {
+	Body           []byte
+	HTTPResponse   *http.Response
+	EventStream200 *ServerSentEventReader[string]
+}
+
+// Status returns HTTPResponse.Status
+func (r TailLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TailLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WatchNotificationsResponse struct {
+	Body           []byte
+	HTTPResponse   *http.Response
+	EventStream200 *ServerSentEventReader[Notification]
+	JSONDefault    *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r WatchNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WatchNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PublishNotificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PublishNotificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PublishNotificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// TailLogsWithResponse request returning *TailLogsResponse
+func (c *ClientWithResponses) TailLogsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TailLogsResponse, error) {
+	rsp, err := c.TailLogs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTailLogsResponse(rsp)
+}
+
+// WatchNotificationsWithResponse request returning *WatchNotificationsResponse
+func (c *ClientWithResponses) WatchNotificationsWithResponse(ctx context.Context, params *WatchNotificationsParams, reqEditors ...RequestEditorFn) (*WatchNotificationsResponse, error) {
+	rsp, err := c.WatchNotifications(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWatchNotificationsResponse(rsp)
+}
+
+// PublishNotificationWithBodyWithResponse request with arbitrary body returning *PublishNotificationResponse
+func (c *ClientWithResponses) PublishNotificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PublishNotificationResponse, error) {
+	rsp, err := c.PublishNotificationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishNotificationResponse(rsp)
+}
+
+func (c *ClientWithResponses) PublishNotificationWithResponse(ctx context.Context, body PublishNotificationJSONRequestBody, reqEditors ...RequestEditorFn) (*PublishNotificationResponse, error) {
+	rsp, err := c.PublishNotification(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishNotificationResponse(rsp)
+}
+
+// ParseTailLogsResponse parses an HTTP response from a TailLogsWithResponse call
+func ParseTailLogsResponse(rsp *http.Response) (*TailLogsResponse, error) {
+	mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" && rsp.StatusCode == 200 {
+		return &TailLogsResponse{
+			HTTPResponse:   rsp,
+			EventStream200: newServerSentEventReader[string](rsp.Body),
+		}, nil
+	}
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TailLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseWatchNotificationsResponse parses an HTTP response from a WatchNotificationsWithResponse call
+func ParseWatchNotificationsResponse(rsp *http.Response) (*WatchNotificationsResponse, error) {
+	mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" && rsp.StatusCode == 200 {
+		return &WatchNotificationsResponse{
+			HTTPResponse:   rsp,
+			EventStream200: newServerSentEventReader[Notification](rsp.Body),
+		}, nil
+	}
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WatchNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePublishNotificationResponse parses an HTTP response from a PublishNotificationWithResponse call
+func ParsePublishNotificationResponse(rsp *http.Response) (*PublishNotificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PublishNotificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ServerSentEvent is an event of a text/event-stream response, whose data is decoded as T.
+type ServerSentEvent[T any] struct {
+	// Event is the type of the event, which is `message` when it's empty
+	Event string
+	// ID is the ID of the event, which is the last ID that was sent, if any
+	ID string
+	// Retry is how long the client should wait before reconnecting, when it isn't zero
+	Retry time.Duration
+	// Data is the data of the event, which is encoded as JSON, unless T is a string
+	Data T
+}
+
+// ServerSentEventWriter writes the events of a text/event-stream response, flushing each of them as soon as it's written.
+type ServerSentEventWriter[T any] struct {
+	w io.Writer
+}
+
+// newServerSentEventWriter returns a ServerSentEventWriter, having flushed the response's headers, so that the client can start reading its events.
+func newServerSentEventWriter[T any](w io.Writer) *ServerSentEventWriter[T] {
+	flushServerSentEvents(w)
+	return &ServerSentEventWriter[T]{w: w}
+}
+
+// Send writes an event, whose data is encoded as JSON, unless T is a string, in which case it's written as is.
+func (s *ServerSentEventWriter[T]) Send(event ServerSentEvent[T]) error {
+	var data string
+	if text, ok := any(event.Data).(string); ok {
+		data = text
+	} else {
+		encoded, err := json.Marshal(event.Data)
+		if err != nil {
+			return err
+		}
+		data = string(encoded)
+	}
+	if strings.ContainsAny(event.Event, "\r\n") {
+		return errors.New("the type of an event can't contain a line break")
+	}
+	if strings.ContainsAny(event.ID, "\r\n\x00") {
+		return errors.New("the ID of an event can't contain a line break or NULL")
+	}
+
+	var b strings.Builder
+	if event.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event.Event)
+	}
+	if event.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", event.ID)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", event.Retry.Milliseconds())
+	}
+	for _, line := range splitServerSentEventLines(data) {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return err
+	}
+	flushServerSentEvents(s.w)
+	return nil
+}
+
+// Comment writes a comment, which the client ignores, such as to keep the connection alive.
+func (s *ServerSentEventWriter[T]) Comment(comment string) error {
+	var b strings.Builder
+	for _, line := range splitServerSentEventLines(comment) {
+		fmt.Fprintf(&b, ": %s\n", line)
+	}
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return err
+	}
+	flushServerSentEvents(s.w)
+	return nil
+}
+
+// splitServerSentEventLines splits text into the lines of a field, which end with CRLF, LF or CR.
+func splitServerSentEventLines(text string) []string {
+	return strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text), "\n")
+}
+
+// flushServerSentEvents flushes the events which have been written, when the writer can be flushed.
+func flushServerSentEvents(w io.Writer) {
+	switch f := w.(type) {
+	case http.ResponseWriter:
+		_ = http.NewResponseController(f).Flush()
+	case interface{ Flush() error }:
+		_ = f.Flush()
+	}
+}
+
+// ServerSentEventReader reads the events of a text/event-stream response, decoding each of them as it's read. It must be closed once it's no longer needed, which closes the response's body.
+type ServerSentEventReader[T any] struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	lastID  string
+	retry   time.Duration
+}
+
+// newServerSentEventReader returns a ServerSentEventReader for the body of a text/event-stream response.
+func newServerSentEventReader[T any](body io.ReadCloser) *ServerSentEventReader[T] {
+	scanner := bufio.NewScanner(body)
+	// the data of an event can be much larger than the scanner's default limit of a line
+	scanner.Buffer(make([]byte, 0, 4096), 16<<20)
+	scanner.Split(scanServerSentEventLines)
+	return &ServerSentEventReader[T]{body: body, scanner: scanner}
+}
+
+// Next reads the next event, returning io.EOF once the response has ended. An error decoding the data of an event is returned with the rest of the event, after which the next event can still be read.
+func (r *ServerSentEventReader[T]) Next() (ServerSentEvent[T], error) {
+	var event ServerSentEvent[T]
+	var data strings.Builder
+	hasData := false
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			// a blank line dispatches the event, unless it has no data
+			if !hasData {
+				event.Event = ""
+				continue
+			}
+			event.ID = r.lastID
+			event.Retry = r.retry
+			return event, decodeServerSentEventData(strings.TrimSuffix(data.String(), "\n"), &event.Data)
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteString("\n")
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				r.lastID = value
+			}
+		case "retry":
+			if milliseconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+				r.retry = time.Duration(milliseconds) * time.Millisecond
+			}
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return ServerSentEvent[T]{}, err
+	}
+	// an event which hasn't been dispatched by a blank line is discarded
+	return ServerSentEvent[T]{}, io.EOF
+}
+
+// All iterates over the rest of the events, until the response ends or there's an error, which is the last item. It doesn't close the reader.
+func (r *ServerSentEventReader[T]) All() iter.Seq2[ServerSentEvent[T], error] {
+	return func(yield func(ServerSentEvent[T], error) bool) {
+		for {
+			event, err := r.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(event, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// LastEventID returns the ID of the last event, if any, which the client can reconnect with as the Last-Event-ID header.
+func (r *ServerSentEventReader[T]) LastEventID() string {
+	return r.lastID
+}
+
+// Close closes the body of the response.
+func (r *ServerSentEventReader[T]) Close() error {
+	return r.body.Close()
+}
+
+// decodeServerSentEventData decodes the data of an event, which is JSON, unless T is a string.
+func decodeServerSentEventData[T any](data string, dest *T) error {
+	if text, ok := any(dest).(*string); ok {
+		*text = data
+		return nil
+	}
+	return json.Unmarshal([]byte(data), dest)
+}
+
+// scanServerSentEventLines splits a text/event-stream into lines, which end with CRLF, LF or CR.
+func scanServerSentEventLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\r' {
+			if i+1 == len(data) && !atEOF {
+				// the CR may be followed by a LF which hasn't been read yet
+				return 0, nil, nil
+			}
+			if i+1 < len(data) && data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /logs)
+	TailLogs(w http.ResponseWriter, r *http.Request)
+
+	// (GET /notifications)
+	WatchNotifications(w http.ResponseWriter, r *http.Request, params WatchNotificationsParams)
+
+	// (POST /notifications)
+	PublishNotification(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// TailLogs operation middleware
+func (siw *ServerInterfaceWrapper) TailLogs(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TailLogs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// WatchNotifications operation middleware
+func (siw *ServerInterfaceWrapper) WatchNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchNotificationsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.WatchNotifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PublishNotification operation middleware
+func (siw *ServerInterfaceWrapper) PublishNotification(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishNotification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/logs", wrapper.TailLogs)
+	m.HandleFunc("GET "+options.BaseURL+"/notifications", wrapper.WatchNotifications)
+	m.HandleFunc("POST "+options.BaseURL+"/notifications", wrapper.PublishNotification)
+
+	return m
+}
+
+type TailLogsRequestObject struct {
+}
+
+type TailLogsResponseObject interface {
+	VisitTailLogsResponse(w http.ResponseWriter) error
+}
+
+type TailLogs200EventStreamResponse func(events *ServerSentEventWriter[string]) error
+
+func (response TailLogs200EventStreamResponse) VisitTailLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(200)
+
+	return response(newServerSentEventWriter[string](w))
+}
+
+type WatchNotificationsRequestObject struct {
+	Params WatchNotificationsParams
+}
+
+type WatchNotificationsResponseObject interface {
+	VisitWatchNotificationsResponse(w http.ResponseWriter) error
+}
+
+type WatchNotifications200EventStreamResponse func(events *ServerSentEventWriter[Notification]) error
+
+func (response WatchNotifications200EventStreamResponse) VisitWatchNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(200)
+
+	return response(newServerSentEventWriter[Notification](w))
+}
+
+type WatchNotificationsdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response WatchNotificationsdefaultJSONResponse) VisitWatchNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PublishNotificationRequestObject struct {
+	Body *PublishNotificationJSONRequestBody
+}
+
+type PublishNotificationResponseObject interface {
+	VisitPublishNotificationResponse(w http.ResponseWriter) error
+}
+
+type PublishNotification204Response struct {
+}
+
+func (response PublishNotification204Response) VisitPublishNotificationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /logs)
+	TailLogs(ctx context.Context, request TailLogsRequestObject) (TailLogsResponseObject, error)
+
+	// (GET /notifications)
+	WatchNotifications(ctx context.Context, request WatchNotificationsRequestObject) (WatchNotificationsResponseObject, error)
+
+	// (POST /notifications)
+	PublishNotification(ctx context.Context, request PublishNotificationRequestObject) (PublishNotificationResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// TailLogs operation middleware
+func (sh *strictHandler) TailLogs(w http.ResponseWriter, r *http.Request) {
+	var request TailLogsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.TailLogs(ctx, request.(TailLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TailLogs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(TailLogsResponseObject); ok {
+		if err := validResponse.VisitTailLogsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// WatchNotifications operation middleware
+func (sh *strictHandler) WatchNotifications(w http.ResponseWriter, r *http.Request, params WatchNotificationsParams) {
+	var request WatchNotificationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.WatchNotifications(ctx, request.(WatchNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "WatchNotifications")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(WatchNotificationsResponseObject); ok {
+		if err := validResponse.VisitWatchNotificationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PublishNotification operation middleware
+func (sh *strictHandler) PublishNotification(w http.ResponseWriter, r *http.Request) {
+	var request PublishNotificationRequestObject
+
+	var body PublishNotificationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PublishNotification(ctx, request.(PublishNotificationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PublishNotification")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PublishNotificationResponseObject); ok {
+		if err := validResponse.VisitPublishNotificationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -0,0 +1,176 @@
+package serversentevents
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, ssi StrictServerInterface) (*httptest.Server, *ClientWithResponses) {
+	t.Helper()
+	server := httptest.NewServer(Handler(NewStrictHandler(ssi, nil)))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	return server, client
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func publish(t *testing.T, client *ClientWithResponses, message string) {
+	t.Helper()
+	rsp, err := client.PublishNotificationWithResponse(context.Background(), Notification{Message: message})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, rsp.StatusCode())
+}
+
+func TestWatchNotifications(t *testing.T) {
+	_, client := newTestServer(t, NewServer())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rsp, err := client.WatchNotificationsWithResponse(ctx, &WatchNotificationsParams{})
+	require.NoError(t, err)
+	require.NotNil(t, rsp.EventStream200)
+	defer rsp.EventStream200.Close()
+	assert.Equal(t, "no-cache", rsp.HTTPResponse.Header.Get("Cache-Control"))
+
+	// the notifications are received as they're published, while the
+	// response is still being sent
+	for i, message := range []string{"first", "second", "third"} {
+		publish(t, client, message)
+
+		event, err := rsp.EventStream200.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "notification", event.Event)
+		assert.Equal(t, []string{"0", "1", "2"}[i], event.ID)
+		assert.Equal(t, message, event.Data.Message)
+	}
+	assert.Equal(t, "2", rsp.EventStream200.LastEventID())
+}
+
+func TestResumeNotifications(t *testing.T) {
+	_, client := newTestServer(t, NewServer())
+	for _, message := range []string{"first", "second", "third"} {
+		publish(t, client, message)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rsp, err := client.WatchNotificationsWithResponse(ctx, &WatchNotificationsParams{LastEventID: ptr("0")})
+	require.NoError(t, err)
+	require.NotNil(t, rsp.EventStream200)
+	defer rsp.EventStream200.Close()
+
+	var messages []string
+	for event, err := range rsp.EventStream200.All() {
+		require.NoError(t, err)
+		messages = append(messages, event.Data.Message)
+		if len(messages) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"second", "third"}, messages)
+
+	rsp, err = client.WatchNotificationsWithResponse(ctx, &WatchNotificationsParams{LastEventID: ptr("latest")})
+	require.NoError(t, err)
+	assert.Nil(t, rsp.EventStream200)
+	require.NotNil(t, rsp.JSONDefault)
+	assert.Equal(t, "the Last-Event-ID must be the ID of a notification", rsp.JSONDefault.Message)
+}
+
+func TestTailLogs(t *testing.T) {
+	server, client := newTestServer(t, NewServer("starting", "listening on\n:8080"))
+
+	rsp, err := client.TailLogsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.EventStream200)
+	defer rsp.EventStream200.Close()
+
+	var lines []string
+	for event, err := range rsp.EventStream200.All() {
+		require.NoError(t, err)
+		assert.Empty(t, event.Event)
+		assert.Equal(t, 5*time.Second, event.Retry)
+		lines = append(lines, event.Data)
+	}
+	assert.Equal(t, []string{"starting", "listening on\n:8080"}, lines)
+
+	// the text of the events is written as is, with a line for each line of
+	// their data
+	raw, err := http.Get(server.URL + "/logs")
+	require.NoError(t, err)
+	defer raw.Body.Close()
+	body, err := io.ReadAll(raw.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream", raw.Header.Get("Content-Type"))
+	assert.Equal(t, ": the logs so far\nretry: 5000\ndata: starting\n\nretry: 5000\ndata: listening on\ndata: :8080\n\n", string(body))
+}
+
+func TestReadEvents(t *testing.T) {
+	// the events are read as the HTML standard describes, whichever line
+	// endings they use
+	body := strings.Join([]string{
+		": a comment",
+		"event: notification",
+		"id: 7",
+		`data: {"message":`,
+		`data:"hello"}`,
+		"",
+		"event: ignored, as it has no data",
+		"",
+		"retry: 100\r",
+		`data: {"message": "crlf"}` + "\r",
+		"\r",
+		`data: {"message": "cr"}` + "\r\r",
+		`data: not JSON`,
+		"",
+		`data: {"message": "after an error"}`,
+		"",
+		`data: {"message": "not dispatched"}`,
+	}, "\n")
+
+	reader := newServerSentEventReader[Notification](io.NopCloser(strings.NewReader(body)))
+
+	event, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ServerSentEvent[Notification]{Event: "notification", ID: "7", Data: Notification{Message: "hello"}}, event)
+
+	event, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ServerSentEvent[Notification]{ID: "7", Retry: 100 * time.Millisecond, Data: Notification{Message: "crlf"}}, event)
+
+	event, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "cr", event.Data.Message)
+
+	_, err = reader.Next()
+	assert.Error(t, err)
+
+	event, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "after an error", event.Data.Message)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestWriteEvents(t *testing.T) {
+	var b strings.Builder
+	writer := newServerSentEventWriter[Notification](&b)
+
+	require.NoError(t, writer.Send(ServerSentEvent[Notification]{Event: "notification", ID: "1", Data: Notification{Message: "hello", Severity: ptr(Warning)}}))
+	assert.Equal(t, "event: notification\nid: 1\ndata: {\"message\":\"hello\",\"severity\":\"warning\"}\n\n", b.String())
+
+	assert.Error(t, writer.Send(ServerSentEvent[Notification]{Event: "two\nlines"}))
+	assert.Error(t, writer.Send(ServerSentEvent[Notification]{ID: "two\rlines"}))
+}
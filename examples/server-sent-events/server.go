@@ -0,0 +1,110 @@
+package serversentevents
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Server publishes notifications to the clients which are watching them, and
+// keeps them so that clients can resume from the last one they received.
+type Server struct {
+	lock          sync.Mutex
+	notifications []Notification
+	watchers      map[chan struct{}]struct{}
+	logs          []string
+}
+
+var _ StrictServerInterface = (*Server)(nil)
+
+// NewServer returns a Server, whose logs are the given lines.
+func NewServer(logs ...string) *Server {
+	return &Server{
+		watchers: map[chan struct{}]struct{}{},
+		logs:     logs,
+	}
+}
+
+func (s *Server) PublishNotification(ctx context.Context, request PublishNotificationRequestObject) (PublishNotificationResponseObject, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.notifications = append(s.notifications, *request.Body)
+	for watcher := range s.watchers {
+		select {
+		case watcher <- struct{}{}:
+		default:
+			// the watcher is already due to send the notifications
+		}
+	}
+	return PublishNotification204Response{}, nil
+}
+
+func (s *Server) WatchNotifications(ctx context.Context, request WatchNotificationsRequestObject) (WatchNotificationsResponseObject, error) {
+	next := 0
+	if request.Params.LastEventID != nil {
+		last, err := strconv.Atoi(*request.Params.LastEventID)
+		if err != nil {
+			return WatchNotificationsdefaultJSONResponse{
+				Body:       Error{Message: "the Last-Event-ID must be the ID of a notification"},
+				StatusCode: 400,
+			}, nil
+		}
+		next = last + 1
+	}
+
+	// the watcher is registered before the response is sent, so that no
+	// notifications are missed once the client has the response
+	watcher := make(chan struct{}, 1)
+	s.lock.Lock()
+	s.watchers[watcher] = struct{}{}
+	s.lock.Unlock()
+
+	return WatchNotifications200EventStreamResponse(func(events *ServerSentEventWriter[Notification]) error {
+		defer func() {
+			s.lock.Lock()
+			delete(s.watchers, watcher)
+			s.lock.Unlock()
+		}()
+		for {
+			s.lock.Lock()
+			pending := s.notifications[min(next, len(s.notifications)):]
+			s.lock.Unlock()
+			for _, notification := range pending {
+				err := events.Send(ServerSentEvent[Notification]{
+					Event: "notification",
+					ID:    strconv.Itoa(next),
+					Data:  notification,
+				})
+				if err != nil {
+					return err
+				}
+				next++
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-watcher:
+			}
+		}
+	}), nil
+}
+
+func (s *Server) TailLogs(ctx context.Context, request TailLogsRequestObject) (TailLogsResponseObject, error) {
+	return TailLogs200EventStreamResponse(func(events *ServerSentEventWriter[string]) error {
+		if err := events.Comment("the logs so far"); err != nil {
+			return err
+		}
+		for _, line := range s.logs {
+			err := events.Send(ServerSentEvent[string]{
+				Retry: 5 * time.Second,
+				Data:  line,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,470 @@
+// Package echoserver provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package echoserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// Defines values for ExportPetsParamsFormat.
+const (
+	JsonSeq ExportPetsParamsFormat = "json-seq"
+	Ndjson  ExportPetsParamsFormat = "ndjson"
+)
+
+// Valid indicates whether the value is one of the values defined for ExportPetsParamsFormat.
+func (e ExportPetsParamsFormat) Valid() bool {
+	switch e {
+	case JsonSeq, Ndjson:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllExportPetsParamsFormatValues returns each of the values defined for ExportPetsParamsFormat.
+func AllExportPetsParamsFormatValues() []ExportPetsParamsFormat {
+	return []ExportPetsParamsFormat{
+		JsonSeq,
+		Ndjson,
+	}
+}
+
+// ParseExportPetsParamsFormat returns the ExportPetsParamsFormat value which is represented by s, or an error if s isn't one of its values.
+func ParseExportPetsParamsFormat(s string) (ExportPetsParamsFormat, error) {
+	switch s {
+	case "json-seq":
+		return JsonSeq, nil
+	case "ndjson":
+		return Ndjson, nil
+	}
+	var zero ExportPetsParamsFormat
+	return zero, fmt.Errorf("%q is not a valid ExportPetsParamsFormat, it must be one of %v", s, AllExportPetsParamsFormatValues())
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	Imported int `json:"imported"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64   `json:"id"`
+	Name string  `json:"name"`
+	Tag  *string `json:"tag,omitempty"`
+}
+
+// ImportPetsJSONBody defines parameters for ImportPets.
+type ImportPetsJSONBody = []Pet
+
+// ExportPetsParams defines parameters for ExportPets.
+type ExportPetsParams struct {
+	Format *ExportPetsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportPetsParamsFormat defines parameters for ExportPets.
+type ExportPetsParamsFormat string
+
+// ImportPetsJSONRequestBody defines body for ImportPets for application/json ContentType.
+type ImportPetsJSONRequestBody = ImportPetsJSONBody
+
+// ImportPetsNDJSONRequestBody defines body for ImportPets for application/x-ndjson ContentType.
+type ImportPetsNDJSONRequestBody = Pet
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx echo.Context) error
+
+	// (POST /pets)
+	ImportPets(ctx echo.Context) error
+
+	// (GET /pets/export)
+	ExportPets(ctx echo.Context, params ExportPetsParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// ListPets converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPets(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListPets(ctx)
+	return err
+}
+
+// ImportPets converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportPets(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ImportPets(ctx)
+	return err
+}
+
+// ExportPets converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportPets(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportPetsParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportPets(ctx, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/pets", wrapper.ListPets)
+	router.POST(baseURL+"/pets", wrapper.ImportPets)
+	router.GET(baseURL+"/pets/export", wrapper.ExportPets)
+
+}
+
+type ListPetsRequestObject struct {
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200ResponseHeaders struct {
+	XTotalCount int
+}
+
+type ListPets200JSONResponse struct {
+	Body    iter.Seq2[Pet, error]
+	Headers ListPets200ResponseHeaders
+}
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "json", response.Body)
+}
+
+type ImportPetsRequestObject struct {
+	JSONBody   iter.Seq2[Pet, error]
+	NDJSONBody iter.Seq2[Pet, error]
+}
+
+type ImportPetsResponseObject interface {
+	VisitImportPetsResponse(w http.ResponseWriter) error
+}
+
+type ImportPets200JSONResponse ImportResult
+
+func (response ImportPets200JSONResponse) VisitImportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPets400JSONResponse Error
+
+func (response ImportPets400JSONResponse) VisitImportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportPetsRequestObject struct {
+	Params ExportPetsParams
+}
+
+type ExportPetsResponseObject interface {
+	VisitExportPetsResponse(w http.ResponseWriter) error
+}
+
+type ExportPets200JSONSeqResponse iter.Seq2[Pet, error]
+
+func (response ExportPets200JSONSeqResponse) VisitExportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json-seq")
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "json-seq", iter.Seq2[Pet, error](response))
+}
+
+type ExportPets200NDJSONResponse iter.Seq2[Pet, error]
+
+func (response ExportPets200NDJSONResponse) VisitExportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "ndjson", iter.Seq2[Pet, error](response))
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	ImportPets(ctx context.Context, request ImportPetsRequestObject) (ImportPetsResponseObject, error)
+
+	// (GET /pets/export)
+	ExportPets(ctx context.Context, request ExportPetsRequestObject) (ExportPetsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(ctx echo.Context) error {
+	var request ListPetsRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPets(ctx.Request().Context(), request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		return validResponse.VisitListPetsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ImportPets operation middleware
+func (sh *strictHandler) ImportPets(ctx echo.Context) error {
+	var request ImportPetsRequestObject
+
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/json") {
+		request.JSONBody = decodeJSONStream[Pet](ctx.Request().Body, "json")
+	}
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/x-ndjson") {
+		request.NDJSONBody = decodeJSONStream[Pet](ctx.Request().Body, "ndjson")
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportPets(ctx.Request().Context(), request.(ImportPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportPets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ImportPetsResponseObject); ok {
+		return validResponse.VisitImportPetsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ExportPets operation middleware
+func (sh *strictHandler) ExportPets(ctx echo.Context, params ExportPetsParams) error {
+	var request ExportPetsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportPets(ctx.Request().Context(), request.(ExportPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportPets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ExportPetsResponseObject); ok {
+		return validResponse.VisitExportPetsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// encodeJSONStream writes each of the items of a stream as it's iterated over, in the format of its media type, which is `json` for the elements of a JSON array, `ndjson` for newline delimited JSON, or `json-seq` for a JSON text sequence. When the writer can be flushed, such as an http.ResponseWriter, each item is flushed as soon as it's written.
+func encodeJSONStream[T any](w io.Writer, format string, items iter.Seq2[T, error]) error {
+	var err error
+	first := true
+	if format == "json" {
+		if _, err = io.WriteString(w, "["); err != nil {
+			return err
+		}
+	}
+	items(func(item T, itemErr error) bool {
+		if itemErr != nil {
+			err = itemErr
+			return false
+		}
+		var data []byte
+		if data, err = json.Marshal(item); err != nil {
+			return false
+		}
+		switch format {
+		case "json":
+			if !first {
+				data = append([]byte{','}, data...)
+			}
+		case "ndjson":
+			data = append(data, '\n')
+		case "json-seq":
+			data = append(append([]byte{0x1e}, data...), '\n')
+		}
+		first = false
+		if _, err = w.Write(data); err != nil {
+			return false
+		}
+		flushJSONStream(w)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		if _, err = io.WriteString(w, "]"); err != nil {
+			return err
+		}
+		flushJSONStream(w)
+	}
+	return nil
+}
+
+// flushJSONStream flushes the items which have been written to a stream, when the writer can be flushed.
+func flushJSONStream(w io.Writer) {
+	switch f := w.(type) {
+	case http.ResponseWriter:
+		_ = http.NewResponseController(f).Flush()
+	case interface{ Flush() error }:
+		_ = f.Flush()
+	}
+}
+
+// decodeJSONStream iterates over the items of a stream, in the format of its media type, decoding each of them as it's read. The iteration stops at the first error, and the stream can only be iterated over once.
+func decodeJSONStream[T any](r io.Reader, format string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if format == "json-seq" {
+			r = jsonSeqReader{r}
+		}
+		decoder := json.NewDecoder(r)
+		if format == "json" {
+			token, err := decoder.Token()
+			if err == nil && token != json.Delim('[') {
+				err = errors.New("the stream isn't a JSON array")
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+		for format != "json" || decoder.More() {
+			var item T
+			err := decoder.Decode(&item)
+			if err == io.EOF && format != "json" {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			yield(zero, err)
+		}
+	}
+}
+
+// jsonSeqReader reads a JSON text sequence as whitespace delimited JSON, by replacing its record separators, which can't be within the JSON texts, with newlines.
+type jsonSeqReader struct {
+	io.Reader
+}
+
+func (r jsonSeqReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	for i, b := range p[:n] {
+		if b == 0x1e {
+			p[i] = '\n'
+		}
+	}
+	return n, err
+}
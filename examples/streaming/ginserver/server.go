@@ -0,0 +1,74 @@
+package ginserver
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Server is a store of pets, which streams them rather than buffering them
+// in memory.
+type Server struct {
+	lock sync.RWMutex
+	pets []Pet
+}
+
+var _ StrictServerInterface = (*Server)(nil)
+
+// all iterates over the pets in the store.
+func (s *Server) all() iter.Seq2[Pet, error] {
+	return func(yield func(Pet, error) bool) {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		for _, pet := range s.pets {
+			if !yield(pet, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error) {
+	s.lock.RLock()
+	count := len(s.pets)
+	s.lock.RUnlock()
+	return ListPets200JSONResponse{
+		Body:    s.all(),
+		Headers: ListPets200ResponseHeaders{XTotalCount: count},
+	}, nil
+}
+
+func (s *Server) ImportPets(ctx context.Context, request ImportPetsRequestObject) (ImportPetsResponseObject, error) {
+	pets := request.JSONBody
+	if pets == nil {
+		pets = request.NDJSONBody
+	}
+	if pets == nil {
+		return ImportPets400JSONResponse{Message: "the pets must be a JSON array or newline delimited JSON"}, nil
+	}
+
+	imported := 0
+	var err error
+	// each of the pets is added as it's read
+	pets(func(pet Pet, petErr error) bool {
+		if err = petErr; err != nil {
+			return false
+		}
+		s.lock.Lock()
+		s.pets = append(s.pets, pet)
+		s.lock.Unlock()
+		imported++
+		return true
+	})
+	if err != nil {
+		return ImportPets400JSONResponse{Message: err.Error()}, nil
+	}
+	return ImportPets200JSONResponse{Imported: imported}, nil
+}
+
+func (s *Server) ExportPets(ctx context.Context, request ExportPetsRequestObject) (ExportPetsResponseObject, error) {
+	if request.Params.Format != nil && *request.Params.Format == JsonSeq {
+		return ExportPets200JSONSeqResponse(s.all()), nil
+	}
+	return ExportPets200NDJSONResponse(s.all()), nil
+}
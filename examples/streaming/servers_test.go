@@ -0,0 +1,83 @@
+package streaming
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/kataras/iris/v12"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/streaming/echoserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/streaming/fiberserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/streaming/ginserver"
+	"github.com/oapi-codegen/oapi-codegen/v2/examples/streaming/irisserver"
+)
+
+func servers() map[string]http.Handler {
+	e := echo.New()
+	echoserver.RegisterHandlers(e, echoserver.NewStrictHandler(&echoserver.Server{}, nil))
+
+	gin.SetMode(gin.TestMode)
+	g := gin.New()
+	ginserver.RegisterHandlers(g, ginserver.NewStrictHandler(&ginserver.Server{}, nil))
+
+	f := fiber.New()
+	fiberserver.RegisterHandlers(f, fiberserver.NewStrictHandler(&fiberserver.Server{}, nil))
+
+	i := iris.New()
+	irisserver.RegisterHandlers(i, irisserver.NewStrictHandler(&irisserver.Server{}, nil))
+
+	return map[string]http.Handler{
+		"std-http": Handler(NewStrictHandler(&Server{}, nil)),
+		"echo":     e,
+		"gin":      g,
+		"fiber":    adaptor.FiberApp(f),
+		"iris":     i,
+	}
+}
+
+func TestServers(t *testing.T) {
+	for name, handler := range servers() {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			client, err := NewClientWithResponses(server.URL)
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			rsp, err := client.ImportPetsWithNDJSONStream(ctx, slicePets(pets[:2]))
+			require.NoError(t, err)
+			imported, err := ParseImportPetsResponse(rsp)
+			require.NoError(t, err)
+			require.NotNil(t, imported.JSON200, string(imported.Body))
+			assert.Equal(t, 2, imported.JSON200.Imported)
+
+			rsp, err = client.ImportPetsWithJSONStream(ctx, slicePets(pets[2:]))
+			require.NoError(t, err)
+			imported, err = ParseImportPetsResponse(rsp)
+			require.NoError(t, err)
+			require.NotNil(t, imported.JSON200, string(imported.Body))
+			assert.Equal(t, 1, imported.JSON200.Imported)
+
+			rsp, err = client.ListPets(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "3", rsp.Header.Get("X-Total-Count"))
+			listed, err := DecodeListPets200JSONStream(rsp)
+			require.NoError(t, err)
+			assert.Equal(t, pets, collect(t, listed))
+
+			rsp, err = client.ExportPets(ctx, &ExportPetsParams{Format: ptr(JsonSeq)})
+			require.NoError(t, err)
+			exported, err := DecodeExportPets200JSONSeqStream(rsp)
+			require.NoError(t, err)
+			assert.Equal(t, pets, collect(t, exported))
+		})
+	}
+}
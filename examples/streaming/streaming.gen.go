@@ -0,0 +1,1245 @@
+//go:build go1.22
+
+// Package streaming provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Defines values for ExportPetsParamsFormat.
+const (
+	JsonSeq ExportPetsParamsFormat = "json-seq"
+	Ndjson  ExportPetsParamsFormat = "ndjson"
+)
+
+// Valid indicates whether the value is one of the values defined for ExportPetsParamsFormat.
+func (e ExportPetsParamsFormat) Valid() bool {
+	switch e {
+	case JsonSeq, Ndjson:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllExportPetsParamsFormatValues returns each of the values defined for ExportPetsParamsFormat.
+func AllExportPetsParamsFormatValues() []ExportPetsParamsFormat {
+	return []ExportPetsParamsFormat{
+		JsonSeq,
+		Ndjson,
+	}
+}
+
+// ParseExportPetsParamsFormat returns the ExportPetsParamsFormat value which is represented by s, or an error if s isn't one of its values.
+func ParseExportPetsParamsFormat(s string) (ExportPetsParamsFormat, error) {
+	switch s {
+	case "json-seq":
+		return JsonSeq, nil
+	case "ndjson":
+		return Ndjson, nil
+	}
+	var zero ExportPetsParamsFormat
+	return zero, fmt.Errorf("%q is not a valid ExportPetsParamsFormat, it must be one of %v", s, AllExportPetsParamsFormatValues())
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	Imported int `json:"imported"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Id   int64   `json:"id"`
+	Name string  `json:"name"`
+	Tag  *string `json:"tag,omitempty"`
+}
+
+// ImportPetsJSONBody defines parameters for ImportPets.
+type ImportPetsJSONBody = []Pet
+
+// ExportPetsParams defines parameters for ExportPets.
+type ExportPetsParams struct {
+	Format *ExportPetsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportPetsParamsFormat defines parameters for ExportPets.
+type ExportPetsParamsFormat string
+
+// ImportPetsJSONRequestBody defines body for ImportPets for application/json ContentType.
+type ImportPetsJSONRequestBody = ImportPetsJSONBody
+
+// ImportPetsNDJSONRequestBody defines body for ImportPets for application/x-ndjson ContentType.
+type ImportPetsNDJSONRequestBody = Pet
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListPets request
+	ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportPetsWithBody request with any body
+	ImportPetsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportPets(ctx context.Context, body ImportPetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ImportPetsWithJSONStream(ctx context.Context, body iter.Seq2[Pet, error], reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportPetsWithNDJSONStream(ctx context.Context, body iter.Seq2[Pet, error], reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportPets request
+	ExportPets(ctx context.Context, params *ExportPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) ImportPetsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportPetsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ImportPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) ImportPets(ctx context.Context, body ImportPetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportPetsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ImportPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// ImportPetsWithJSONStream requests ImportPets with the items of a stream as its body, which are encoded as application/json while the request is sent, rather than buffered.
+func (c *Client) ImportPetsWithJSONStream(ctx context.Context, body iter.Seq2[Pet, error], reqEditors ...RequestEditorFn) (*http.Response, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(encodeJSONStream(writer, "json", body))
+	}()
+	rsp, err := c.ImportPetsWithBody(ctx, "application/json", reader, reqEditors...)
+	if err != nil {
+		// the body may not have been read, so the items stop being encoded
+		_ = reader.CloseWithError(err)
+	}
+	return rsp, err
+}
+
+// ImportPetsWithNDJSONStream requests ImportPets with the items of a stream as its body, which are encoded as application/x-ndjson while the request is sent, rather than buffered.
+func (c *Client) ImportPetsWithNDJSONStream(ctx context.Context, body iter.Seq2[Pet, error], reqEditors ...RequestEditorFn) (*http.Response, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(encodeJSONStream(writer, "ndjson", body))
+	}()
+	rsp, err := c.ImportPetsWithBody(ctx, "application/x-ndjson", reader, reqEditors...)
+	if err != nil {
+		// the body may not have been read, so the items stop being encoded
+		_ = reader.CloseWithError(err)
+	}
+	return rsp, err
+}
+
+func (c *Client) ExportPets(ctx context.Context, params *ExportPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportPetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ExportPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportPetsRequest calls the generic ImportPets builder with application/json body
+func NewImportPetsRequest(server string, body ImportPetsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportPetsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewImportPetsRequestWithBody generates requests for ImportPets with any type of body
+func NewImportPetsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExportPetsRequest generates requests for ExportPets
+func NewExportPetsRequest(server string, params *ExportPetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListPetsWithResponse request
+	ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error)
+
+	// ImportPetsWithBodyWithResponse request with any body
+	ImportPetsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportPetsResponse, error)
+
+	ImportPetsWithResponse(ctx context.Context, body ImportPetsJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportPetsResponse, error)
+
+	// ExportPetsWithResponse request
+	ExportPetsWithResponse(ctx context.Context, params *ExportPetsParams, reqEditors ...RequestEditorFn) (*ExportPetsResponse, error)
+}
+
+type ListPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResult
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPetsResponse, error) {
+	rsp, err := c.ListPets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPetsResponse(rsp)
+}
+
+// ImportPetsWithBodyWithResponse request with arbitrary body returning *ImportPetsResponse
+func (c *ClientWithResponses) ImportPetsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportPetsResponse, error) {
+	rsp, err := c.ImportPetsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportPetsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportPetsWithResponse(ctx context.Context, body ImportPetsJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportPetsResponse, error) {
+	rsp, err := c.ImportPets(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportPetsResponse(rsp)
+}
+
+// ExportPetsWithResponse request returning *ExportPetsResponse
+func (c *ClientWithResponses) ExportPetsWithResponse(ctx context.Context, params *ExportPetsParams, reqEditors ...RequestEditorFn) (*ExportPetsResponse, error) {
+	rsp, err := c.ExportPets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportPetsResponse(rsp)
+}
+
+// ParseListPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParseListPetsResponse(rsp *http.Response) (*ListPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Pet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportPetsResponse parses an HTTP response from a ImportPetsWithResponse call
+func ParseImportPetsResponse(rsp *http.Response) (*ImportPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportPetsResponse parses an HTTP response from a ExportPetsWithResponse call
+func ParseExportPetsResponse(rsp *http.Response) (*ExportPetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// DecodeListPets200JSONStream iterates over the items of the application/json body of a 200 response from ListPets, decoding each of them as it's read. The body is closed once the items have all been read or the iteration is stopped, or when an error is returned because the response doesn't have the status code and content type.
+func DecodeListPets200JSONStream(rsp *http.Response) (iter.Seq2[Pet, error], error) {
+	if rsp.StatusCode != 200 {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's status code is %d, rather than 200", rsp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type")); mediaType != "application/json" {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's content type is %q, rather than application/json", rsp.Header.Get("Content-Type"))
+	}
+	items := decodeJSONStream[Pet](rsp.Body, "json")
+	return func(yield func(Pet, error) bool) {
+		defer func() { _ = rsp.Body.Close() }()
+		items(yield)
+	}, nil
+}
+
+// DecodeExportPets200JSONSeqStream iterates over the items of the application/json-seq body of a 200 response from ExportPets, decoding each of them as it's read. The body is closed once the items have all been read or the iteration is stopped, or when an error is returned because the response doesn't have the status code and content type.
+func DecodeExportPets200JSONSeqStream(rsp *http.Response) (iter.Seq2[Pet, error], error) {
+	if rsp.StatusCode != 200 {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's status code is %d, rather than 200", rsp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type")); mediaType != "application/json-seq" {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's content type is %q, rather than application/json-seq", rsp.Header.Get("Content-Type"))
+	}
+	items := decodeJSONStream[Pet](rsp.Body, "json-seq")
+	return func(yield func(Pet, error) bool) {
+		defer func() { _ = rsp.Body.Close() }()
+		items(yield)
+	}, nil
+}
+
+// DecodeExportPets200NDJSONStream iterates over the items of the application/x-ndjson body of a 200 response from ExportPets, decoding each of them as it's read. The body is closed once the items have all been read or the iteration is stopped, or when an error is returned because the response doesn't have the status code and content type.
+func DecodeExportPets200NDJSONStream(rsp *http.Response) (iter.Seq2[Pet, error], error) {
+	if rsp.StatusCode != 200 {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's status code is %d, rather than 200", rsp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type")); mediaType != "application/x-ndjson" {
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("the response's content type is %q, rather than application/x-ndjson", rsp.Header.Get("Content-Type"))
+	}
+	items := decodeJSONStream[Pet](rsp.Body, "ndjson")
+	return func(yield func(Pet, error) bool) {
+		defer func() { _ = rsp.Body.Close() }()
+		items(yield)
+	}, nil
+}
+
+// encodeJSONStream writes each of the items of a stream as it's iterated over, in the format of its media type, which is `json` for the elements of a JSON array, `ndjson` for newline delimited JSON, or `json-seq` for a JSON text sequence. When the writer can be flushed, such as an http.ResponseWriter, each item is flushed as soon as it's written.
+func encodeJSONStream[T any](w io.Writer, format string, items iter.Seq2[T, error]) error {
+	var err error
+	first := true
+	if format == "json" {
+		if _, err = io.WriteString(w, "["); err != nil {
+			return err
+		}
+	}
+	items(func(item T, itemErr error) bool {
+		if itemErr != nil {
+			err = itemErr
+			return false
+		}
+		var data []byte
+		if data, err = json.Marshal(item); err != nil {
+			return false
+		}
+		switch format {
+		case "json":
+			if !first {
+				data = append([]byte{','}, data...)
+			}
+		case "ndjson":
+			data = append(data, '\n')
+		case "json-seq":
+			data = append(append([]byte{0x1e}, data...), '\n')
+		}
+		first = false
+		if _, err = w.Write(data); err != nil {
+			return false
+		}
+		flushJSONStream(w)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		if _, err = io.WriteString(w, "]"); err != nil {
+			return err
+		}
+		flushJSONStream(w)
+	}
+	return nil
+}
+
+// flushJSONStream flushes the items which have been written to a stream, when the writer can be flushed.
+func flushJSONStream(w io.Writer) {
+	switch f := w.(type) {
+	case http.ResponseWriter:
+		_ = http.NewResponseController(f).Flush()
+	case interface{ Flush() error }:
+		_ = f.Flush()
+	}
+}
+
+// decodeJSONStream iterates over the items of a stream, in the format of its media type, decoding each of them as it's read. The iteration stops at the first error, and the stream can only be iterated over once.
+func decodeJSONStream[T any](r io.Reader, format string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if format == "json-seq" {
+			r = jsonSeqReader{r}
+		}
+		decoder := json.NewDecoder(r)
+		if format == "json" {
+			token, err := decoder.Token()
+			if err == nil && token != json.Delim('[') {
+				err = errors.New("the stream isn't a JSON array")
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+		for format != "json" || decoder.More() {
+			var item T
+			err := decoder.Decode(&item)
+			if err == io.EOF && format != "json" {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			yield(zero, err)
+		}
+	}
+}
+
+// jsonSeqReader reads a JSON text sequence as whitespace delimited JSON, by replacing its record separators, which can't be within the JSON texts, with newlines.
+type jsonSeqReader struct {
+	io.Reader
+}
+
+func (r jsonSeqReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	for i, b := range p[:n] {
+		if b == 0x1e {
+			p[i] = '\n'
+		}
+	}
+	return n, err
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /pets)
+	ListPets(w http.ResponseWriter, r *http.Request)
+
+	// (POST /pets)
+	ImportPets(w http.ResponseWriter, r *http.Request)
+
+	// (GET /pets/export)
+	ExportPets(w http.ResponseWriter, r *http.Request, params ExportPetsParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListPets operation middleware
+func (siw *ServerInterfaceWrapper) ListPets(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportPets operation middleware
+func (siw *ServerInterfaceWrapper) ImportPets(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportPets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportPets operation middleware
+func (siw *ServerInterfaceWrapper) ExportPets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportPetsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportPets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/pets", wrapper.ListPets)
+	m.HandleFunc("POST "+options.BaseURL+"/pets", wrapper.ImportPets)
+	m.HandleFunc("GET "+options.BaseURL+"/pets/export", wrapper.ExportPets)
+
+	return m
+}
+
+type ListPetsRequestObject struct {
+}
+
+type ListPetsResponseObject interface {
+	VisitListPetsResponse(w http.ResponseWriter) error
+}
+
+type ListPets200ResponseHeaders struct {
+	XTotalCount int
+}
+
+type ListPets200JSONResponse struct {
+	Body    iter.Seq2[Pet, error]
+	Headers ListPets200ResponseHeaders
+}
+
+func (response ListPets200JSONResponse) VisitListPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "json", response.Body)
+}
+
+type ImportPetsRequestObject struct {
+	JSONBody   iter.Seq2[Pet, error]
+	NDJSONBody iter.Seq2[Pet, error]
+}
+
+type ImportPetsResponseObject interface {
+	VisitImportPetsResponse(w http.ResponseWriter) error
+}
+
+type ImportPets200JSONResponse ImportResult
+
+func (response ImportPets200JSONResponse) VisitImportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPets400JSONResponse Error
+
+func (response ImportPets400JSONResponse) VisitImportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportPetsRequestObject struct {
+	Params ExportPetsParams
+}
+
+type ExportPetsResponseObject interface {
+	VisitExportPetsResponse(w http.ResponseWriter) error
+}
+
+type ExportPets200JSONSeqResponse iter.Seq2[Pet, error]
+
+func (response ExportPets200JSONSeqResponse) VisitExportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json-seq")
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "json-seq", iter.Seq2[Pet, error](response))
+}
+
+type ExportPets200NDJSONResponse iter.Seq2[Pet, error]
+
+func (response ExportPets200NDJSONResponse) VisitExportPetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(200)
+
+	return encodeJSONStream(w, "ndjson", iter.Seq2[Pet, error](response))
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /pets)
+	ListPets(ctx context.Context, request ListPetsRequestObject) (ListPetsResponseObject, error)
+
+	// (POST /pets)
+	ImportPets(ctx context.Context, request ImportPetsRequestObject) (ImportPetsResponseObject, error)
+
+	// (GET /pets/export)
+	ExportPets(ctx context.Context, request ExportPetsRequestObject) (ExportPetsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListPets operation middleware
+func (sh *strictHandler) ListPets(w http.ResponseWriter, r *http.Request) {
+	var request ListPetsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPets(ctx, request.(ListPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPetsResponseObject); ok {
+		if err := validResponse.VisitListPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportPets operation middleware
+func (sh *strictHandler) ImportPets(w http.ResponseWriter, r *http.Request) {
+	var request ImportPetsRequestObject
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		request.JSONBody = decodeJSONStream[Pet](r.Body, "json")
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-ndjson") {
+		request.NDJSONBody = decodeJSONStream[Pet](r.Body, "ndjson")
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportPets(ctx, request.(ImportPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportPetsResponseObject); ok {
+		if err := validResponse.VisitImportPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportPets operation middleware
+func (sh *strictHandler) ExportPets(w http.ResponseWriter, r *http.Request, params ExportPetsParams) {
+	var request ExportPetsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportPets(ctx, request.(ExportPetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportPets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportPetsResponseObject); ok {
+		if err := validResponse.VisitExportPetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -0,0 +1,213 @@
+package streaming
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pets = []Pet{
+	{Id: 1, Name: "Fido"},
+	{Id: 2, Name: "Tiddles", Tag: ptr("cat")},
+	{Id: 3, Name: "Bubbles"},
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newTestClient(t *testing.T, ssi StrictServerInterface) *ClientWithResponses {
+	t.Helper()
+	server := httptest.NewServer(Handler(NewStrictHandler(ssi, nil)))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func collect[T any](t *testing.T, items iter.Seq2[T, error]) []T {
+	t.Helper()
+	var collected []T
+	for item, err := range items {
+		require.NoError(t, err)
+		collected = append(collected, item)
+	}
+	return collected
+}
+
+func TestImportAndListPets(t *testing.T) {
+	server := &Server{}
+	client := newTestClient(t, server)
+
+	rsp, err := client.ImportPetsWithNDJSONStream(context.Background(), slicePets(pets[:2]))
+	require.NoError(t, err)
+	imported, err := ParseImportPetsResponse(rsp)
+	require.NoError(t, err)
+	require.NotNil(t, imported.JSON200)
+	assert.Equal(t, 2, imported.JSON200.Imported)
+
+	rsp, err = client.ImportPetsWithJSONStream(context.Background(), slicePets(pets[2:]))
+	require.NoError(t, err)
+	imported, err = ParseImportPetsResponse(rsp)
+	require.NoError(t, err)
+	require.NotNil(t, imported.JSON200)
+	assert.Equal(t, 1, imported.JSON200.Imported)
+
+	rsp, err = client.ListPets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "3", rsp.Header.Get("X-Total-Count"))
+	listed, err := DecodeListPets200JSONStream(rsp)
+	require.NoError(t, err)
+	assert.Equal(t, pets, collect(t, listed))
+}
+
+func TestExportPets(t *testing.T) {
+	server := &Server{pets: pets}
+	client := newTestClient(t, server)
+
+	rsp, err := client.ExportPets(context.Background(), &ExportPetsParams{Format: ptr(Ndjson)})
+	require.NoError(t, err)
+	exported, err := DecodeExportPets200NDJSONStream(rsp)
+	require.NoError(t, err)
+	assert.Equal(t, pets, collect(t, exported))
+
+	rsp, err = client.ExportPets(context.Background(), &ExportPetsParams{Format: ptr(JsonSeq)})
+	require.NoError(t, err)
+	_, err = DecodeExportPets200NDJSONStream(rsp)
+	assert.EqualError(t, err, `the response's content type is "application/json-seq", rather than application/x-ndjson`)
+
+	rsp, err = client.ExportPets(context.Background(), &ExportPetsParams{Format: ptr(JsonSeq)})
+	require.NoError(t, err)
+	exported, err = DecodeExportPets200JSONSeqStream(rsp)
+	require.NoError(t, err)
+	assert.Equal(t, pets, collect(t, exported))
+}
+
+func TestStreamFormats(t *testing.T) {
+	handler := Handler(NewStrictHandler(&Server{pets: pets[:2]}, nil))
+	tests := []struct {
+		path string
+		body string
+	}{
+		{
+			path: "/pets",
+			body: `[{"id":1,"name":"Fido"},{"id":2,"name":"Tiddles","tag":"cat"}]`,
+		},
+		{
+			path: "/pets/export?format=ndjson",
+			body: "{\"id\":1,\"name\":\"Fido\"}\n{\"id\":2,\"name\":\"Tiddles\",\"tag\":\"cat\"}\n",
+		},
+		{
+			path: "/pets/export?format=json-seq",
+			body: "\x1e{\"id\":1,\"name\":\"Fido\"}\n\x1e{\"id\":2,\"name\":\"Tiddles\",\"tag\":\"cat\"}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, test.body, rec.Body.String())
+		})
+	}
+}
+
+func TestImportInvalidPets(t *testing.T) {
+	server := &Server{}
+	handler := Handler(NewStrictHandler(server, nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader("{\"id\":1,\"name\":\"Fido\"}\n{\"id\":"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message":"unexpected EOF"}`, rec.Body.String())
+	// the pets before the invalid one were imported as they were read
+	assert.Len(t, server.pets, 1)
+
+	req = httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"id":1,"name":"Fido"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message":"the stream isn't a JSON array"}`, rec.Body.String())
+}
+
+// slowServer only exports its second pet once the first has been received.
+type slowServer struct {
+	*Server
+	received chan struct{}
+}
+
+func (s slowServer) ExportPets(ctx context.Context, request ExportPetsRequestObject) (ExportPetsResponseObject, error) {
+	return ExportPets200NDJSONResponse(func(yield func(Pet, error) bool) {
+		if !yield(pets[0], nil) {
+			return
+		}
+		select {
+		case <-s.received:
+		case <-time.After(5 * time.Second):
+			yield(Pet{}, io.ErrNoProgress)
+			return
+		}
+		yield(pets[1], nil)
+	}), nil
+}
+
+func TestPetsAreStreamed(t *testing.T) {
+	server := slowServer{Server: &Server{}, received: make(chan struct{})}
+	client := newTestClient(t, server)
+
+	rsp, err := client.ExportPets(context.Background(), nil)
+	require.NoError(t, err)
+	exported, err := DecodeExportPets200NDJSONStream(rsp)
+	require.NoError(t, err)
+
+	var received []Pet
+	for pet, err := range exported {
+		require.NoError(t, err)
+		received = append(received, pet)
+		if len(received) == 1 {
+			close(server.received)
+		}
+	}
+	assert.Equal(t, pets[:2], received)
+
+	// each pet is imported before the next is sent
+	body := func(yield func(Pet, error) bool) {
+		for i, pet := range pets {
+			if !yield(pet, nil) {
+				return
+			}
+			assert.Eventually(t, func() bool {
+				server.lock.RLock()
+				defer server.lock.RUnlock()
+				return len(server.pets) == i+1
+			}, 5*time.Second, time.Millisecond)
+		}
+	}
+	rsp, err = client.ImportPetsWithNDJSONStream(context.Background(), body)
+	require.NoError(t, err)
+	imported, err := ParseImportPetsResponse(rsp)
+	require.NoError(t, err)
+	require.NotNil(t, imported.JSON200)
+	assert.Equal(t, 3, imported.JSON200.Imported)
+}
+
+func slicePets(pets []Pet) iter.Seq2[Pet, error] {
+	return func(yield func(Pet, error) bool) {
+		for _, pet := range pets {
+			if !yield(pet, nil) {
+				return
+			}
+		}
+	}
+}
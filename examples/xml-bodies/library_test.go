@@ -0,0 +1,89 @@
+package xmlbodies
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dune = Book{
+	Isbn:    "9780441172719",
+	Title:   "Dune",
+	Authors: &[]string{"Frank Herbert"},
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *ClientWithResponses) {
+	t.Helper()
+	server := httptest.NewServer(Handler(NewStrictHandler(&Server{}, nil)))
+	t.Cleanup(server.Close)
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	return server, client
+}
+
+func TestAddBook(t *testing.T) {
+	_, client := newTestServer(t)
+
+	rsp, err := client.AddBookWithXMLBodyWithResponse(context.Background(), dune)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rsp.StatusCode())
+	assert.Equal(t, "application/xml", rsp.HTTPResponse.Header.Get("Content-Type"))
+	assert.Equal(t, "/books/9780441172719", rsp.HTTPResponse.Header.Get("Location"))
+	require.NotNil(t, rsp.XML201)
+	assert.Equal(t, dune.Isbn, rsp.XML201.Isbn)
+	assert.Equal(t, dune.Title, rsp.XML201.Title)
+	assert.Equal(t, dune.Authors, rsp.XML201.Authors)
+
+	// the elements and attributes are named by the schemas' `xml` objects
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<book isbn="9780441172719"><authors><author>Frank Herbert</author></authors><title>Dune</title></book>`,
+		string(rsp.Body))
+
+	rsp, err = client.AddBookWithXMLBodyWithResponse(context.Background(), dune)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, rsp.StatusCode())
+	require.NotNil(t, rsp.ApplicationproblemXML409)
+	assert.Equal(t, "Conflict", rsp.ApplicationproblemXML409.Title)
+	assert.Contains(t, string(rsp.Body), `<problem xmlns="urn:ietf:rfc:7807">`)
+}
+
+func TestListBooks(t *testing.T) {
+	server, client := newTestServer(t)
+
+	// a book can be added with any XML which has the elements and attributes
+	// of its schema
+	rsp, err := http.Post(server.URL+"/books", "application/xml", strings.NewReader(`
+		<book isbn="9780441172719">
+			<title>Dune</title>
+			<authors>
+				<author>Frank Herbert</author>
+			</authors>
+		</book>`))
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rsp.Body)
+	rsp.Body.Close()
+	require.Equal(t, http.StatusCreated, rsp.StatusCode)
+
+	books, err := client.ListBooksWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, books.XML200)
+	require.NotNil(t, books.XML200.Books)
+	require.Len(t, *books.XML200.Books, 1)
+	assert.Equal(t, "Dune", (*books.XML200.Books)[0].Title)
+	assert.Contains(t, string(books.Body), `<library><book isbn="9780441172719">`)
+}
+
+func TestAddBookWithInvalidXML(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	rsp, err := http.Post(server.URL+"/books", "application/xml", strings.NewReader(`<book isbn="9780441172719">`))
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+}
@@ -0,0 +1,38 @@
+package xmlbodies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Server is a library of books, which are sent and received as XML.
+type Server struct {
+	lock  sync.Mutex
+	books []Book
+}
+
+var _ StrictServerInterface = (*Server)(nil)
+
+func (s *Server) ListBooks(ctx context.Context, request ListBooksRequestObject) (ListBooksResponseObject, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	books := append([]Book(nil), s.books...)
+	return ListBooks200XMLResponse{Books: &books}, nil
+}
+
+func (s *Server) AddBook(ctx context.Context, request AddBookRequestObject) (AddBookResponseObject, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, book := range s.books {
+		if book.Isbn == request.Body.Isbn {
+			detail := fmt.Sprintf("%q is already in the library", book.Title)
+			return AddBook409ApplicationProblemPlusXMLResponse{Title: "Conflict", Detail: &detail}, nil
+		}
+	}
+	s.books = append(s.books, *request.Body)
+	return AddBook201XMLResponse{
+		Body:    *request.Body,
+		Headers: AddBook201ResponseHeaders{Location: "/books/" + request.Body.Isbn},
+	}, nil
+}
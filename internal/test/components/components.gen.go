@@ -18,6 +18,39 @@ const (
 	Enum1Two   Enum1 = "Two"
 )
 
+// Valid indicates whether the value is one of the values defined for Enum1.
+func (e Enum1) Valid() bool {
+	switch e {
+	case Enum1One, Enum1Three, Enum1Two:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnum1Values returns each of the values defined for Enum1.
+func AllEnum1Values() []Enum1 {
+	return []Enum1{
+		Enum1One,
+		Enum1Three,
+		Enum1Two,
+	}
+}
+
+// ParseEnum1 returns the Enum1 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnum1(s string) (Enum1, error) {
+	switch s {
+	case "One":
+		return Enum1One, nil
+	case "Three":
+		return Enum1Three, nil
+	case "Two":
+		return Enum1Two, nil
+	}
+	var zero Enum1
+	return zero, fmt.Errorf("%q is not a valid Enum1, it must be one of %v", s, AllEnum1Values())
+}
+
 // Defines values for Enum2.
 const (
 	Enum2Four  Enum2 = "Four"
@@ -25,6 +58,39 @@ const (
 	Enum2Two   Enum2 = "Two"
 )
 
+// Valid indicates whether the value is one of the values defined for Enum2.
+func (e Enum2) Valid() bool {
+	switch e {
+	case Enum2Four, Enum2Three, Enum2Two:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnum2Values returns each of the values defined for Enum2.
+func AllEnum2Values() []Enum2 {
+	return []Enum2{
+		Enum2Four,
+		Enum2Three,
+		Enum2Two,
+	}
+}
+
+// ParseEnum2 returns the Enum2 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnum2(s string) (Enum2, error) {
+	switch s {
+	case "Four":
+		return Enum2Four, nil
+	case "Three":
+		return Enum2Three, nil
+	case "Two":
+		return Enum2Two, nil
+	}
+	var zero Enum2
+	return zero, fmt.Errorf("%q is not a valid Enum2, it must be one of %v", s, AllEnum2Values())
+}
+
 // Defines values for Enum3.
 const (
 	Enum3Bar      Enum3 = "Bar"
@@ -32,6 +98,39 @@ const (
 	Enum3Foo      Enum3 = "Foo"
 )
 
+// Valid indicates whether the value is one of the values defined for Enum3.
+func (e Enum3) Valid() bool {
+	switch e {
+	case Enum3Bar, Enum3Enum1One, Enum3Foo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnum3Values returns each of the values defined for Enum3.
+func AllEnum3Values() []Enum3 {
+	return []Enum3{
+		Enum3Bar,
+		Enum3Enum1One,
+		Enum3Foo,
+	}
+}
+
+// ParseEnum3 returns the Enum3 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnum3(s string) (Enum3, error) {
+	switch s {
+	case "Bar":
+		return Enum3Bar, nil
+	case "Enum1One":
+		return Enum3Enum1One, nil
+	case "Foo":
+		return Enum3Foo, nil
+	}
+	var zero Enum3
+	return zero, fmt.Errorf("%q is not a valid Enum3, it must be one of %v", s, AllEnum3Values())
+}
+
 // Defines values for Enum4.
 const (
 	Cat   Enum4 = "Cat"
@@ -39,6 +138,39 @@ const (
 	Mouse Enum4 = "Mouse"
 )
 
+// Valid indicates whether the value is one of the values defined for Enum4.
+func (e Enum4) Valid() bool {
+	switch e {
+	case Cat, Dog, Mouse:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnum4Values returns each of the values defined for Enum4.
+func AllEnum4Values() []Enum4 {
+	return []Enum4{
+		Cat,
+		Dog,
+		Mouse,
+	}
+}
+
+// ParseEnum4 returns the Enum4 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnum4(s string) (Enum4, error) {
+	switch s {
+	case "Cat":
+		return Cat, nil
+	case "Dog":
+		return Dog, nil
+	case "Mouse":
+		return Mouse, nil
+	}
+	var zero Enum4
+	return zero, fmt.Errorf("%q is not a valid Enum4, it must be one of %v", s, AllEnum4Values())
+}
+
 // Defines values for Enum5.
 const (
 	Enum5N5 Enum5 = 5
@@ -46,6 +178,39 @@ const (
 	Enum5N7 Enum5 = 7
 )
 
+// Valid indicates whether the value is one of the values defined for Enum5.
+func (e Enum5) Valid() bool {
+	switch e {
+	case Enum5N5, Enum5N6, Enum5N7:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnum5Values returns each of the values defined for Enum5.
+func AllEnum5Values() []Enum5 {
+	return []Enum5{
+		Enum5N5,
+		Enum5N6,
+		Enum5N7,
+	}
+}
+
+// ParseEnum5 returns the Enum5 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnum5(s string) (Enum5, error) {
+	switch s {
+	case "5":
+		return Enum5N5, nil
+	case "6":
+		return Enum5N6, nil
+	case "7":
+		return Enum5N7, nil
+	}
+	var zero Enum5
+	return zero, fmt.Errorf("%q is not a valid Enum5, it must be one of %v", s, AllEnum5Values())
+}
+
 // Defines values for EnumUnion.
 const (
 	EnumUnionFour  EnumUnion = "Four"
@@ -54,6 +219,42 @@ const (
 	EnumUnionTwo   EnumUnion = "Two"
 )
 
+// Valid indicates whether the value is one of the values defined for EnumUnion.
+func (e EnumUnion) Valid() bool {
+	switch e {
+	case EnumUnionFour, EnumUnionOne, EnumUnionThree, EnumUnionTwo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnumUnionValues returns each of the values defined for EnumUnion.
+func AllEnumUnionValues() []EnumUnion {
+	return []EnumUnion{
+		EnumUnionFour,
+		EnumUnionOne,
+		EnumUnionThree,
+		EnumUnionTwo,
+	}
+}
+
+// ParseEnumUnion returns the EnumUnion value which is represented by s, or an error if s isn't one of its values.
+func ParseEnumUnion(s string) (EnumUnion, error) {
+	switch s {
+	case "Four":
+		return EnumUnionFour, nil
+	case "One":
+		return EnumUnionOne, nil
+	case "Three":
+		return EnumUnionThree, nil
+	case "Two":
+		return EnumUnionTwo, nil
+	}
+	var zero EnumUnion
+	return zero, fmt.Errorf("%q is not a valid EnumUnion, it must be one of %v", s, AllEnumUnionValues())
+}
+
 // Defines values for EnumUnion2.
 const (
 	EnumUnion2One   EnumUnion2 = "One"
@@ -62,6 +263,42 @@ const (
 	EnumUnion2Two   EnumUnion2 = "Two"
 )
 
+// Valid indicates whether the value is one of the values defined for EnumUnion2.
+func (e EnumUnion2) Valid() bool {
+	switch e {
+	case EnumUnion2One, EnumUnion2Seven, EnumUnion2Three, EnumUnion2Two:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnumUnion2Values returns each of the values defined for EnumUnion2.
+func AllEnumUnion2Values() []EnumUnion2 {
+	return []EnumUnion2{
+		EnumUnion2One,
+		EnumUnion2Seven,
+		EnumUnion2Three,
+		EnumUnion2Two,
+	}
+}
+
+// ParseEnumUnion2 returns the EnumUnion2 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnumUnion2(s string) (EnumUnion2, error) {
+	switch s {
+	case "One":
+		return EnumUnion2One, nil
+	case "Seven":
+		return EnumUnion2Seven, nil
+	case "Three":
+		return EnumUnion2Three, nil
+	case "Two":
+		return EnumUnion2Two, nil
+	}
+	var zero EnumUnion2
+	return zero, fmt.Errorf("%q is not a valid EnumUnion2, it must be one of %v", s, AllEnumUnion2Values())
+}
+
 // Defines values for FunnyValues.
 const (
 	FunnyValuesAnd      FunnyValues = "&"
@@ -71,6 +308,45 @@ const (
 	FunnyValuesPercent  FunnyValues = "%"
 )
 
+// Valid indicates whether the value is one of the values defined for FunnyValues.
+func (e FunnyValues) Valid() bool {
+	switch e {
+	case FunnyValuesAnd, FunnyValuesAsterisk, FunnyValuesEmpty, FunnyValuesN5, FunnyValuesPercent:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllFunnyValuesValues returns each of the values defined for FunnyValues.
+func AllFunnyValuesValues() []FunnyValues {
+	return []FunnyValues{
+		FunnyValuesAnd,
+		FunnyValuesAsterisk,
+		FunnyValuesEmpty,
+		FunnyValuesN5,
+		FunnyValuesPercent,
+	}
+}
+
+// ParseFunnyValues returns the FunnyValues value which is represented by s, or an error if s isn't one of its values.
+func ParseFunnyValues(s string) (FunnyValues, error) {
+	switch s {
+	case "&":
+		return FunnyValuesAnd, nil
+	case "*":
+		return FunnyValuesAsterisk, nil
+	case "":
+		return FunnyValuesEmpty, nil
+	case "5":
+		return FunnyValuesN5, nil
+	case "%":
+		return FunnyValuesPercent, nil
+	}
+	var zero FunnyValues
+	return zero, fmt.Errorf("%q is not a valid FunnyValues, it must be one of %v", s, AllFunnyValuesValues())
+}
+
 // Defines values for EnumParam1.
 const (
 	EnumParam1Both EnumParam1 = "both"
@@ -78,6 +354,39 @@ const (
 	EnumParam1On   EnumParam1 = "on"
 )
 
+// Valid indicates whether the value is one of the values defined for EnumParam1.
+func (e EnumParam1) Valid() bool {
+	switch e {
+	case EnumParam1Both, EnumParam1Off, EnumParam1On:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnumParam1Values returns each of the values defined for EnumParam1.
+func AllEnumParam1Values() []EnumParam1 {
+	return []EnumParam1{
+		EnumParam1Both,
+		EnumParam1Off,
+		EnumParam1On,
+	}
+}
+
+// ParseEnumParam1 returns the EnumParam1 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnumParam1(s string) (EnumParam1, error) {
+	switch s {
+	case "both":
+		return EnumParam1Both, nil
+	case "off":
+		return EnumParam1Off, nil
+	case "on":
+		return EnumParam1On, nil
+	}
+	var zero EnumParam1
+	return zero, fmt.Errorf("%q is not a valid EnumParam1, it must be one of %v", s, AllEnumParam1Values())
+}
+
 // Defines values for EnumParam2.
 const (
 	EnumParam2Both EnumParam2 = "both"
@@ -85,6 +394,39 @@ const (
 	EnumParam2On   EnumParam2 = "on"
 )
 
+// Valid indicates whether the value is one of the values defined for EnumParam2.
+func (e EnumParam2) Valid() bool {
+	switch e {
+	case EnumParam2Both, EnumParam2Off, EnumParam2On:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnumParam2Values returns each of the values defined for EnumParam2.
+func AllEnumParam2Values() []EnumParam2 {
+	return []EnumParam2{
+		EnumParam2Both,
+		EnumParam2Off,
+		EnumParam2On,
+	}
+}
+
+// ParseEnumParam2 returns the EnumParam2 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnumParam2(s string) (EnumParam2, error) {
+	switch s {
+	case "both":
+		return EnumParam2Both, nil
+	case "off":
+		return EnumParam2Off, nil
+	case "on":
+		return EnumParam2On, nil
+	}
+	var zero EnumParam2
+	return zero, fmt.Errorf("%q is not a valid EnumParam2, it must be one of %v", s, AllEnumParam2Values())
+}
+
 // Defines values for EnumParam3.
 const (
 	Alice EnumParam3 = "alice"
@@ -92,6 +434,39 @@ const (
 	Eve   EnumParam3 = "eve"
 )
 
+// Valid indicates whether the value is one of the values defined for EnumParam3.
+func (e EnumParam3) Valid() bool {
+	switch e {
+	case Alice, Bob, Eve:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllEnumParam3Values returns each of the values defined for EnumParam3.
+func AllEnumParam3Values() []EnumParam3 {
+	return []EnumParam3{
+		Alice,
+		Bob,
+		Eve,
+	}
+}
+
+// ParseEnumParam3 returns the EnumParam3 value which is represented by s, or an error if s isn't one of its values.
+func ParseEnumParam3(s string) (EnumParam3, error) {
+	switch s {
+	case "alice":
+		return Alice, nil
+	case "bob":
+		return Bob, nil
+	case "eve":
+		return Eve, nil
+	}
+	var zero EnumParam3
+	return zero, fmt.Errorf("%q is not a valid EnumParam3, it must be one of %v", s, AllEnumParam3Values())
+}
+
 // AdditionalPropertiesObject1 Has additional properties of type int
 type AdditionalPropertiesObject1 struct {
 	Id                   int            `json:"id"`
@@ -29,6 +29,39 @@ const (
 	Placed    OrderStatus = "placed"
 )
 
+// Valid indicates whether the value is one of the values defined for OrderStatus.
+func (e OrderStatus) Valid() bool {
+	switch e {
+	case Approved, Delivered, Placed:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllOrderStatusValues returns each of the values defined for OrderStatus.
+func AllOrderStatusValues() []OrderStatus {
+	return []OrderStatus{
+		Approved,
+		Delivered,
+		Placed,
+	}
+}
+
+// ParseOrderStatus returns the OrderStatus value which is represented by s, or an error if s isn't one of its values.
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	switch s {
+	case "approved":
+		return Approved, nil
+	case "delivered":
+		return Delivered, nil
+	case "placed":
+		return Placed, nil
+	}
+	var zero OrderStatus
+	return zero, fmt.Errorf("%q is not a valid OrderStatus, it must be one of %v", s, AllOrderStatusValues())
+}
+
 // Defines values for PetStatus.
 const (
 	PetStatusAvailable PetStatus = "available"
@@ -36,6 +69,39 @@ const (
 	PetStatusSold      PetStatus = "sold"
 )
 
+// Valid indicates whether the value is one of the values defined for PetStatus.
+func (e PetStatus) Valid() bool {
+	switch e {
+	case PetStatusAvailable, PetStatusPending, PetStatusSold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllPetStatusValues returns each of the values defined for PetStatus.
+func AllPetStatusValues() []PetStatus {
+	return []PetStatus{
+		PetStatusAvailable,
+		PetStatusPending,
+		PetStatusSold,
+	}
+}
+
+// ParsePetStatus returns the PetStatus value which is represented by s, or an error if s isn't one of its values.
+func ParsePetStatus(s string) (PetStatus, error) {
+	switch s {
+	case "available":
+		return PetStatusAvailable, nil
+	case "pending":
+		return PetStatusPending, nil
+	case "sold":
+		return PetStatusSold, nil
+	}
+	var zero PetStatus
+	return zero, fmt.Errorf("%q is not a valid PetStatus, it must be one of %v", s, AllPetStatusValues())
+}
+
 // Defines values for FindPetsByStatusParamsStatus.
 const (
 	FindPetsByStatusParamsStatusAvailable FindPetsByStatusParamsStatus = "available"
@@ -43,6 +109,39 @@ const (
 	FindPetsByStatusParamsStatusSold      FindPetsByStatusParamsStatus = "sold"
 )
 
+// Valid indicates whether the value is one of the values defined for FindPetsByStatusParamsStatus.
+func (e FindPetsByStatusParamsStatus) Valid() bool {
+	switch e {
+	case FindPetsByStatusParamsStatusAvailable, FindPetsByStatusParamsStatusPending, FindPetsByStatusParamsStatusSold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllFindPetsByStatusParamsStatusValues returns each of the values defined for FindPetsByStatusParamsStatus.
+func AllFindPetsByStatusParamsStatusValues() []FindPetsByStatusParamsStatus {
+	return []FindPetsByStatusParamsStatus{
+		FindPetsByStatusParamsStatusAvailable,
+		FindPetsByStatusParamsStatusPending,
+		FindPetsByStatusParamsStatusSold,
+	}
+}
+
+// ParseFindPetsByStatusParamsStatus returns the FindPetsByStatusParamsStatus value which is represented by s, or an error if s isn't one of its values.
+func ParseFindPetsByStatusParamsStatus(s string) (FindPetsByStatusParamsStatus, error) {
+	switch s {
+	case "available":
+		return FindPetsByStatusParamsStatusAvailable, nil
+	case "pending":
+		return FindPetsByStatusParamsStatusPending, nil
+	case "sold":
+		return FindPetsByStatusParamsStatusSold, nil
+	}
+	var zero FindPetsByStatusParamsStatus
+	return zero, fmt.Errorf("%q is not a valid FindPetsByStatusParamsStatus, it must be one of %v", s, AllFindPetsByStatusParamsStatusValues())
+}
+
 // Address defines model for Address.
 type Address struct {
 	City   *string `json:"city,omitempty"`
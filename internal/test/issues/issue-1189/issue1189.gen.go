@@ -27,18 +27,108 @@ const (
 	TestFieldA1Foo TestFieldA1 = "foo"
 )
 
+// Valid indicates whether the value is one of the values defined for TestFieldA1.
+func (e TestFieldA1) Valid() bool {
+	switch e {
+	case TestFieldA1Bar, TestFieldA1Foo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllTestFieldA1Values returns each of the values defined for TestFieldA1.
+func AllTestFieldA1Values() []TestFieldA1 {
+	return []TestFieldA1{
+		TestFieldA1Bar,
+		TestFieldA1Foo,
+	}
+}
+
+// ParseTestFieldA1 returns the TestFieldA1 value which is represented by s, or an error if s isn't one of its values.
+func ParseTestFieldA1(s string) (TestFieldA1, error) {
+	switch s {
+	case "bar":
+		return TestFieldA1Bar, nil
+	case "foo":
+		return TestFieldA1Foo, nil
+	}
+	var zero TestFieldA1
+	return zero, fmt.Errorf("%q is not a valid TestFieldA1, it must be one of %v", s, AllTestFieldA1Values())
+}
+
 // Defines values for TestFieldB.
 const (
 	TestFieldBBar TestFieldB = "bar"
 	TestFieldBFoo TestFieldB = "foo"
 )
 
+// Valid indicates whether the value is one of the values defined for TestFieldB.
+func (e TestFieldB) Valid() bool {
+	switch e {
+	case TestFieldBBar, TestFieldBFoo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllTestFieldBValues returns each of the values defined for TestFieldB.
+func AllTestFieldBValues() []TestFieldB {
+	return []TestFieldB{
+		TestFieldBBar,
+		TestFieldBFoo,
+	}
+}
+
+// ParseTestFieldB returns the TestFieldB value which is represented by s, or an error if s isn't one of its values.
+func ParseTestFieldB(s string) (TestFieldB, error) {
+	switch s {
+	case "bar":
+		return TestFieldBBar, nil
+	case "foo":
+		return TestFieldBFoo, nil
+	}
+	var zero TestFieldB
+	return zero, fmt.Errorf("%q is not a valid TestFieldB, it must be one of %v", s, AllTestFieldBValues())
+}
+
 // Defines values for TestFieldC1.
 const (
 	Bar TestFieldC1 = "bar"
 	Foo TestFieldC1 = "foo"
 )
 
+// Valid indicates whether the value is one of the values defined for TestFieldC1.
+func (e TestFieldC1) Valid() bool {
+	switch e {
+	case Bar, Foo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllTestFieldC1Values returns each of the values defined for TestFieldC1.
+func AllTestFieldC1Values() []TestFieldC1 {
+	return []TestFieldC1{
+		Bar,
+		Foo,
+	}
+}
+
+// ParseTestFieldC1 returns the TestFieldC1 value which is represented by s, or an error if s isn't one of its values.
+func ParseTestFieldC1(s string) (TestFieldC1, error) {
+	switch s {
+	case "bar":
+		return Bar, nil
+	case "foo":
+		return Foo, nil
+	}
+	var zero TestFieldC1
+	return zero, fmt.Errorf("%q is not a valid TestFieldC1, it must be one of %v", s, AllTestFieldC1Values())
+}
+
 // Test defines model for test.
 type Test struct {
 	FieldA *Test_FieldA `json:"fieldA,omitempty"`
@@ -198,6 +288,10 @@ func (t *Test_FieldC) UnmarshalJSON(b []byte) error {
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
 // Doer performs HTTP requests.
 //
 // The standard http.Client implements this interface.
@@ -205,6 +299,25 @@ type HttpRequestDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
 // Client which conforms to the OpenAPI3 specification for this service.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
@@ -220,6 +333,14 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -245,6 +366,9 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if client.Client == nil {
 		client.Client = &http.Client{}
 	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
 	return &client, nil
 }
 
@@ -266,6 +390,26 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// Test request
@@ -277,11 +421,12 @@ func (c *Client) Test(ctx context.Context, reqEditors ...RequestEditorFn) (*http
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "Test")
 	req = req.WithContext(ctx)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req)
 }
 
 // NewTestRequest generates requests for Test
@@ -311,6 +456,21 @@ func NewTestRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -23,6 +23,42 @@ const (
 	Two   Document_Status = "two"
 )
 
+// Valid indicates whether the value is one of the values defined for Document_Status.
+func (e Document_Status) Valid() bool {
+	switch e {
+	case Four, One, Three, Two:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllDocument_StatusValues returns each of the values defined for Document_Status.
+func AllDocument_StatusValues() []Document_Status {
+	return []Document_Status{
+		Four,
+		One,
+		Three,
+		Two,
+	}
+}
+
+// ParseDocument_Status returns the Document_Status value which is represented by s, or an error if s isn't one of its values.
+func ParseDocument_Status(s string) (Document_Status, error) {
+	switch s {
+	case "four":
+		return Four, nil
+	case "one":
+		return One, nil
+	case "three":
+		return Three, nil
+	case "two":
+		return Two, nil
+	}
+	var zero Document_Status
+	return zero, fmt.Errorf("%q is not a valid Document_Status, it must be one of %v", s, AllDocument_StatusValues())
+}
+
 // Document defines model for Document.
 type Document struct {
 	Name   *string          `json:"name,omitempty"`
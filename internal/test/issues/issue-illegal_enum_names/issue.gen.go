@@ -34,12 +34,70 @@ const (
 	BarUnderscoreFoo Bar = "_Foo_"
 )
 
+// Valid indicates whether the value is one of the values defined for Bar.
+func (e Bar) Valid() bool {
+	switch e {
+	case BarBar, BarEmpty, BarFoo, BarFoo1, BarFoo2, BarFooBar, BarFooBar1, BarN1, BarN1Foo, BarUnderscoreFoo:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllBarValues returns each of the values defined for Bar.
+func AllBarValues() []Bar {
+	return []Bar{
+		BarBar,
+		BarEmpty,
+		BarFoo,
+		BarFoo1,
+		BarFoo2,
+		BarFooBar,
+		BarFooBar1,
+		BarN1,
+		BarN1Foo,
+		BarUnderscoreFoo,
+	}
+}
+
+// ParseBar returns the Bar value which is represented by s, or an error if s isn't one of its values.
+func ParseBar(s string) (Bar, error) {
+	switch s {
+	case "Bar":
+		return BarBar, nil
+	case "":
+		return BarEmpty, nil
+	case "Foo":
+		return BarFoo, nil
+	case " Foo":
+		return BarFoo1, nil
+	case " Foo ":
+		return BarFoo2, nil
+	case "Foo Bar":
+		return BarFooBar, nil
+	case "Foo-Bar":
+		return BarFooBar1, nil
+	case "1":
+		return BarN1, nil
+	case "1Foo":
+		return BarN1Foo, nil
+	case "_Foo_":
+		return BarUnderscoreFoo, nil
+	}
+	var zero Bar
+	return zero, fmt.Errorf("%q is not a valid Bar, it must be one of %v", s, AllBarValues())
+}
+
 // Bar defines model for Bar.
 type Bar string
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
+// ResponseEditorFn is the function signature for the ResponseEditor callback function, which
+// is called with each response before it's returned, or parsed
+type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error
+
 // Doer performs HTTP requests.
 //
 // The standard http.Client implements this interface.
@@ -47,6 +105,25 @@ type HttpRequestDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// HttpRequestDoerFunc allows using a function as an HttpRequestDoer, such as
+// in a middleware.
+type HttpRequestDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationIDContextKey struct{}
+
+// OperationIDFromContext returns the ID of the operation which the client is
+// making a request for, from the context passed to a RequestEditorFn,
+// ResponseEditorFn or middleware.
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDContextKey{}).(string)
+	return operationID, ok
+}
+
 // Client which conforms to the OpenAPI3 specification for this service.
 type Client struct {
 	// The endpoint of the server conforming to this interface, with scheme,
@@ -62,6 +139,14 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A list of callbacks for observing, or modifying, responses before they're
+	// returned, or parsed.
+	ResponseEditors []ResponseEditorFn
+
+	// A list of middlewares which wrap the Doer, in the order they're applied,
+	// so that the last is the outermost.
+	Middlewares []func(HttpRequestDoer) HttpRequestDoer
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -87,6 +172,9 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if client.Client == nil {
 		client.Client = &http.Client{}
 	}
+	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}
 	return &client, nil
 }
 
@@ -108,6 +196,26 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithResponseEditorFn allows setting up a callback function, which will be
+// called with each response, before it's returned, or parsed. This can be used
+// to observe, or mutate, the response, or to return an error instead.
+func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.ResponseEditors = append(c.ResponseEditors, fn)
+		return nil
+	}
+}
+
+// WithMiddleware allows wrapping the Doer, which is used to send each request,
+// once the client is created. Middlewares are applied in the order they're
+// added, so the last one added sees each request first.
+func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middleware)
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetFoo request
@@ -119,11 +227,12 @@ func (c *Client) GetFoo(ctx context.Context, reqEditors ...RequestEditorFn) (*ht
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "GetFoo")
 	req = req.WithContext(ctx)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req)
 }
 
 // NewGetFooRequest generates requests for GetFoo
@@ -153,6 +262,21 @@ func NewGetFooRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, and then calls the ResponseEditors with its response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	rsp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range c.ResponseEditors {
+		if err := r(req.Context(), rsp); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
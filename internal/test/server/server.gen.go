@@ -19,6 +19,36 @@ const (
 	Text GetWithContentTypeParamsContentType = "text"
 )
 
+// Valid indicates whether the value is one of the values defined for GetWithContentTypeParamsContentType.
+func (e GetWithContentTypeParamsContentType) Valid() bool {
+	switch e {
+	case Json, Text:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllGetWithContentTypeParamsContentTypeValues returns each of the values defined for GetWithContentTypeParamsContentType.
+func AllGetWithContentTypeParamsContentTypeValues() []GetWithContentTypeParamsContentType {
+	return []GetWithContentTypeParamsContentType{
+		Json,
+		Text,
+	}
+}
+
+// ParseGetWithContentTypeParamsContentType returns the GetWithContentTypeParamsContentType value which is represented by s, or an error if s isn't one of its values.
+func ParseGetWithContentTypeParamsContentType(s string) (GetWithContentTypeParamsContentType, error) {
+	switch s {
+	case "json":
+		return Json, nil
+	case "text":
+		return Text, nil
+	}
+	var zero GetWithContentTypeParamsContentType
+	return zero, fmt.Errorf("%q is not a valid GetWithContentTypeParamsContentType, it must be one of %v", s, AllGetWithContentTypeParamsContentTypeValues())
+}
+
 // EveryTypeOptional defines model for EveryTypeOptional.
 type EveryTypeOptional struct {
 	ArrayInlineField     *[]int              `json:"array_inline_field,omitempty"`
@@ -0,0 +1,386 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GoAPI is the Go API of the code which is generated for a spec, as the
+// generator models it, which DiffGoAPIs compares to find the changes to a spec
+// which break the code that uses its generated code.
+type GoAPI struct {
+	// Types are the types which are declared, by name, when the models are
+	// generated.
+	Types map[string]GoAPIType
+	// Constants are the constants of the enums, by name, when the models are
+	// generated.
+	Constants map[string]GoAPIConstant
+	// Operations are the operations of the paths, by name, which the methods
+	// of the client and the servers are generated for.
+	Operations map[string]GoAPIOperation
+	// Interfaces are the interfaces which have methods for each of the
+	// operations, such as the ServerInterface, which the code that uses the
+	// generated code implements.
+	Interfaces []string
+}
+
+// GoAPIType is a type of a GoAPI.
+type GoAPIType struct {
+	// Alias is whether the type is declared as an alias.
+	Alias bool
+	// Underlying is what the type is declared as, such as `string` or
+	// `[]Pet`, which is empty for a struct, whose Fields are compared instead.
+	Underlying string
+	// Fields are the types of the fields of a struct, by name.
+	Fields map[string]string
+	// UnionElements are the names of the elements of a union, which its `As`,
+	// `From` and `Merge` methods are named after.
+	UnionElements []string
+}
+
+// GoAPIConstant is a constant of an enum of a GoAPI.
+type GoAPIConstant struct {
+	// Type is the enum's type.
+	Type string
+	// Value is the constant's value, as it's written in Go.
+	Value string
+}
+
+// GoAPIOperation is an operation of a GoAPI, whose methods are generated on
+// the client and the servers.
+type GoAPIOperation struct {
+	// PathParams are the types of the path parameters, which are the
+	// arguments of each of the operation's methods.
+	PathParams []string
+	// Params is the type of the operation's other parameters, which is an
+	// argument of each of the operation's methods, when it has any.
+	Params string
+	// ClientMethods are the arguments for the body of each of the methods of
+	// the client, by name, when the client is generated.
+	ClientMethods map[string]string
+}
+
+// GoAPI returns the Go API of the code which would be generated for the spec.
+// As when generating it, the spec's operations are filtered, and its unused
+// components are pruned, as configured.
+func (g *Generator) GoAPI(spec *openapi3.T) (*GoAPI, error) {
+	opts := g.options
+	gs := g.newState(spec)
+	t, err := g.templatesFor(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, webhookOps, callbackOps, err := g.operationDefinitions(gs, spec)
+	if err != nil {
+		return nil, err
+	}
+	modelOps := append(append(append([]OperationDefinition{}, ops...), webhookOps...), callbackOps...)
+
+	api := &GoAPI{
+		Types:      map[string]GoAPIType{},
+		Constants:  map[string]GoAPIConstant{},
+		Operations: map[string]GoAPIOperation{},
+	}
+
+	if opts.Generate.Models {
+		types, err := gs.generateComponentTypeDefinitions(t, spec, opts.OutputOptions.ExcludeSchemas)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range modelOps {
+			types = append(types, op.TypeDefinitions...)
+		}
+		for _, td := range types {
+			api.addType(td)
+		}
+		for _, op := range modelOps {
+			for _, body := range op.Bodies {
+				if body.IsSupported() {
+					api.addType(*body.TypeDef(op.OperationId))
+				}
+			}
+		}
+
+		for _, enum := range gs.enumDefinitions(types) {
+			for name, value := range enum.GetValues() {
+				api.Constants[name] = GoAPIConstant{
+					Type:  enum.TypeName,
+					Value: enum.ValueWrapper + value + enum.ValueWrapper,
+				}
+			}
+		}
+	}
+
+	if opts.Generate.Client {
+		for _, op := range ops {
+			responses, err := gs.responseTypeDefinitions(&op)
+			if err != nil {
+				return nil, err
+			}
+			response := GoAPIType{Fields: map[string]string{}}
+			for _, rtd := range responses {
+				response.Fields[rtd.TypeName] = "*" + rtd.Schema.TypeDecl()
+				if rtd.IsEventStream() {
+					response.Fields[rtd.TypeName] = "*ServerSentEventReader[" + rtd.Schema.TypeDecl() + "]"
+				}
+				for _, td := range rtd.AdditionalTypeDefinitions {
+					api.addType(td)
+				}
+			}
+			api.Types[gs.genResponseTypeName(op.OperationId)] = response
+		}
+	}
+
+	if opts.Generate.ChiServer || opts.Generate.EchoServer || opts.Generate.FiberServer || opts.Generate.GinServer ||
+		opts.Generate.GorillaServer || opts.Generate.IrisServer || opts.Generate.StdHTTPServer {
+		api.Interfaces = append(api.Interfaces, "ServerInterface")
+	}
+	if opts.Generate.Strict {
+		api.Interfaces = append(api.Interfaces, "StrictServerInterface")
+	}
+	if opts.Generate.Client {
+		api.Interfaces = append(api.Interfaces, clientInterfaces...)
+	}
+
+	for _, op := range ops {
+		operation := GoAPIOperation{}
+		for _, param := range op.PathParams {
+			operation.PathParams = append(operation.PathParams, param.TypeDef())
+		}
+		if op.RequiresParamObject() {
+			operation.Params = "*" + op.OperationId + "Params"
+		}
+		if opts.Generate.Client {
+			operation.ClientMethods = clientMethods(op)
+		}
+		api.Operations[op.OperationId] = operation
+	}
+
+	return api, nil
+}
+
+// addType adds the type, and the additional types which it's generated with.
+func (a *GoAPI) addType(td TypeDefinition) {
+	t := GoAPIType{Alias: td.IsAlias()}
+
+	schema := td.Schema
+	if strings.HasPrefix(schema.TypeDecl(), "struct") {
+		t.Fields = map[string]string{}
+		for _, p := range schema.Properties {
+			t.Fields[p.GoFieldName()] = p.GoTypeDef()
+		}
+		if schema.HasAdditionalProperties {
+			t.Fields["AdditionalProperties"] = "map[string]" + schema.AdditionalPropertiesType.TypeDecl()
+		}
+		for _, element := range schema.UnionElements {
+			t.UnionElements = append(t.UnionElements, element.Method())
+		}
+	} else {
+		t.Underlying = schema.TypeDecl()
+	}
+	a.Types[td.TypeName] = t
+
+	for _, additional := range schema.AdditionalTypes {
+		a.addType(additional)
+	}
+}
+
+// clientInterfaces are the interfaces of the client, which have each of its
+// methods.
+var clientInterfaces = []string{"ClientInterface", "ClientWithResponsesInterface"}
+
+// clientMethods returns the arguments for the body of each of the methods of
+// the client which are generated for the operation, by name, as the client's
+// template generates them.
+func clientMethods(op OperationDefinition) map[string]string {
+	if !op.HasBody() {
+		return map[string]string{op.OperationId: ""}
+	}
+
+	methods := map[string]string{
+		op.OperationId + "WithBody": "contentType string, body io.Reader",
+	}
+	for _, body := range op.Bodies {
+		if body.IsSupportedByClient() {
+			methods[op.OperationId+body.Suffix()] = "body " + op.OperationId + body.NameTag + "RequestBody"
+		}
+		if body.IsStream() {
+			methods[op.OperationId+"With"+body.NameTag+"Stream"] = "body " + body.Stream.TypeDecl()
+		}
+	}
+	return methods
+}
+
+// BreakingChange is a change to the Go API of the code which is generated for
+// a spec, which breaks the code that uses it.
+type BreakingChange struct {
+	// Identifier is the Go identifier which changed, such as `Pet`,
+	// `Pet.Tag`, or the name of an operation.
+	Identifier string
+	// Message describes the change.
+	Message string
+}
+
+// String describes the BreakingChange on a single line.
+func (c BreakingChange) String() string {
+	return c.Identifier + ": " + c.Message
+}
+
+// DiffGoAPIs returns the changes from the old GoAPI to the new one which break
+// the code that uses the old one, ordered by their kind, and then by their
+// identifier. Changes which are compatible, such as a new type, or a new
+// field, aren't returned. A new operation, or a new method of the client,
+// breaks the implementations of the interfaces which it's added to, so is only
+// compatible when none of them are generated.
+func DiffGoAPIs(old, new *GoAPI) []BreakingChange {
+	var changes []BreakingChange
+	add := func(identifier string, format string, args ...interface{}) {
+		changes = append(changes, BreakingChange{Identifier: identifier, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range SortedMapKeys(old.Types) {
+		oldType := old.Types[name]
+		newType, ok := new.Types[name]
+		if !ok {
+			add(name, "the type was removed")
+			continue
+		}
+
+		if oldType.Alias != newType.Alias {
+			if newType.Alias {
+				add(name, "the type is now an alias, so it no longer has methods of its own")
+			} else {
+				add(name, "the type is no longer an alias, so it's no longer assignable to and from %s", describeUnderlying(oldType))
+			}
+		}
+
+		if oldType.Underlying != newType.Underlying {
+			add(name, "the type changed from %s to %s", describeUnderlying(oldType), describeUnderlying(newType))
+			continue
+		}
+
+		for _, field := range SortedMapKeys(oldType.Fields) {
+			oldField := oldType.Fields[field]
+			newField, ok := newType.Fields[field]
+			switch {
+			case !ok:
+				add(name+"."+field, "the field was removed")
+			case oldField == "*"+newField:
+				add(name+"."+field, "the field's type changed from %s to %s, losing its pointer, such as when it becomes required", oldField, newField)
+			case oldField != newField:
+				add(name+"."+field, "the field's type changed from %s to %s", oldField, newField)
+			}
+		}
+
+		for _, element := range oldType.UnionElements {
+			if !slices.Contains(newType.UnionElements, element) {
+				add(name, "%[1]s is no longer one of the elements of the union, so its As%[1]s, From%[1]s and Merge%[1]s methods were removed", element)
+			}
+		}
+	}
+
+	// a constant which was removed, and whose value is now that of a new
+	// constant of the same type, was renamed
+	renamed := map[GoAPIConstant]string{}
+	for _, name := range SortedMapKeys(new.Constants) {
+		if _, ok := old.Constants[name]; !ok {
+			renamed[new.Constants[name]] = name
+		}
+	}
+	for _, name := range SortedMapKeys(old.Constants) {
+		oldConstant := old.Constants[name]
+		newConstant, ok := new.Constants[name]
+		switch {
+		case !ok && renamed[oldConstant] != "":
+			add(name, "the constant was renamed to %s", renamed[oldConstant])
+		case !ok:
+			add(name, "the constant was removed")
+		case oldConstant.Type != newConstant.Type:
+			add(name, "the constant's type changed from %s to %s", oldConstant.Type, newConstant.Type)
+		case oldConstant.Value != newConstant.Value:
+			add(name, "the constant's value changed from %s to %s", oldConstant.Value, newConstant.Value)
+		}
+	}
+
+	for _, name := range SortedMapKeys(old.Operations) {
+		oldOp := old.Operations[name]
+		newOp, ok := new.Operations[name]
+		if !ok {
+			add(name, "the operation was removed, along with its methods")
+			continue
+		}
+
+		if strings.Join(oldOp.PathParams, ", ") != strings.Join(newOp.PathParams, ", ") {
+			add(name, "the path parameters of the operation's methods changed from (%s) to (%s)", strings.Join(oldOp.PathParams, ", "), strings.Join(newOp.PathParams, ", "))
+		}
+
+		switch {
+		case oldOp.Params == "" && newOp.Params != "":
+			add(name, "the operation's methods now take a params argument of %s", newOp.Params)
+		case oldOp.Params != "" && newOp.Params == "":
+			add(name, "the operation's methods no longer take a params argument of %s", oldOp.Params)
+		}
+
+		for _, method := range SortedMapKeys(oldOp.ClientMethods) {
+			oldBody := oldOp.ClientMethods[method]
+			newBody, ok := newOp.ClientMethods[method]
+			switch {
+			case !ok:
+				add(method, "the client's method was removed, as the operation's request bodies changed")
+			case oldBody != newBody:
+				add(method, "the body arguments of the client's method changed from (%s) to (%s)", oldBody, newBody)
+			}
+		}
+
+		if interfaces := sharedInterfaces(old, new, clientInterfaces); len(interfaces) != 0 {
+			for _, method := range SortedMapKeys(newOp.ClientMethods) {
+				if _, ok := oldOp.ClientMethods[method]; !ok {
+					add(method, "the client's method was added, as the operation's request bodies changed, which breaks the implementations of the %s", describeInterfaces(interfaces))
+				}
+			}
+		}
+	}
+
+	if interfaces := sharedInterfaces(old, new, nil); len(interfaces) != 0 {
+		for _, name := range SortedMapKeys(new.Operations) {
+			if _, ok := old.Operations[name]; !ok {
+				add(name, "the operation was added, along with its methods, which breaks the implementations of the %s", describeInterfaces(interfaces))
+			}
+		}
+	}
+
+	return changes
+}
+
+// sharedInterfaces returns the interfaces which both GoAPIs have, out of the
+// candidates, or out of all of them when there are no candidates.
+func sharedInterfaces(old, new *GoAPI, candidates []string) []string {
+	var interfaces []string
+	for _, name := range old.Interfaces {
+		if slices.Contains(new.Interfaces, name) && (candidates == nil || slices.Contains(candidates, name)) {
+			interfaces = append(interfaces, name)
+		}
+	}
+	return interfaces
+}
+
+// describeInterfaces describes a list of interfaces, such as `ServerInterface
+// and StrictServerInterface`.
+func describeInterfaces(interfaces []string) string {
+	if len(interfaces) == 1 {
+		return interfaces[0]
+	}
+	return strings.Join(interfaces[:len(interfaces)-1], ", ") + " and " + interfaces[len(interfaces)-1]
+}
+
+// describeUnderlying describes what a type is declared as.
+func describeUnderlying(t GoAPIType) string {
+	if t.Underlying == "" {
+		return "a struct"
+	}
+	return t.Underlying
+}
@@ -0,0 +1,255 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldAPIDiffOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: The pet was added
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Animal'
+    delete:
+      operationId: deletePet
+      responses:
+        '204':
+          description: The pet was deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+        tag:
+          type: string
+        status:
+          $ref: '#/components/schemas/Status'
+    Status:
+      type: string
+      enum: [available, pending, sold]
+      x-enum-varnames: [Available, Pending, Sold]
+    Cat:
+      type: object
+      properties:
+        purrs:
+          type: boolean
+    Dog:
+      type: object
+      properties:
+        barks:
+          type: boolean
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+`
+
+func TestGoAPI(t *testing.T) {
+	api := testGoAPI(t, oldAPIDiffOpenAPIDefinition)
+
+	assert.Equal(t, GoAPIType{
+		Fields: map[string]string{
+			"Id":     "int64",
+			"Name":   "*string",
+			"Tag":    "*string",
+			"Status": "*Status",
+		},
+	}, api.Types["Pet"])
+	assert.Equal(t, GoAPIType{Underlying: "string"}, api.Types["Status"])
+	assert.Equal(t, []string{"Cat", "Dog"}, api.Types["Animal"].UnionElements)
+	assert.Equal(t, GoAPIType{Alias: true, Underlying: "Pet"}, api.Types["AddPetJSONRequestBody"])
+	assert.Equal(t, "*[]Pet", api.Types["ListPetsResponse"].Fields["JSON200"])
+
+	assert.Equal(t, GoAPIConstant{Type: "Status", Value: `"sold"`}, api.Constants["Sold"])
+
+	assert.Equal(t, GoAPIOperation{
+		PathParams:    []string{"string"},
+		ClientMethods: map[string]string{"GetPet": ""},
+	}, api.Operations["GetPet"])
+	assert.Equal(t, "*ListPetsParams", api.Operations["ListPets"].Params)
+	assert.Equal(t, map[string]string{
+		"AddPetWithBody": "contentType string, body io.Reader",
+		"AddPet":         "body AddPetJSONRequestBody",
+	}, api.Operations["AddPet"].ClientMethods)
+
+	assert.Equal(t, []string{"ServerInterface", "ClientInterface", "ClientWithResponsesInterface"}, api.Interfaces)
+}
+
+func TestDiffGoAPIs(t *testing.T) {
+	old := testGoAPI(t, oldAPIDiffOpenAPIDefinition)
+
+	// the same spec has no breaking changes
+	assert.Empty(t, DiffGoAPIs(old, testGoAPI(t, oldAPIDiffOpenAPIDefinition)))
+
+	new := testGoAPI(t, strings.NewReplacer(
+		// the operation is removed
+		`    delete:
+      operationId: deletePet
+      responses:
+        '204':
+          description: The pet was deleted
+`, "",
+		// the query parameter is removed, so there are no params
+		`      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+`, "",
+		// the path parameter is an integer
+		`        schema:
+          type: string
+    get:`, `        schema:
+          type: integer
+    get:`,
+		// the tag is required, and the name is removed
+		"required: [id]", "required: [id, tag]",
+		`        name:
+          type: string
+`, "",
+		// an enum constant is renamed, and another is removed
+		"enum: [available, pending, sold]", "enum: [available, sold]",
+		"x-enum-varnames: [Available, Pending, Sold]", "x-enum-varnames: [Available, SoldOut]",
+		// the union loses an element
+		`        - $ref: '#/components/schemas/Dog'
+`, "",
+		// the body is optional, rather than required, which is compatible,
+		// but it can also be text, which adds a method to the client
+		`      requestBody:
+        content:`, `      requestBody:
+        required: false
+        content:
+          text/plain:
+            schema:
+              type: string`,
+	).Replace(oldAPIDiffOpenAPIDefinition))
+
+	var changes []string
+	for _, change := range DiffGoAPIs(old, new) {
+		changes = append(changes, change.String())
+	}
+	assert.Equal(t, []string{
+		"Animal: Dog is no longer one of the elements of the union, so its AsDog, FromDog and MergeDog methods were removed",
+		"DeletePetResponse: the type was removed",
+		// the schema is pruned, as it's no longer used
+		"Dog: the type was removed",
+		"ListPetsParams: the type was removed",
+		"Pet.Name: the field was removed",
+		"Pet.Tag: the field's type changed from *string to string, losing its pointer, such as when it becomes required",
+		"Pending: the constant was removed",
+		"Sold: the constant was renamed to SoldOut",
+		"AddPetWithTextBody: the client's method was added, as the operation's request bodies changed, which breaks the implementations of the ClientInterface and ClientWithResponsesInterface",
+		"DeletePet: the operation was removed, along with its methods",
+		"GetPet: the path parameters of the operation's methods changed from (string) to (int)",
+		"ListPets: the operation's methods no longer take a params argument of *ListPetsParams",
+	}, changes)
+}
+
+func TestDiffGoAPIsAddedOperation(t *testing.T) {
+	addOperation := strings.NewReplacer(`    delete:
+      operationId: deletePet`, `    put:
+      operationId: updatePet
+      responses:
+        '204':
+          description: The pet was updated
+    delete:
+      operationId: deletePet`)
+
+	old := testGoAPI(t, oldAPIDiffOpenAPIDefinition)
+	new := testGoAPI(t, addOperation.Replace(oldAPIDiffOpenAPIDefinition))
+
+	var changes []string
+	for _, change := range DiffGoAPIs(old, new) {
+		changes = append(changes, change.String())
+	}
+	assert.Equal(t, []string{
+		"UpdatePet: the operation was added, along with its methods, which breaks the implementations of the ServerInterface, ClientInterface and ClientWithResponsesInterface",
+	}, changes)
+
+	// the operation is compatible when there are no interfaces for its methods
+	generate := GenerateOptions{Models: true}
+	old = testGoAPIWith(t, oldAPIDiffOpenAPIDefinition, generate)
+	new = testGoAPIWith(t, addOperation.Replace(oldAPIDiffOpenAPIDefinition), generate)
+	assert.Empty(t, old.Interfaces)
+	assert.Empty(t, DiffGoAPIs(old, new))
+}
+
+func testGoAPI(t *testing.T, definition string) *GoAPI {
+	t.Helper()
+	return testGoAPIWith(t, definition, GenerateOptions{
+		Models:        true,
+		Client:        true,
+		StdHTTPServer: true,
+	})
+}
+
+func testGoAPIWith(t *testing.T, definition string, generate GenerateOptions) *GoAPI {
+	t.Helper()
+
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(definition))
+	require.NoError(t, err)
+
+	g, err := NewGenerator(Configuration{
+		PackageName: "api",
+		Generate:    generate,
+	})
+	require.NoError(t, err)
+
+	api, err := g.GoAPI(spec)
+	require.NoError(t, err)
+	return api
+}
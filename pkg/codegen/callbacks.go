@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// CallbackDefinition describes where a callback operation was defined.
+type CallbackDefinition struct {
+	// Name is the key of the callback in the `callbacks` of the operation, such as `onComplete`
+	Name string
+	// OperationId is the (normalized) OperationId of the operation that defines the callback
+	OperationId string
+	// Expression is the runtime expression which is resolved to the URL of the callback, such as `{$request.body#/callbackUrl}`
+	Expression string
+}
+
+// CallbackDefinitions returns an OperationDefinition for each operation of the
+// `callbacks` defined by the given operations.
+//
+// The Path of each operation is the runtime expression that the URL of the
+// callback is resolved from. Callback operations without an `operationId` are
+// named after the operation defining them, and the name of the callback.
+func CallbackDefinitions(swagger *openapi3.T, ops []OperationDefinition, initialismOverrides bool) ([]OperationDefinition, error) {
+	gs := packageGeneratorState()
+	gs.spec = swagger
+	return gs.callbackDefinitions(swagger, ops, initialismOverrides)
+}
+
+func (gs *generatorState) callbackDefinitions(swagger *openapi3.T, ops []OperationDefinition, initialismOverrides bool) ([]OperationDefinition, error) {
+	var operations []OperationDefinition
+
+	var toCamelCaseFunc func(string) string
+	if initialismOverrides {
+		toCamelCaseFunc = gs.toCamelCaseWithInitialism
+	} else {
+		toCamelCaseFunc = ToCamelCase
+	}
+
+	for _, op := range ops {
+		if op.Spec == nil {
+			continue
+		}
+
+		for _, name := range SortedMapKeys(op.Spec.Callbacks) {
+			callbackRef := op.Spec.Callbacks[name]
+			if callbackRef == nil || callbackRef.Value == nil {
+				return nil, fmt.Errorf("callback %s of %s has no value", name, op.OperationId)
+			}
+
+			callback := callbackRef.Value.Map()
+			for _, expression := range SortedMapKeys(callback) {
+				// The runtime expression isn't a path, so the operations are
+				// described as if they were found under a path made up of the
+				// names of the operation and callback, which is also used to
+				// name any operations without an `operationId`
+				callbackPath := "/" + op.OperationId + "/" + name
+				callbackOperations, err := gs.describePathItemOperations(swagger, callbackPath, callback[expression], toCamelCaseFunc)
+				if err != nil {
+					return nil, fmt.Errorf("error describing callback %s of %s: %w", name, op.OperationId, err)
+				}
+				for i := range callbackOperations {
+					callbackOperations[i].Path = expression
+					callbackOperations[i].Callback = &CallbackDefinition{
+						Name:        name,
+						OperationId: op.OperationId,
+						Expression:  expression,
+					}
+				}
+				operations = append(operations, callbackOperations...)
+			}
+		}
+	}
+	return operations, nil
+}
+
+// GenerateCallbacks generates the receiver-side CallbackServerInterface, along
+// with its net/http wrappers, and the sender-side CallbackClient for each of
+// the callback operations.
+func GenerateCallbacks(t *template.Template, ops []OperationDefinition, opts Configuration) (string, error) {
+	return generateWebhookTemplates(t, webhookTemplateContext{Prefix: "Callback", Callbacks: true, Operations: ops}, opts)
+}
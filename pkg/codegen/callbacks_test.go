@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestCallbackDefinitions(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/callbacks.yaml")
+	require.NoError(t, err)
+
+	ops, err := OperationDefinitions(swagger, false)
+	require.NoError(t, err)
+
+	callbackOps, err := CallbackDefinitions(swagger, ops, false)
+	require.NoError(t, err)
+	require.Len(t, callbackOps, 2)
+
+	assert.Equal(t, "JobCompleted", callbackOps[0].OperationId)
+	assert.Equal(t, "{$request.body#/callbackUrl}", callbackOps[0].Path)
+	assert.Equal(t, "POST", callbackOps[0].Method)
+	assert.Equal(t, &CallbackDefinition{
+		Name:        "onComplete",
+		OperationId: "CreateJob",
+		Expression:  "{$request.body#/callbackUrl}",
+	}, callbackOps[0].Callback)
+
+	// operations without an operationId are named after the operation and callback
+	assert.Equal(t, "PutCreateJobOnProgress", callbackOps[1].OperationId)
+	assert.Equal(t, "{$request.body#/callbackUrl}/progress?job={$response.body#/id}", callbackOps[1].Path)
+	assert.Len(t, callbackOps[1].HeaderParams, 1)
+}
+
+func TestGenerateCallbacks(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Callbacks: true,
+			Strict:    true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/callbacks.yaml")
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "type JobResult struct {")
+	assert.Contains(t, code, "type JobCompletedJSONRequestBody = JobResult")
+	assert.Contains(t, code, "type PutCreateJobOnProgressParams struct {")
+
+	// Check the receiver
+	assert.Contains(t, code, "type CallbackServerInterface interface {")
+	assert.Contains(t, code, "JobCompleted(w http.ResponseWriter, r *http.Request)")
+	assert.Contains(t, code, "func JobCompletedCallbackHandler(si CallbackServerInterface, options CallbackHandlerOptions) http.Handler {")
+	assert.Contains(t, code, "type CallbackStrictServerInterface interface {")
+	assert.Contains(t, code, "func NewCallbackStrictHandler(ssi CallbackStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc) CallbackServerInterface {")
+
+	// Check the sender
+	assert.Contains(t, code, `const JobCompletedCallbackExpression = "{$request.body#/callbackUrl}"`)
+	assert.Contains(t, code, "func (s CallbackSource) ResolveExpression(expression string) (string, error) {")
+	assert.Contains(t, code, "func (c *CallbackClient) JobCompleted(ctx context.Context, source CallbackSource, body JobCompletedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+	assert.Contains(t, code, "func NewPutCreateJobOnProgressCallbackRequest(callbackURL string, params *PutCreateJobOnProgressParams) (*http.Request, error) {")
+}
+
+func TestGenerateCallbacksWithClientAndWebhooks(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Callbacks: true,
+			Webhooks:  true,
+			Client:    true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/callbacks.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that the types shared with the other clients are only defined once
+	assert.Equal(t, 1, strings.Count(code, "type HttpRequestDoer interface {"))
+	assert.Equal(t, 1, strings.Count(code, "type RequestEditorFn func("))
+}
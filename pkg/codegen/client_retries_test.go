@@ -0,0 +1,111 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientRetriesOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Client retries
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+    post:
+      operationId: feedPets
+      x-oapi-codegen-retryable: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: The pets were fed
+`
+
+func TestGenerateClientRetries(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientRetriesOpenAPIDefinition))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientRetries: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "RetryPolicy *RetryPolicy")
+	assert.Contains(t, code, "func WithRetryPolicy(policy RetryPolicy) ClientOption {")
+	assert.Contains(t, code, "func WithIdempotencyKeys(generate func() string) ClientOption {")
+	assert.Contains(t, code, `crand "crypto/rand"`)
+	assert.Contains(t, code, "rsp, err := c.doWithRetries(req, retryable)")
+	assert.Contains(t, code, `func (c *Client) ListPets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, false)
+}`)
+	assert.Contains(t, code, `func (c *Client) FeedPets(ctx context.Context, body FeedPetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFeedPetsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, operationIDContextKey{}, "FeedPets")
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, true)
+}`)
+
+	t.Run("without client-retries", func(t *testing.T) {
+		opts.OutputOptions.ClientRetries = false
+
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+
+		assert.NotContains(t, code, "RetryPolicy")
+		assert.NotContains(t, code, "doWithRetries")
+		assert.Contains(t, code, "return c.do(req)")
+	})
+}
+
+func TestClientRetryableExtensionMustBeABoolean(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientRetriesOpenAPIDefinition))
+	require.NoError(t, err)
+	swagger.Paths.Value("/pets").Post.Extensions[extOapiCodegenRetryable] = "yes"
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+	})
+	assert.ErrorContains(t, err, "x-oapi-codegen-retryable")
+}
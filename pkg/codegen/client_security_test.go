@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientSecurityOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Client security
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+    post:
+      operationId: addPet
+      security:
+        - oauth2: [pets:write]
+        - tenant: []
+          apiKey: []
+      responses:
+        '204':
+          description: The pet was added
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        '204':
+          description: The API is healthy
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    tenant:
+      type: apiKey
+      in: header
+      name: X-Tenant-ID
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            pets:write: Change pets
+`
+
+func TestDescribeSecurityRequirements(t *testing.T) {
+	requirements := DescribeSecurityRequirements(openapi3.SecurityRequirements{
+		{"oauth2": {"pets:write"}},
+		{"tenant": {}, "apiKey": {}},
+		{},
+	})
+
+	assert.Equal(t, [][]SecurityDefinition{
+		{{ProviderName: "oauth2", Scopes: []string{"pets:write"}}},
+		{{ProviderName: "apiKey", Scopes: []string{}}, {ProviderName: "tenant", Scopes: []string{}}},
+		{},
+	}, requirements)
+}
+
+func TestGenerateClientSecurity(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientSecurityOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTypeName: "PetsClient",
+			ClientSecurity: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "type SecurityProviders map[string]RequestEditorFn")
+	assert.Contains(t, code, "	SecurityProviders SecurityProviders\n}")
+	assert.Contains(t, code, `var operationSecurityRequirements = map[string][][]string{
+	"ListPets": {
+		{"apiKey"},
+	},
+	"AddPet": {
+		{"oauth2"},
+		{"apiKey", "tenant"},
+	},
+}`)
+	assert.Contains(t, code, "func WithSecurityProviders(providers SecurityProviders) ClientOption {")
+	assert.Contains(t, code, `func (c *PetsClient) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	if err := c.applySecurity(ctx, req); err != nil {
+		return err
+	}`)
+}
+
+func TestClientSecurityIsOptional(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientSecurityOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, code, "SecurityProviders")
+	assert.NotContains(t, code, "applySecurity")
+}
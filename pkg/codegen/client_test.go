@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateClientMiddleware(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientRetriesOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTypeName: "PetsClient",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "type ResponseEditorFn func(ctx context.Context, rsp *http.Response) error")
+	assert.Contains(t, code, "func (f HttpRequestDoerFunc) Do(req *http.Request) (*http.Response, error) {")
+	assert.Contains(t, code, "func OperationIDFromContext(ctx context.Context) (string, bool) {")
+	assert.Contains(t, code, "func WithResponseEditorFn(fn ResponseEditorFn) ClientOption {")
+	assert.Contains(t, code, "func WithMiddleware(middleware func(HttpRequestDoer) HttpRequestDoer) ClientOption {")
+	assert.Contains(t, code, `	for _, middleware := range client.Middlewares {
+		client.Client = middleware(client.Client)
+	}`)
+	assert.Contains(t, code, `ctx = context.WithValue(ctx, operationIDContextKey{}, "ListPets")`)
+	assert.Contains(t, code, `ctx = context.WithValue(ctx, operationIDContextKey{}, "FeedPets")`)
+	assert.Contains(t, code, "func (c *PetsClient) do(req *http.Request) (*http.Response, error) {")
+	assert.NotContains(t, code, "c.Client.Do(req)\n}")
+}
@@ -0,0 +1,191 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientTypedMethodsOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Client typed methods
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '4XX':
+          description: The request was invalid
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+        default:
+          description: Something went wrong
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: The pet was updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '202':
+          description: The pet will be updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        '206':
+          description: Some of the pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+func TestGenerateClientTypedMethods(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientTypedMethodsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTypedMethods: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "type UnexpectedStatusError struct {")
+
+	assert.Contains(t, code, "func (c *ClientWithResponses) GetPetTyped(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*Pet, error) {")
+	assert.Contains(t, code, `type GetPet4XXError struct {
+	Response *GetPetResponse
+	// Body is the decoded JSON body of the response, which is nil when it doesn't have one
+	Body *Error
+}`)
+	assert.Contains(t, code, `type GetPetDefaultError struct {
+	Response *GetPetResponse
+}`)
+	assert.Contains(t, code, `	switch {
+	case response.StatusCode()/100 == 4:
+		return nil, &GetPet4XXError{Response: response, Body: response.JSON4XX}
+	default:
+		return nil, &GetPetDefaultError{Response: response}
+	}
+}`)
+
+	assert.Contains(t, code, "func (c *ClientWithResponses) UpdatePetWithBodyTyped(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*Pet, error) {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) UpdatePetTyped(ctx context.Context, id string, body UpdatePetJSONRequestBody, reqEditors ...RequestEditorFn) (*Pet, error) {")
+	assert.Contains(t, code, `		if response.JSON200 != nil {
+			return response.JSON200, nil
+		}
+		if response.JSON202 != nil {
+			return response.JSON202, nil
+		}`)
+	assert.Contains(t, code, `return nil, &UnexpectedStatusError{OperationID: "UpdatePet", HTTPResponse: response.HTTPResponse, Body: response.Body}`)
+
+	// the successful responses have bodies of different types, so there's no type for the method to return
+	assert.NotContains(t, code, "ListPetsTyped")
+}
+
+func TestClientTypedMethodsSkippedDiagnostic(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientTypedMethodsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	g, err := NewGenerator(Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTypedMethods: true,
+		},
+	})
+	require.NoError(t, err)
+	_, diagnostics, err := g.GenerateFilesWithDiagnostics(swagger)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Diagnostic{{
+		Rule:    DiagnosticTypedClientSkipped,
+		Message: "the successful responses of ListPets have bodies of the different types []Pet and Pet, so the client has no ListPetsTyped methods",
+		Pointer: "#/paths/~1pets/get/responses/206",
+	}}, diagnostics)
+}
+
+func TestClientTypedMethodsAreOptional(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(clientTypedMethodsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, code, "Typed")
+	assert.NotContains(t, code, "UnexpectedStatusError")
+}
@@ -15,23 +15,23 @@
 package codegen
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
 	"os"
+	"regexp"
 	"runtime/debug"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
-	"golang.org/x/tools/imports"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 )
@@ -41,15 +41,96 @@ import (
 //go:embed templates
 var templates embed.FS
 
-// globalState stores all global state. Please don't put global state anywhere
-// else so that we can easily track it.
-var globalState struct {
+// generatorState is the state of a generation. The helpers, and the template
+// functions, which depend on it are its methods, so that generations don't
+// interfere with each other.
+type generatorState struct {
 	options       Configuration
 	spec          *openapi3.T
 	importMapping importMap
 	// initialismsMap stores initialisms as "lower(initialism) -> initialism" map.
 	// List of initialisms was taken from https://staticcheck.io/docs/configuration/options/#initialisms.
 	initialismsMap map[string]string
+	// initialismsRegexp matches any of the initialisms
+	initialismsRegexp *regexp.Regexp
+	// nameNormalizer is the `name-normalizer` which names the generated types
+	// and functions
+	nameNormalizer NameNormalizer
+	// responseTypeSuffix is the suffix of the types of the responses of the
+	// client with responses
+	responseTypeSuffix string
+	// diagnostics are the warnings about the spec, which are nil outside of a
+	// generation
+	diagnostics *diagnostics
+}
+
+// newGeneratorState returns the state of a generation with the default
+// options.
+func newGeneratorState() *generatorState {
+	return &generatorState{
+		initialismsMap:     defaultInitialismsMap,
+		initialismsRegexp:  defaultInitialismsRegexp,
+		nameNormalizer:     ToCamelCase,
+		responseTypeSuffix: "Response",
+	}
+}
+
+// packageState is the state of the latest generation, without its
+// diagnostics, which the exported helpers, such as SchemaNameToTypeName, are
+// called with, so that they name types as the latest generation did. It's
+// only read, and written, under its lock, but generations in parallel replace
+// each other's state.
+var packageState struct {
+	sync.Mutex
+	state *generatorState
+}
+
+// packageGeneratorState returns a copy of the state of the latest generation,
+// or the state of a generation with the default options before the first
+// generation.
+func packageGeneratorState() *generatorState {
+	packageState.Lock()
+	defer packageState.Unlock()
+	if packageState.state == nil {
+		return newGeneratorState()
+	}
+	gs := *packageState.state
+	return &gs
+}
+
+// setPackageState replaces the state which the exported helpers are called
+// with.
+func setPackageState(gs generatorState) {
+	gs.diagnostics = nil
+	packageState.Lock()
+	defer packageState.Unlock()
+	packageState.state = &gs
+}
+
+// SetGlobalStateSpec sets the spec which the exported helpers, such as
+// RefPathToGoType, resolve references with, until the next generation.
+//
+// Deprecated: each generation resolves the references of its own spec, and
+// the helpers are called with the options, and spec, of the latest one, so
+// this is only needed to call the helpers with another spec.
+func SetGlobalStateSpec(spec *openapi3.T) {
+	packageState.Lock()
+	defer packageState.Unlock()
+	gs := newGeneratorState()
+	if packageState.state != nil {
+		gs = packageState.state
+	}
+	gs.spec = spec
+	packageState.state = gs
+}
+
+// orDefault returns the state, or the state of the latest generation when it's
+// nil, such as for the definitions which are created outside of a generation.
+func (gs *generatorState) orDefault() *generatorState {
+	if gs == nil {
+		return packageGeneratorState()
+	}
+	return gs
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -114,48 +195,95 @@ func constructImportMapping(importMapping map[string]string) importMap {
 // Generate uses the Go templating engine to generate all of our server wrappers from
 // the descriptions we've built up above from the schema objects.
 // opts defines
+//
+// Generate is safe to call from many goroutines, but it prepares the options
+// on each call, so when generating several specs with the same options, a
+// Generator prepares them only once.
 func Generate(spec *openapi3.T, opts Configuration) (string, error) {
-	// This is global state
-	globalState.options = opts
-	globalState.spec = spec
-	globalState.importMapping = constructImportMapping(opts.ImportMapping)
+	g, err := NewGenerator(opts)
+	if err != nil {
+		return "", err
+	}
+	return g.Generate(spec)
+}
 
-	filterOperationsByTag(spec, opts)
-	filterOperationsByOperationID(spec, opts)
-	if !opts.OutputOptions.SkipPrune {
-		pruneUnusedComponents(spec)
+// GenerateFiles generates the same code as Generate, but splits it into the
+// files configured in the `output-options.layout`. The first file is the main
+// output, unless all of its code has been written to other files.
+func GenerateFiles(spec *openapi3.T, opts Configuration) ([]GeneratedFile, error) {
+	g, err := NewGenerator(opts)
+	if err != nil {
+		return nil, err
 	}
+	return g.GenerateFiles(spec)
+}
 
-	// if we are provided an override for the response type suffix update it
-	if opts.OutputOptions.ResponseTypeSuffix != "" {
-		responseTypeSuffix = opts.OutputOptions.ResponseTypeSuffix
+// Generator generates code from specs with the same Configuration, whose
+// templates, and any user-provided templates, are loaded only once.
+//
+// A Generator is safe to use from many goroutines, as the state of each
+// generation is its own.
+//
+// Generating code prunes, and filters, the spec it's generated from, so a
+// spec mustn't be generated by more than one goroutine at a time.
+type Generator struct {
+	options Configuration
+	// state is the state which each generation starts with
+	state     generatorState
+	templates *template.Template
+}
+
+// NewGenerator returns a Generator of code with the options, or an error
+// when they're invalid, or their templates can't be loaded.
+func NewGenerator(opts Configuration) (*Generator, error) {
+	if problems := opts.OutputOptions.Layout.Validate(); len(problems) > 0 {
+		var errs []error
+		for _, k := range SortedMapKeys(problems) {
+			errs = append(errs, fmt.Errorf("`output-options` configuration for %v was incorrect: %v", k, problems[k]))
+		}
+		return nil, errors.Join(errs...)
 	}
 
-	if globalState.options.OutputOptions.ClientTypeName == "" {
-		globalState.options.OutputOptions.ClientTypeName = defaultClientTypeName
+	g := &Generator{
+		options: opts,
+		state:   *newGeneratorState(),
+	}
+
+	if g.options.OutputOptions.ClientTypeName == "" {
+		g.options.OutputOptions.ClientTypeName = defaultClientTypeName
+	}
+	g.state.options = g.options
+	g.state.importMapping = constructImportMapping(opts.ImportMapping)
+
+	// if we are provided an override for the response type suffix update it
+	if opts.OutputOptions.ResponseTypeSuffix != "" {
+		g.state.responseTypeSuffix = opts.OutputOptions.ResponseTypeSuffix
 	}
 
 	nameNormalizerFunction := NameNormalizerFunction(opts.OutputOptions.NameNormalizer)
-	nameNormalizer = NameNormalizers[nameNormalizerFunction]
-	if nameNormalizer == nil {
-		return "", fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
+	g.state.nameNormalizer = NameNormalizers[nameNormalizerFunction]
+	if g.state.nameNormalizer == nil {
+		return nil, fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
 			opts.OutputOptions.NameNormalizer, NameNormalizers.Options())
 	}
 
 	if nameNormalizerFunction != NameNormalizerFunctionToCamelCaseWithInitialisms && len(opts.OutputOptions.AdditionalInitialisms) > 0 {
-		return "", fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
+		return nil, fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
 	}
 
-	globalState.initialismsMap = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
+	if len(opts.OutputOptions.AdditionalInitialisms) > 0 {
+		g.state.initialismsMap, g.state.initialismsRegexp = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
+		g.state.nameNormalizer = g.state.toCamelCaseWithInitialisms
+	}
 
-	// This creates the golang templates text package
-	TemplateFunctions["opts"] = func() Configuration { return globalState.options }
-	t := template.New("oapi-codegen").Funcs(TemplateFunctions)
+	// This creates the golang templates text package, whose functions are
+	// replaced by those of each generation
+	t := template.New("oapi-codegen").Funcs(g.state.templateFunctions())
 	// This parses all of our own template files into the template object
 	// above
 	err := LoadTemplates(templates, t)
 	if err != nil {
-		return "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
+		return nil, fmt.Errorf("error parsing oapi-codegen templates: %w", err)
 	}
 
 	// load user-provided templates. Will Override built-in versions.
@@ -164,49 +292,160 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 
 		txt, err := GetUserTemplateText(template)
 		if err != nil {
-			return "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
+			return nil, fmt.Errorf("error loading user-provided template %q: %w", name, err)
 		}
 
 		_, err = utpl.Parse(txt)
 		if err != nil {
-			return "", fmt.Errorf("error parsing user-provided template %q: %w", name, err)
+			return nil, fmt.Errorf("error parsing user-provided template %q: %w", name, err)
 		}
 	}
+	g.templates = t
+
+	return g, nil
+}
+
+// Generate generates the code of the spec, like the Generate function.
+func (g *Generator) Generate(spec *openapi3.T) (string, error) {
+	if !g.options.OutputOptions.Layout.IsZero() {
+		return "", errors.New("the `output-options.layout` writes to multiple files, so requires using `GenerateFiles`")
+	}
 
-	ops, err := OperationDefinitions(spec, opts.OutputOptions.InitialismOverrides)
+	files, err := g.GenerateFiles(spec)
 	if err != nil {
-		return "", fmt.Errorf("error creating operation definitions: %w", err)
+		return "", err
 	}
+	return files[0].Code, nil
+}
+
+// GenerateFiles generates the code of the spec, split into files, like the
+// GenerateFiles function.
+func (g *Generator) GenerateFiles(spec *openapi3.T) ([]GeneratedFile, error) {
+	files, _, err := g.GenerateFilesWithDiagnostics(spec)
+	return files, err
+}
+
+// GenerateFilesWithDiagnostics generates the code of the spec, like
+// GenerateFiles, and returns the Diagnostics of the problems with the spec
+// that were found while generating it, which are returned even when the code
+// can't be generated.
+func (g *Generator) GenerateFilesWithDiagnostics(spec *openapi3.T) ([]GeneratedFile, []Diagnostic, error) {
+	files, diagnostics, err := g.generate(spec)
+	if err != nil {
+		return nil, diagnostics, err
+	}
+	if err := formatFiles(files, g.options); err != nil {
+		return nil, diagnostics, err
+	}
+	return files, diagnostics, nil
+}
+
+// generate generates the unformatted code of the spec.
+func (g *Generator) generate(spec *openapi3.T) ([]GeneratedFile, []Diagnostic, error) {
+	gs := g.newState(spec)
+	files, err := g.generateFiles(gs, spec)
+	return files, gs.diagnostics.list, err
+}
 
-	xGoTypeImports, err := OperationImports(ops)
+// newState returns the state of a generation of the spec.
+func (g *Generator) newState(spec *openapi3.T) *generatorState {
+	gs := g.state
+	gs.spec = spec
+	setPackageState(gs)
+	gs.diagnostics = &diagnostics{spec: spec}
+	return &gs
+}
+
+// templatesFor returns the Generator's templates, whose functions are those of
+// the generation.
+func (g *Generator) templatesFor(gs *generatorState) (*template.Template, error) {
+	t, err := g.templates.Clone()
 	if err != nil {
-		return "", fmt.Errorf("error getting operation imports: %w", err)
+		return nil, fmt.Errorf("error cloning oapi-codegen templates: %w", err)
+	}
+	return t.Funcs(gs.templateFunctions()), nil
+}
+
+// operationDefinitions filters the operations of the spec, and prunes its
+// unused components, as configured, and returns the definitions of its
+// operations, and of the webhooks and callbacks which are generated.
+func (g *Generator) operationDefinitions(gs *generatorState, spec *openapi3.T) (ops, webhookOps, callbackOps []OperationDefinition, err error) {
+	opts := g.options
+
+	filterOperationsByTag(spec, opts)
+	filterOperationsByOperationID(spec, opts)
+	if !opts.OutputOptions.SkipPrune {
+		pruneUnusedComponents(spec)
+	}
+
+	ops, err = gs.operationDefinitions(spec, opts.OutputOptions.InitialismOverrides)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating operation definitions: %w", err)
+	}
+
+	if opts.Generate.Webhooks {
+		webhookOps, err = gs.webhookDefinitions(spec, opts.OutputOptions.InitialismOverrides)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error creating webhook definitions: %w", err)
+		}
+	}
+
+	if opts.Generate.Callbacks {
+		callbackOps, err = gs.callbackDefinitions(spec, ops, opts.OutputOptions.InitialismOverrides)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error creating callback definitions: %w", err)
+		}
+	}
+
+	return ops, webhookOps, callbackOps, nil
+}
+
+// generateFiles generates the unformatted code of the spec, in the generation.
+func (g *Generator) generateFiles(gs *generatorState, spec *openapi3.T) ([]GeneratedFile, error) {
+	opts := g.options
+	t, err := g.templatesFor(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, webhookOps, callbackOps, err := g.operationDefinitions(gs, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The types for the parameters and bodies of webhooks and callbacks are
+	// generated alongside those of the paths
+	modelOps := append(append(append([]OperationDefinition{}, ops...), webhookOps...), callbackOps...)
+
+	xGoTypeImports, err := OperationImports(modelOps)
+	if err != nil {
+		return nil, fmt.Errorf("error getting operation imports: %w", err)
 	}
 
 	var typeDefinitions, constantDefinitions string
 	if opts.Generate.Models {
-		typeDefinitions, err = GenerateTypeDefinitions(t, spec, ops, opts.OutputOptions.ExcludeSchemas)
+		typeDefinitions, err = gs.generateTypeDefinitions(t, spec, modelOps, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
-			return "", fmt.Errorf("error generating type definitions: %w", err)
+			return nil, fmt.Errorf("error generating type definitions: %w", err)
 		}
 
-		constantDefinitions, err = GenerateConstants(t, ops)
+		constantDefinitions, err = GenerateConstants(t, modelOps)
 		if err != nil {
-			return "", fmt.Errorf("error generating constants: %w", err)
+			return nil, fmt.Errorf("error generating constants: %w", err)
 		}
 
 		imprts, err := GetTypeDefinitionsImports(spec, opts.OutputOptions.ExcludeSchemas)
 		if err != nil {
-			return "", fmt.Errorf("error getting type definition imports: %w", err)
+			return nil, fmt.Errorf("error getting type definition imports: %w", err)
 		}
 		MergeImports(xGoTypeImports, imprts)
 	}
 
 	var serverURLsDefinitions string
 	if opts.Generate.ServerURLs {
-		serverURLsDefinitions, err = GenerateServerURLs(t, spec)
+		serverURLsDefinitions, err = gs.generateServerURLs(t, spec)
 		if err != nil {
-			return "", fmt.Errorf("error generating Server URLs: %w", err)
+			return nil, fmt.Errorf("error generating Server URLs: %w", err)
 		}
 	}
 
@@ -214,7 +453,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.IrisServer {
 		irisServerOut, err = GenerateIrisServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -222,7 +461,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.EchoServer {
 		echoServerOut, err = GenerateEchoServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -230,7 +469,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.ChiServer {
 		chiServerOut, err = GenerateChiServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -238,7 +477,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.FiberServer {
 		fiberServerOut, err = GenerateFiberServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -246,7 +485,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.GinServer {
 		ginServerOut, err = GenerateGinServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -254,7 +493,7 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.GorillaServer {
 		gorillaServerOut, err = GenerateGorillaServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -262,7 +501,15 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.StdHTTPServer {
 		stdHTTPServerOut, err = GenerateStdHTTPServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
+		}
+	}
+
+	var serverSecurityOut string
+	if opts.OutputOptions.ServerSecurity {
+		serverSecurityOut, err = GenerateServerSecurity(t, spec, ops)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the server's security handler: %w", err)
 		}
 	}
 
@@ -270,201 +517,172 @@ func Generate(spec *openapi3.T, opts Configuration) (string, error) {
 	if opts.Generate.Strict {
 		var responses []ResponseDefinition
 		if spec.Components != nil {
-			responses, err = GenerateResponseDefinitions("", spec.Components.Responses)
+			responses, err = gs.generateResponseDefinitions("", spec.Components.Responses)
 			if err != nil {
-				return "", fmt.Errorf("error generation response definitions for schema: %w", err)
+				return nil, fmt.Errorf("error generation response definitions for schema: %w", err)
 			}
 		}
 		strictServerResponses, err := GenerateStrictResponses(t, responses)
 		if err != nil {
-			return "", fmt.Errorf("error generation response definitions for schema: %w", err)
+			return nil, fmt.Errorf("error generation response definitions for schema: %w", err)
 		}
 		strictServerOut, err = GenerateStrictServer(t, ops, opts)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 		strictServerOut = strictServerResponses + strictServerOut
-	}
 
-	var clientOut string
-	if opts.Generate.Client {
-		clientOut, err = GenerateClient(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating client: %w", err)
-		}
-	}
+		if opts.OutputOptions.StrictServerRequestValidation != StrictServerRequestValidationNone {
+			var types []TypeDefinition
+			if opts.OutputOptions.StrictServerRequestValidation == StrictServerRequestValidationGenerated {
+				types, err = gs.generateComponentTypeDefinitions(t, spec, opts.OutputOptions.ExcludeSchemas)
+				if err != nil {
+					return nil, fmt.Errorf("error generating type definitions for request validation: %w", err)
+				}
+				types = append(types, validationOperationTypes(ops)...)
+			}
 
-	var clientWithResponsesOut string
-	if opts.Generate.Client {
-		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating client with responses: %w", err)
+			strictRequestValidation, err := GenerateStrictRequestValidation(t, types, ops, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error generating request validation for the strict server: %w", err)
+			}
+			strictServerOut += strictRequestValidation
 		}
-	}
 
-	var inlinedSpec string
-	if opts.Generate.EmbeddedSpec {
-		inlinedSpec, err = GenerateInlinedSpec(t, globalState.importMapping, spec)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+		if opts.Generate.MockServer {
+			mockServer, err := GenerateMockServer(t, ops)
+			if err != nil {
+				return nil, fmt.Errorf("error generating the mock server: %w", err)
+			}
+			strictServerOut += mockServer
 		}
 	}
 
-	var buf bytes.Buffer
-	w := bufio.NewWriter(&buf)
-
-	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
-	importsOut, err := GenerateImports(
-		t,
-		externalImports,
-		opts.PackageName,
-		opts.NoVCSVersionOverride,
-	)
-	if err != nil {
-		return "", fmt.Errorf("error generating imports: %w", err)
-	}
-
-	_, err = w.WriteString(importsOut)
-	if err != nil {
-		return "", fmt.Errorf("error writing imports: %w", err)
-	}
-
-	_, err = w.WriteString(constantDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing constants: %w", err)
-	}
-
-	_, err = w.WriteString(serverURLsDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing Server URLs: %w", err)
-	}
-
-	_, err = w.WriteString(typeDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing type definitions: %w", err)
-	}
-
-	if opts.Generate.Client {
-		_, err = w.WriteString(clientOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
-		}
-		_, err = w.WriteString(clientWithResponsesOut)
+	var webhooksOut string
+	if opts.Generate.Webhooks {
+		webhooksOut, err = GenerateWebhooks(t, webhookOps, opts)
 		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
+			return nil, fmt.Errorf("error generating webhooks: %w", err)
 		}
 	}
 
-	if opts.Generate.IrisServer {
-		_, err = w.WriteString(irisServerOut)
+	var callbacksOut string
+	if opts.Generate.Callbacks {
+		callbacksOut, err = GenerateCallbacks(t, callbackOps, opts)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating callbacks: %w", err)
 		}
-
 	}
 
-	if opts.Generate.EchoServer {
-		_, err = w.WriteString(echoServerOut)
+	var clientOut string
+	if opts.Generate.Client {
+		clientOut, err = GenerateClient(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating client: %w", err)
 		}
 	}
 
-	if opts.Generate.ChiServer {
-		_, err = w.WriteString(chiServerOut)
+	var clientWithResponsesOut string
+	if opts.Generate.Client {
+		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating client with responses: %w", err)
 		}
-	}
 
-	if opts.Generate.FiberServer {
-		_, err = w.WriteString(fiberServerOut)
+		pagination, err := GeneratePagination(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating pagination for client with responses: %w", err)
 		}
-	}
+		clientWithResponsesOut += pagination
 
-	if opts.Generate.GinServer {
-		_, err = w.WriteString(ginServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+		if opts.OutputOptions.ClientTypedMethods {
+			typed, err := gs.generateTypedClient(t, ops)
+			if err != nil {
+				return nil, fmt.Errorf("error generating typed methods for client with responses: %w", err)
+			}
+			clientWithResponsesOut += typed
 		}
 	}
 
-	if opts.Generate.GorillaServer {
-		_, err = w.WriteString(gorillaServerOut)
+	var clientStreamsOut string
+	if opts.Generate.Client {
+		clientStreamsOut, err = GenerateClientStreams(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating client streams: %w", err)
 		}
 	}
 
-	if opts.Generate.StdHTTPServer {
-		_, err = w.WriteString(stdHTTPServerOut)
+	// the client and strict server share the encoding and decoding of
+	// streams, unless they're generated in separate packages
+	var clientStreamEncodingOut, strictServerStreamEncodingOut string
+	if opts.Generate.Client || opts.Generate.Strict {
+		streamEncodingOut, err := GenerateStreams(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating the encoding of streams: %w", err)
+		}
+		if opts.Generate.Client {
+			clientStreamEncodingOut = streamEncodingOut
+		}
+		if opts.Generate.Strict && (!opts.Generate.Client || outputPackageName(opts, outputPartClient) != outputPackageName(opts, outputPartStrictServer)) {
+			strictServerStreamEncodingOut = streamEncodingOut
 		}
 	}
 
-	if opts.Generate.Strict {
-		_, err = w.WriteString(strictServerOut)
+	var fakeClientOut string
+	if opts.Generate.FakeClient {
+		fakeClientOut, err = GenerateFakeClient(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating fake client: %w", err)
 		}
 	}
 
+	var inlinedSpec string
 	if opts.Generate.EmbeddedSpec {
-		_, err = w.WriteString(inlinedSpec)
+		inlinedSpec, err = GenerateInlinedSpec(t, gs.importMapping, spec)
 		if err != nil {
-			return "", fmt.Errorf("error writing inlined spec: %w", err)
-		}
-	}
-
-	err = w.Flush()
-	if err != nil {
-		return "", fmt.Errorf("error flushing output buffer: %w", err)
-	}
-
-	// remove any byte-order-marks which break Go-Code
-	goCode := SanitizeCode(buf.String())
-
-	// The generation code produces unindented horrors. Use the Go Imports
-	// to make it all pretty.
-	if opts.OutputOptions.SkipFmt {
-		return goCode, nil
-	}
-
-	outBytes, err := imports.Process(opts.PackageName+".go", []byte(goCode), nil)
-	if err != nil {
-		return "", fmt.Errorf("error formatting Go code %s: %w", goCode, err)
-	}
-	return string(outBytes), nil
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
+		}
+	}
+
+	externalImports := append(gs.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
+
+	sections := []outputSection{
+		{outputPartModels, constantDefinitions},
+		{outputPartModels, serverURLsDefinitions},
+		{outputPartModels, typeDefinitions},
+		{outputPartClient, clientOut},
+		{outputPartClient, clientWithResponsesOut},
+		{outputPartClient, clientStreamsOut},
+		{outputPartClient, clientStreamEncodingOut},
+		{outputPartClient, fakeClientOut},
+		{outputPartServer, irisServerOut},
+		{outputPartServer, echoServerOut},
+		{outputPartServer, chiServerOut},
+		{outputPartServer, fiberServerOut},
+		{outputPartServer, ginServerOut},
+		{outputPartServer, gorillaServerOut},
+		{outputPartServer, stdHTTPServerOut},
+		{outputPartServer, serverSecurityOut},
+		{outputPartStrictServer, strictServerOut},
+		{outputPartStrictServer, strictServerStreamEncodingOut},
+		{outputPartMain, webhooksOut},
+		{outputPartMain, callbacksOut},
+		{outputPartEmbeddedSpec, inlinedSpec},
+	}
+
+	return gs.layoutFiles(t, opts, externalImports, sections)
 }
 
 func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
-	var allTypes []TypeDefinition
-	if swagger.Components != nil {
-		schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component schemas: %w", err)
-		}
-
-		paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component parameters: %w", err)
-		}
-		allTypes = append(schemaTypes, paramTypes...)
-
-		responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component responses: %w", err)
-		}
-		allTypes = append(allTypes, responseTypes...)
+	gs := packageGeneratorState()
+	gs.spec = swagger
+	return gs.generateTypeDefinitions(t, swagger, ops, excludeSchemas)
+}
 
-		bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go types for component request bodies: %w", err)
-		}
-		allTypes = append(allTypes, bodyTypes...)
+func (gs *generatorState) generateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
+	allTypes, err := gs.generateComponentTypeDefinitions(t, swagger, excludeSchemas)
+	if err != nil {
+		return "", err
 	}
 
 	// Go through all operations, and add their types to allTypes, so that we can
@@ -481,7 +699,7 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []Op
 		return "", fmt.Errorf("error generating Go types for component request bodies: %w", err)
 	}
 
-	enumsOut, err := GenerateEnums(t, enumTypes)
+	enumsOut, err := gs.generateEnums(t, enumTypes)
 	if err != nil {
 		return "", fmt.Errorf("error generating code for type enums: %w", err)
 	}
@@ -506,10 +724,65 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []Op
 		return "", fmt.Errorf("error generating boilerplate for union types with additionalProperties: %w", err)
 	}
 
-	typeDefinitions := strings.Join([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate}, "")
+	var validationOut string
+	if gs.options.Generate.Validation {
+		validationOut, err = GenerateValidation(t, append(allTypes, validationOperationTypes(ops)...))
+		if err != nil {
+			return "", fmt.Errorf("error generating validation for type definitions: %w", err)
+		}
+		// the request validation of the strict server returns the
+		// ValidationErrors, and uses their helpers, even when none of the
+		// types have constraints to validate
+		if validationOut == "" && gs.options.OutputOptions.StrictServerRequestValidation != StrictServerRequestValidationNone {
+			validationOut, err = GenerateTemplates([]string{"validation.tmpl"}, t, struct{ Types []ValidationDefinition }{})
+			if err != nil {
+				return "", fmt.Errorf("error generating validation errors: %w", err)
+			}
+		}
+	}
+
+	typeDefinitions := strings.Join([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate, validationOut}, "")
 	return typeDefinitions, nil
 }
 
+// GenerateComponentTypeDefinitions returns the type definitions for the
+// schemas, parameters, responses and request bodies of the specification's
+// components.
+func GenerateComponentTypeDefinitions(t *template.Template, swagger *openapi3.T, excludeSchemas []string) ([]TypeDefinition, error) {
+	gs := packageGeneratorState()
+	gs.spec = swagger
+	return gs.generateComponentTypeDefinitions(t, swagger, excludeSchemas)
+}
+
+func (gs *generatorState) generateComponentTypeDefinitions(t *template.Template, swagger *openapi3.T, excludeSchemas []string) ([]TypeDefinition, error) {
+	var allTypes []TypeDefinition
+	if swagger.Components != nil {
+		schemaTypes, err := gs.generateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component schemas: %w", err)
+		}
+
+		paramTypes, err := gs.generateTypesForParameters(t, swagger.Components.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component parameters: %w", err)
+		}
+		allTypes = append(schemaTypes, paramTypes...)
+
+		responseTypes, err := gs.generateTypesForResponses(t, swagger.Components.Responses)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component responses: %w", err)
+		}
+		allTypes = append(allTypes, responseTypes...)
+
+		bodyTypes, err := gs.generateTypesForRequestBodies(t, swagger.Components.RequestBodies)
+		if err != nil {
+			return nil, fmt.Errorf("error generating Go types for component request bodies: %w", err)
+		}
+		allTypes = append(allTypes, bodyTypes...)
+	}
+	return allTypes, nil
+}
+
 // GenerateConstants generates operation ids, context keys, paths, etc. to be exported as constants
 func GenerateConstants(t *template.Template, ops []OperationDefinition) (string, error) {
 	constants := Constants{
@@ -539,6 +812,10 @@ func GenerateConstants(t *template.Template, ops []OperationDefinition) (string,
 // GenerateTypesForSchemas generates type definitions for any custom types defined in the
 // components/schemas section of the Swagger spec.
 func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.SchemaRef, excludeSchemas []string) ([]TypeDefinition, error) {
+	return packageGeneratorState().generateTypesForSchemas(t, schemas, excludeSchemas)
+}
+
+func (gs *generatorState) generateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.SchemaRef, excludeSchemas []string) ([]TypeDefinition, error) {
 	excludeSchemasMap := make(map[string]bool)
 	for _, schema := range excludeSchemas {
 		excludeSchemasMap[schema] = true
@@ -551,12 +828,12 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 		}
 		schemaRef := schemas[schemaName]
 
-		goSchema, err := GenerateGoSchema(schemaRef, []string{schemaName})
+		goSchema, err := gs.generateGoSchema(schemaRef, []string{schemaName})
 		if err != nil {
 			return nil, fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
 		}
 
-		goTypeName, err := renameSchema(schemaName, schemaRef)
+		goTypeName, err := gs.renameSchema(schemaName, schemaRef)
 		if err != nil {
 			return nil, fmt.Errorf("error making name for components/schemas/%s: %w", schemaName, err)
 		}
@@ -575,16 +852,20 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 // GenerateTypesForParameters generates type definitions for any custom types defined in the
 // components/parameters section of the Swagger spec.
 func GenerateTypesForParameters(t *template.Template, params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
+	return packageGeneratorState().generateTypesForParameters(t, params)
+}
+
+func (gs *generatorState) generateTypesForParameters(t *template.Template, params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 	for _, paramName := range SortedMapKeys(params) {
 		paramOrRef := params[paramName]
 
-		goType, err := paramToGoType(paramOrRef.Value, nil)
+		goType, err := gs.paramToGoType(paramOrRef.Value, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err)
 		}
 
-		goTypeName, err := renameParameter(paramName, paramOrRef)
+		goTypeName, err := gs.renameParameter(paramName, paramOrRef)
 		if err != nil {
 			return nil, fmt.Errorf("error making name for components/parameters/%s: %w", paramName, err)
 		}
@@ -597,11 +878,11 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 
 		if paramOrRef.Ref != "" {
 			// Generate a reference type for referenced parameters
-			refType, err := RefPathToGoType(paramOrRef.Ref)
+			refType, err := gs.refPathToGoType(paramOrRef.Ref, true)
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", paramOrRef.Ref, paramName, err)
 			}
-			typeDef.TypeName = SchemaNameToTypeName(refType)
+			typeDef.TypeName = gs.schemaNameToTypeName(refType)
 		}
 
 		types = append(types, typeDef)
@@ -612,6 +893,10 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 // GenerateTypesForResponses generates type definitions for any custom types defined in the
 // components/responses section of the Swagger spec.
 func GenerateTypesForResponses(t *template.Template, responses openapi3.ResponseBodies) ([]TypeDefinition, error) {
+	return packageGeneratorState().generateTypesForResponses(t, responses)
+}
+
+func (gs *generatorState) generateTypesForResponses(t *template.Template, responses openapi3.ResponseBodies) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 
 	for _, responseName := range SortedMapKeys(responses) {
@@ -636,12 +921,12 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 				continue
 			}
 
-			goType, err := GenerateGoSchema(response.Schema, []string{responseName})
+			goType, err := gs.generateGoSchema(response.Schema, []string{responseName})
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for schema in response %s: %w", responseName, err)
 			}
 
-			goTypeName, err := renameResponse(responseName, responseOrRef)
+			goTypeName, err := gs.renameResponse(responseName, responseOrRef)
 			if err != nil {
 				return nil, fmt.Errorf("error making name for components/responses/%s: %w", responseName, err)
 			}
@@ -654,15 +939,15 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 
 			if responseOrRef.Ref != "" {
 				// Generate a reference type for referenced parameters
-				refType, err := RefPathToGoType(responseOrRef.Ref)
+				refType, err := gs.refPathToGoType(responseOrRef.Ref, true)
 				if err != nil {
 					return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", responseOrRef.Ref, responseName, err)
 				}
-				typeDef.TypeName = SchemaNameToTypeName(refType)
+				typeDef.TypeName = gs.schemaNameToTypeName(refType)
 			}
 
 			if jsonCount > 1 {
-				typeDef.TypeName = typeDef.TypeName + mediaTypeToCamelCase(mediaType)
+				typeDef.TypeName = typeDef.TypeName + gs.mediaTypeToCamelCase(mediaType)
 			}
 
 			types = append(types, typeDef)
@@ -674,6 +959,10 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 // GenerateTypesForRequestBodies generates type definitions for any custom types defined in the
 // components/requestBodies section of the Swagger spec.
 func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
+	return packageGeneratorState().generateTypesForRequestBodies(t, bodies)
+}
+
+func (gs *generatorState) generateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 
 	for _, requestBodyName := range SortedMapKeys(bodies) {
@@ -687,12 +976,12 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 				continue
 			}
 
-			goType, err := GenerateGoSchema(body.Schema, []string{requestBodyName})
+			goType, err := gs.generateGoSchema(body.Schema, []string{requestBodyName})
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for schema in body %s: %w", requestBodyName, err)
 			}
 
-			goTypeName, err := renameRequestBody(requestBodyName, requestBodyRef)
+			goTypeName, err := gs.renameRequestBody(requestBodyName, requestBodyRef)
 			if err != nil {
 				return nil, fmt.Errorf("error making name for components/schemas/%s: %w", requestBodyName, err)
 			}
@@ -705,11 +994,11 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 
 			if requestBodyRef.Ref != "" {
 				// Generate a reference type for referenced bodies
-				refType, err := RefPathToGoType(requestBodyRef.Ref)
+				refType, err := gs.refPathToGoType(requestBodyRef.Ref, true)
 				if err != nil {
 					return nil, fmt.Errorf("error generating Go type for (%s) in body %s: %w", requestBodyRef.Ref, requestBodyName, err)
 				}
-				typeDef.TypeName = SchemaNameToTypeName(refType)
+				typeDef.TypeName = gs.schemaNameToTypeName(refType)
 			}
 			types = append(types, typeDef)
 		}
@@ -751,6 +1040,16 @@ func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error)
 }
 
 func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error) {
+	return packageGeneratorState().generateEnums(t, types)
+}
+
+func (gs *generatorState) generateEnums(t *template.Template, types []TypeDefinition) (string, error) {
+	return GenerateTemplates([]string{"constants.tmpl"}, t, Constants{EnumDefinitions: gs.enumDefinitions(types)})
+}
+
+// enumDefinitions returns the enums of the types, whose values are prefixed by
+// their type's name when they'd conflict with another's.
+func (gs *generatorState) enumDefinitions(types []TypeDefinition) []EnumDefinition {
 	enums := []EnumDefinition{}
 
 	// Keep track of which enums we've generated
@@ -773,7 +1072,7 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 				Schema:         tp.Schema,
 				TypeName:       tp.TypeName,
 				ValueWrapper:   wrapper,
-				PrefixTypeName: globalState.options.Compatibility.AlwaysPrefixEnumValues,
+				PrefixTypeName: gs.options.Compatibility.AlwaysPrefixEnumValues,
 			})
 		}
 	}
@@ -823,11 +1122,15 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 
 	// Now see if enums conflict with any non-enum typenames
 
-	return GenerateTemplates([]string{"constants.tmpl"}, t, Constants{EnumDefinitions: enums})
+	return enums
 }
 
 // GenerateImports generates our import statements and package definition.
 func GenerateImports(t *template.Template, externalImports []string, packageName string, versionOverride *string) (string, error) {
+	return packageGeneratorState().generateImports(t, externalImports, packageName, versionOverride)
+}
+
+func (gs *generatorState) generateImports(t *template.Template, externalImports []string, packageName string, versionOverride *string) (string, error) {
 	// Read build version for incorporating into generated files
 	// Unit tests have ok=false, so we'll just use "unknown" for the
 	// version if we can't read this.
@@ -857,7 +1160,7 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		PackageName:       packageName,
 		ModuleName:        modulePath,
 		Version:           moduleVersion,
-		AdditionalImports: globalState.options.AdditionalImports,
+		AdditionalImports: gs.options.AdditionalImports,
 	}
 
 	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
@@ -1211,7 +1514,3 @@ func GetParametersImports(params map[string]*openapi3.ParameterRef) (map[string]
 	}
 	return res, nil
 }
-
-func SetGlobalStateSpec(spec *openapi3.T) {
-	globalState.spec = spec
-}
@@ -3,6 +3,7 @@ package codegen
 import (
 	_ "embed"
 	"go/format"
+	"sync"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -227,5 +228,138 @@ func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error
 `)
 }
 
+func TestExportedHelpersUseTheLatestGeneration(t *testing.T) {
+	t.Cleanup(func() { setPackageState(*newGeneratorState()) })
+
+	loadSpec := func(schema string) *openapi3.T {
+		spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: helpers
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ` + schema + `:
+      type: object
+`))
+		require.NoError(t, err)
+		return spec
+	}
+
+	assert.Equal(t, "UserId", SchemaNameToTypeName("user_id"))
+
+	_, err := Generate(loadSpec("user_id"), Configuration{
+		PackageName: "initialisms",
+		Generate:    GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{
+			NameNormalizer: string(NameNormalizerFunctionToCamelCaseWithInitialisms),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "UserID", SchemaNameToTypeName("user_id"))
+	goType, err := RefPathToGoType("#/components/schemas/user_id")
+	require.NoError(t, err)
+	assert.Equal(t, "UserID", goType)
+
+	// the spec which references are resolved with can be replaced
+	SetGlobalStateSpec(loadSpec("pet_id"))
+	goType, err = RefPathToGoType("#/components/schemas/pet_id")
+	require.NoError(t, err)
+	assert.Equal(t, "PetID", goType)
+}
+
+func TestGeneratorIsSafeForConcurrentUse(t *testing.T) {
+	configurations := []Configuration{
+		{
+			PackageName: "camelcase",
+			Generate: GenerateOptions{
+				Models:     true,
+				Client:     true,
+				EchoServer: true,
+			},
+		},
+		{
+			PackageName: "initialisms",
+			Generate: GenerateOptions{
+				Models:        true,
+				Client:        true,
+				StdHTTPServer: true,
+				Strict:        true,
+			},
+			OutputOptions: OutputOptions{
+				NameNormalizer:     string(NameNormalizerFunctionToCamelCaseWithInitialisms),
+				ResponseTypeSuffix: "Result",
+				ClientTypeName:     "Petstore",
+			},
+		},
+	}
+
+	loadSpec := func() (*openapi3.T, error) {
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		return loader.LoadFromData([]byte(testOpenAPIDefinition))
+	}
+
+	// each configuration is generated on its own first, to have the code that
+	// the concurrent generations must also have
+	var generators []*Generator
+	var expected []string
+	for _, opts := range configurations {
+		g, err := NewGenerator(opts)
+		require.NoError(t, err)
+		generators = append(generators, g)
+
+		spec, err := loadSpec()
+		require.NoError(t, err)
+		code, err := g.Generate(spec)
+		require.NoError(t, err)
+		expected = append(expected, code)
+	}
+	require.Contains(t, expected[1], "type Petstore struct {")
+	require.Contains(t, expected[1], "GetTestByNameResult")
+	require.NotContains(t, expected[0], "GetTestByNameResult")
+
+	// generating code prunes the spec, so each generation has its own
+	specs := make([][]*openapi3.T, len(generators))
+	for i := range generators {
+		for j := 0; j < 4; j++ {
+			spec, err := loadSpec()
+			require.NoError(t, err)
+			specs[i] = append(specs[i], spec)
+		}
+	}
+
+	var wg sync.WaitGroup
+	codes := make([][]string, len(generators))
+	errs := make([][]error, len(generators))
+	for i, g := range generators {
+		codes[i] = make([]string, len(specs[i]))
+		errs[i] = make([]error, len(specs[i]))
+		for j, spec := range specs[i] {
+			wg.Add(1)
+			go func(i, j int, g *Generator, spec *openapi3.T) {
+				defer wg.Done()
+				codes[i][j], errs[i][j] = g.Generate(spec)
+			}(i, j, g, spec)
+		}
+	}
+	wg.Wait()
+
+	for i := range generators {
+		for j := range codes[i] {
+			require.NoError(t, errs[i][j])
+			assert.Equal(t, expected[i], codes[i][j])
+		}
+	}
+
+	// and the state of a generation doesn't remain once it's complete
+	spec, err := loadSpec()
+	require.NoError(t, err)
+	code, err := Generate(spec, configurations[0])
+	require.NoError(t, err)
+	assert.Equal(t, expected[0], code)
+}
+
 //go:embed test_spec.yaml
 var testOpenAPIDefinition string
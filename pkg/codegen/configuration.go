@@ -76,6 +76,34 @@ func (o Configuration) Validate() error {
 		}
 	}
 
+	if o.OutputOptions.Layout.Client != nil && o.OutputOptions.Layout.Client.PackageName != "" && (o.Generate.Webhooks || o.Generate.Callbacks) {
+		errs = append(errs, errors.New("`output-options` configuration for layout.client was incorrect: the client can't be generated in a separate `package` when generating `webhooks` or `callbacks`, which share its types"))
+	}
+
+	if o.OutputOptions.StrictServerRequestValidation != StrictServerRequestValidationNone && !o.Generate.Strict {
+		errs = append(errs, errors.New("`output-options` configuration for strict-server-request-validation was incorrect: validating requests requires `generate.strict-server`"))
+	}
+	switch o.OutputOptions.StrictServerRequestValidation {
+	case StrictServerRequestValidationGenerated:
+		if !o.Generate.Validation {
+			errs = append(errs, errors.New("`output-options` configuration for strict-server-request-validation was incorrect: validating requests with the `generated` validation requires `generate.validation`"))
+		}
+		if o.OutputOptions.Layout.Models != nil && o.OutputOptions.Layout.Models.PackageName != "" {
+			errs = append(errs, errors.New("`output-options` configuration for strict-server-request-validation was incorrect: validating requests with the `generated` validation requires the models to be generated in the same package as the strict server"))
+		}
+	case StrictServerRequestValidationEmbeddedSpec:
+		if !o.Generate.EmbeddedSpec {
+			errs = append(errs, errors.New("`output-options` configuration for strict-server-request-validation was incorrect: validating requests against the `embedded-spec` requires `generate.embedded-spec`"))
+		}
+		if o.OutputOptions.Layout.EmbeddedSpec != nil && o.OutputOptions.Layout.EmbeddedSpec.PackageName != "" {
+			errs = append(errs, errors.New("`output-options` configuration for strict-server-request-validation was incorrect: validating requests against the `embedded-spec` requires it to be generated in the same package as the strict server"))
+		}
+	}
+
+	if o.OutputOptions.ServerSecurity && nServers == 0 {
+		errs = append(errs, errors.New("`output-options` configuration for server-security was incorrect: authenticating requests requires one of the servers to be generated"))
+	}
+
 	if problems := o.OutputOptions.Validate(); problems != nil {
 		for k, v := range problems {
 			errs = append(errs, fmt.Errorf("`output-options` configuration for %v was incorrect: %v", k, v))
@@ -128,10 +156,37 @@ type GenerateOptions struct {
 	EmbeddedSpec bool `yaml:"embedded-spec,omitempty"`
 	// ServerURLs generates types for the `Server` definitions' URLs, instead of needing to provide your own values
 	ServerURLs bool `yaml:"server-urls,omitempty"`
+	// Webhooks generates a receiver-side interface and handlers, as well as a sender-side client, for the OpenAPI 3.1 `webhooks`
+	Webhooks bool `yaml:"webhooks,omitempty"`
+	// Callbacks generates a receiver-side interface and handlers, as well as a sender-side client, for the `callbacks` of each operation
+	Callbacks bool `yaml:"callbacks,omitempty"`
+	// Validation generates a `Validate() error` method for each of the models, which checks the constraints of its schema
+	Validation bool `yaml:"validation,omitempty"`
+	// MockServer generates a `MockServer`, which implements the `StrictServerInterface` by responding with the examples of each operation's responses
+	MockServer bool `yaml:"mock-server,omitempty"`
+	// FakeClient generates a `FakeClientWithResponses`, which implements the `ClientWithResponsesInterface` with a stub for each method, and records their calls, for use in tests
+	FakeClient bool `yaml:"fake-client,omitempty"`
 }
 
 func (oo GenerateOptions) Validate() map[string]string {
-	return nil
+	problems := make(map[string]string)
+
+	if oo.Validation && !oo.Models {
+		problems["validation"] = "The `Validate` methods are generated alongside the models, so `validation` requires `models` to be generated"
+	}
+
+	if oo.MockServer && !oo.Strict {
+		problems["mock-server"] = "The `MockServer` implements the `StrictServerInterface`, so `mock-server` requires `strict-server` to be generated"
+	}
+
+	if oo.FakeClient && !oo.Client {
+		problems["fake-client"] = "The `FakeClientWithResponses` implements the `ClientWithResponsesInterface`, so `fake-client` requires `client` to be generated"
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
 }
 
 func (oo GenerateOptions) Warnings() map[string]string {
@@ -285,9 +340,30 @@ type OutputOptions struct {
 	// EnableYamlTags adds YAML tags to generated structs, in addition to default JSON ones
 	EnableYamlTags bool `yaml:"yaml-tags,omitempty"`
 
+	// EnableXmlTags adds XML tags to generated structs, in addition to default JSON ones, which honour the `xml` object of each schema, for the names of elements and attributes, their namespaces, and whether arrays are wrapped
+	EnableXmlTags bool `yaml:"xml-tags,omitempty"`
+
+	// XMLBodies generates typed request and response bodies for XML media types, such as `application/xml`, `text/xml` and `application/problem+xml`, which are encoded and decoded with `encoding/xml` in the client and strict server, rather than being an `io.Reader`. This also enables `xml-tags`
+	XMLBodies bool `yaml:"xml-bodies,omitempty"`
+
+	// RejectUnknownEnumValues generates an `UnmarshalJSON` method for each enum type, which returns an error when the value isn't one of the enum's values
+	RejectUnknownEnumValues bool `yaml:"reject-unknown-enum-values,omitempty"`
+
 	// ClientResponseBytesFunction decides whether to enable the generation of a `Bytes()` method on response objects for `ClientWithResponses`
 	ClientResponseBytesFunction bool `yaml:"client-response-bytes-function,omitempty"`
 
+	// ClientRetries generates a `RetryPolicy` on the client, and the `WithRetryPolicy` and `WithIdempotencyKeys` `ClientOption`s, to retry requests which are safe to retry, with exponential backoff
+	ClientRetries bool `yaml:"client-retries,omitempty"`
+
+	// ClientTypedMethods generates an `<Op>Typed` method on the `ClientWithResponses` for each operation, which returns the body of a successful response, or an error type for any other response, which can be found with `errors.As`
+	ClientTypedMethods bool `yaml:"client-typed-methods,omitempty"`
+
+	// ClientSecurity generates the `WithSecurityProviders` `ClientOption`, which applies the credentials that each operation's `security` requirements need to its requests, with a provider for each security scheme
+	ClientSecurity bool `yaml:"client-security,omitempty"`
+
+	// ServerSecurity generates a `SecurityHandler` interface, with a method to authenticate the credentials of each security scheme, which the server's wrappers call with the credentials from each request, to meet its operation's `security` requirements before calling the handler
+	ServerSecurity bool `yaml:"server-security,omitempty"`
+
 	// PreferSkipOptionalPointer allows defining at a global level whether to omit the pointer for a type to indicate that the field/type is optional.
 	// This is the same as adding `x-go-type-skip-optional-pointer` to each field (manually, or using an OpenAPI Overlay)
 	PreferSkipOptionalPointer bool `yaml:"prefer-skip-optional-pointer,omitempty"`
@@ -300,8 +376,29 @@ type OutputOptions struct {
 
 	// PreferSkipOptionalPointerOnContainerTypes allows disabling the generation of an "optional pointer" for an optional field that is a container type (such as a slice or a map), which ends up requiring an additional, unnecessary, `... != nil` check
 	PreferSkipOptionalPointerOnContainerTypes bool `yaml:"prefer-skip-optional-pointer-on-container-types,omitempty"`
+
+	// Layout allows writing parts of the generated code to separate files, and optionally separate packages, instead of the main output. Requires using `GenerateFiles`
+	Layout OutputLayoutOptions `yaml:"layout,omitempty"`
+
+	// StrictServerRequestValidation validates the parameters and body of each request to the strict server, before they're passed to the `StrictServerInterface`, passing a `*RequestValidationError` to the `RequestErrorHandlerFunc` when they're invalid. Corresponds with the constants defined for `codegen.StrictServerRequestValidation`
+	StrictServerRequestValidation StrictServerRequestValidation `yaml:"strict-server-request-validation,omitempty"`
 }
 
+// StrictServerRequestValidation is the way in which the strict server
+// validates each request.
+type StrictServerRequestValidation string
+
+const (
+	// StrictServerRequestValidationNone doesn't validate requests
+	StrictServerRequestValidationNone StrictServerRequestValidation = ""
+	// StrictServerRequestValidationGenerated validates requests with the
+	// `Validate` methods that are generated with `generate.validation`
+	StrictServerRequestValidationGenerated StrictServerRequestValidation = "generated"
+	// StrictServerRequestValidationEmbeddedSpec validates requests against the
+	// specification that is generated with `generate.embedded-spec`
+	StrictServerRequestValidationEmbeddedSpec StrictServerRequestValidation = "embedded-spec"
+)
+
 func (oo OutputOptions) Validate() map[string]string {
 	if NameNormalizerFunction(oo.NameNormalizer) != NameNormalizerFunctionToCamelCaseWithInitialisms && len(oo.AdditionalInitialisms) > 0 {
 		return map[string]string{
@@ -309,9 +406,98 @@ func (oo OutputOptions) Validate() map[string]string {
 		}
 	}
 
+	if problems := oo.Layout.Validate(); len(problems) > 0 {
+		return problems
+	}
+
+	switch oo.StrictServerRequestValidation {
+	case StrictServerRequestValidationNone, StrictServerRequestValidationGenerated, StrictServerRequestValidationEmbeddedSpec:
+	default:
+		return map[string]string{
+			"strict-server-request-validation": fmt.Sprintf("%q is not supported, it must be one of %q or %q", oo.StrictServerRequestValidation, StrictServerRequestValidationGenerated, StrictServerRequestValidationEmbeddedSpec),
+		}
+	}
+
 	return nil
 }
 
+// OutputLayoutOptions specifies the separate files that parts of the generated
+// code are written to. Any part that isn't configured is written to the main
+// output.
+type OutputLayoutOptions struct {
+	// Models writes the type definitions, constants and Server URLs to a separate file, and optionally a separate package
+	Models *OutputFileOptions `yaml:"models,omitempty"`
+	// Client writes the client, and client with responses, to a separate file, and optionally a separate package
+	Client *OutputFileOptions `yaml:"client,omitempty"`
+	// Server writes the server boilerplate to a separate file, which must be in the same package as the main output
+	Server *OutputFileOptions `yaml:"server,omitempty"`
+	// StrictServer writes the strict server wrapper to a separate file, which must be in the same package as the main output
+	StrictServer *OutputFileOptions `yaml:"strict-server,omitempty"`
+	// EmbeddedSpec writes the embedded spec to a separate file, and optionally a separate package
+	EmbeddedSpec *OutputFileOptions `yaml:"embedded-spec,omitempty"`
+}
+
+// OutputFileOptions specifies a separate file that generated code is written to.
+type OutputFileOptions struct {
+	// Filename is the path of the file to write the generated code to
+	Filename string `yaml:"filename"`
+	// PackageName is the package to generate the code under, if it differs from the top-level `package`
+	PackageName string `yaml:"package,omitempty"`
+	// ImportPath is the Go import path of the package, which is required for `models` when any generated code is in a different package to it, so that it can be imported
+	ImportPath string `yaml:"import-path,omitempty"`
+}
+
+// IsZero returns whether no separate files have been configured
+func (lo OutputLayoutOptions) IsZero() bool {
+	return lo.Models == nil && lo.Client == nil && lo.Server == nil && lo.StrictServer == nil && lo.EmbeddedSpec == nil
+}
+
+func (lo OutputLayoutOptions) Validate() map[string]string {
+	problems := make(map[string]string)
+
+	files := map[string]*OutputFileOptions{
+		"layout.models":        lo.Models,
+		"layout.client":        lo.Client,
+		"layout.server":        lo.Server,
+		"layout.strict-server": lo.StrictServer,
+		"layout.embedded-spec": lo.EmbeddedSpec,
+	}
+	filenames := make(map[string]string)
+	for _, k := range SortedMapKeys(files) {
+		f := files[k]
+		if f == nil {
+			continue
+		}
+		if f.Filename == "" {
+			problems[k] = "A `filename` must be specified"
+			continue
+		}
+		if other, ok := filenames[f.Filename]; ok {
+			problems[k] = fmt.Sprintf("The `filename` %q is already used by `%s`", f.Filename, other)
+		}
+		filenames[f.Filename] = k
+	}
+
+	if lo.Server != nil && lo.Server.PackageName != "" {
+		problems["layout.server"] = "The server must be generated in the same package as the main output, so `package` can't be specified"
+	}
+	if lo.StrictServer != nil && lo.StrictServer.PackageName != "" {
+		problems["layout.strict-server"] = "The strict server must be generated in the same package as the main output, so `package` can't be specified"
+	}
+
+	if lo.Models != nil && lo.Models.PackageName != "" && lo.Models.ImportPath == "" {
+		problems["layout.models"] = "When the models are generated in a separate `package`, its `import-path` must be specified"
+	}
+	if lo.Client != nil && lo.Client.PackageName != "" && (lo.Models == nil || lo.Models.ImportPath == "") {
+		problems["layout.client"] = "When the client is generated in a separate `package`, the models must be written to a separate file, with its `import-path` specified, so that the client can import them"
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
 type OutputOptionsOverlay struct {
 	Path string `yaml:"path"`
 
@@ -0,0 +1,326 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// The rules of the Diagnostics, which identify the kind of problem that each
+// of them is.
+const (
+	// DiagnosticUntypedSchema is a schema without a `type`, or any properties,
+	// which is generated as `interface{}`.
+	DiagnosticUntypedSchema = "untyped-schema"
+	// DiagnosticArrayWithoutItems is an array without `items`, whose items are
+	// generated as `interface{}`.
+	DiagnosticArrayWithoutItems = "array-without-items"
+	// DiagnosticDiscriminatorMappingUnmatched is a `discriminator.mapping` to a
+	// schema which isn't one of the union's, so the mapping is ignored.
+	DiagnosticDiscriminatorMappingUnmatched = "discriminator-mapping-unmatched"
+	// DiagnosticDiscriminatorPropertyMissing is a schema of a union with a
+	// `discriminator` which doesn't have the discriminator's property.
+	DiagnosticDiscriminatorPropertyMissing = "discriminator-property-missing"
+	// DiagnosticUnsupportedContentType is a request or response body whose
+	// media type isn't supported, so its schema is skipped, and the body is
+	// read and written as bytes.
+	DiagnosticUnsupportedContentType = "unsupported-content-type"
+	// DiagnosticTypedClientSkipped is an operation whose successful responses
+	// have bodies of different types, so its client has no `<Op>Typed`
+	// methods, as there's no single type for them to return.
+	DiagnosticTypedClientSkipped = "typed-client-skipped"
+)
+
+// Diagnostic is a warning about a problem with the spec, which was found while
+// generating its code. It didn't prevent the code from being generated, but
+// the code may not be what was intended.
+type Diagnostic struct {
+	// Rule identifies the kind of problem, such as DiagnosticUntypedSchema.
+	Rule string `json:"rule"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// Pointer is a JSON pointer to the problem in the spec, in the same form
+	// as a `$ref`, such as `#/components/schemas/Pet/properties/tag`, which
+	// is prefixed by the file of an external reference. It's empty when the
+	// problem is somewhere which can't be pointed to.
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// String describes the Diagnostic on a single line.
+func (d Diagnostic) String() string {
+	if d.Pointer == "" {
+		return fmt.Sprintf("%s (%s)", d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%s: %s (%s)", d.Pointer, d.Message, d.Rule)
+}
+
+// diagnostics collects the Diagnostics of a generation of the spec.
+type diagnostics struct {
+	spec *openapi3.T
+	list []Diagnostic
+	seen map[Diagnostic]bool
+	// pointers are the JSON pointers of the parts of the spec which the
+	// Diagnostics refer to, which are only indexed once there's a problem
+	pointers specPointers
+}
+
+// add adds a Diagnostic, unless it's already been added, as some schemas are
+// generated more than once. The Diagnostics of helpers which are called
+// outside of a generation, whose diagnostics are nil, are dropped.
+func (d *diagnostics) add(rule string, pointer string, format string, args ...interface{}) {
+	if d == nil {
+		return
+	}
+	diagnostic := Diagnostic{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+		Pointer: pointer,
+	}
+	if d.seen[diagnostic] {
+		return
+	}
+	if d.seen == nil {
+		d.seen = map[Diagnostic]bool{}
+	}
+	d.seen[diagnostic] = true
+	d.list = append(d.list, diagnostic)
+}
+
+// pointerOf returns the JSON pointer of a part of the spec, such as a
+// *openapi3.Schema, or an empty string when it's not part of the spec.
+func (d *diagnostics) pointerOf(v interface{}) string {
+	if d == nil {
+		return ""
+	}
+	return d.index()[v]
+}
+
+// index returns the JSON pointers of the parts of the spec, which are indexed
+// the first time that they're needed.
+func (d *diagnostics) index() specPointers {
+	if d.pointers == nil {
+		d.pointers = specPointers{}
+		d.pointers.spec(d.spec)
+	}
+	return d.pointers
+}
+
+// addDiagnostic adds a Diagnostic to those of the generation.
+func (gs *generatorState) addDiagnostic(rule string, pointer string, format string, args ...interface{}) {
+	gs.diagnostics.add(rule, pointer, format, args...)
+}
+
+// pointerOf returns the JSON pointer of a part of the spec of the generation.
+func (gs *generatorState) pointerOf(v interface{}) string {
+	return gs.diagnostics.pointerOf(v)
+}
+
+// diagnoseUnsupportedContentType adds a Diagnostic when the schema of a request
+// or response body, whose media type isn't supported, is skipped, unless it's
+// a string, or untyped, which the body's bytes already are.
+func (gs *generatorState) diagnoseUnsupportedContentType(content *openapi3.MediaType, contentType string, kind string) {
+	if content == nil || content.Schema == nil || content.Schema.Value == nil {
+		return
+	}
+	schema := content.Schema.Value
+	if schema.Type.Is("string") ||
+		(schema.Type.Slice() == nil && len(schema.Properties) == 0 && schema.AllOf == nil && schema.AnyOf == nil && schema.OneOf == nil) {
+		return
+	}
+
+	var hint string
+	if util.IsMediaTypeXml(contentType) {
+		hint = ", unless the `output-options.xml-bodies` are enabled"
+	}
+	gs.addDiagnostic(DiagnosticUnsupportedContentType, gs.pointerOf(content),
+		"the schema of the %s %s body is skipped, as its media type isn't supported, so the body is read and written as bytes%s", contentType, kind, hint)
+}
+
+// specPointers maps the parts of a spec, which are *openapi3.Schema,
+// *openapi3.RequestBody, *openapi3.Response and *openapi3.MediaType, to their
+// JSON pointers. A part which is referred to from several places has the
+// pointer of where it's defined.
+type specPointers map[interface{}]string
+
+// appendPointer appends the tokens to a JSON pointer, escaping each of them.
+func appendPointer(pointer string, tokens ...string) string {
+	for _, token := range tokens {
+		pointer += "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+	}
+	return pointer
+}
+
+func (p specPointers) spec(spec *openapi3.T) {
+	if spec == nil {
+		return
+	}
+
+	// the components are indexed first, so that each of them has the pointer
+	// of its definition, rather than of where it's referred to
+	if components := spec.Components; components != nil {
+		for _, name := range SortedMapKeys(components.Schemas) {
+			p.schema(components.Schemas[name], appendPointer("#/components/schemas", name))
+		}
+		for _, name := range SortedMapKeys(components.Parameters) {
+			if param := components.Parameters[name]; param != nil {
+				p.parameter(param.Value, appendPointer("#/components/parameters", name))
+			}
+		}
+		for _, name := range SortedMapKeys(components.Headers) {
+			if header := components.Headers[name]; header != nil && header.Value != nil {
+				p.parameter(&header.Value.Parameter, appendPointer("#/components/headers", name))
+			}
+		}
+		for _, name := range SortedMapKeys(components.RequestBodies) {
+			if body := components.RequestBodies[name]; body != nil {
+				p.requestBody(body.Value, appendPointer("#/components/requestBodies", name))
+			}
+		}
+		for _, name := range SortedMapKeys(components.Responses) {
+			if response := components.Responses[name]; response != nil {
+				p.response(response.Value, appendPointer("#/components/responses", name))
+			}
+		}
+	}
+
+	if spec.Paths != nil {
+		for _, path := range SortedMapKeys(spec.Paths.Map()) {
+			p.pathItem(spec.Paths.Value(path), appendPointer("#/paths", path))
+		}
+	}
+}
+
+// webhooks indexes the PathItems of the `webhooks`, which are decoded from the
+// spec's extensions, rather than being part of it.
+func (p specPointers) webhooks(webhooks map[string]*openapi3.PathItem) {
+	for _, name := range SortedMapKeys(webhooks) {
+		p.pathItem(webhooks[name], appendPointer("#/webhooks", name))
+	}
+}
+
+func (p specPointers) pathItem(pathItem *openapi3.PathItem, pointer string) {
+	if pathItem == nil {
+		return
+	}
+	for i, param := range pathItem.Parameters {
+		if param != nil {
+			p.parameter(param.Value, appendPointer(pointer, "parameters", fmt.Sprint(i)))
+		}
+	}
+	operations := pathItem.Operations()
+	for _, method := range SortedMapKeys(operations) {
+		p.operation(operations[method], appendPointer(pointer, strings.ToLower(method)))
+	}
+}
+
+func (p specPointers) operation(op *openapi3.Operation, pointer string) {
+	if op == nil {
+		return
+	}
+	for i, param := range op.Parameters {
+		if param != nil {
+			p.parameter(param.Value, appendPointer(pointer, "parameters", fmt.Sprint(i)))
+		}
+	}
+	if op.RequestBody != nil {
+		p.requestBody(op.RequestBody.Value, appendPointer(pointer, "requestBody"))
+	}
+	if op.Responses != nil {
+		for _, status := range SortedMapKeys(op.Responses.Map()) {
+			if response := op.Responses.Value(status); response != nil {
+				p.response(response.Value, appendPointer(pointer, "responses", status))
+			}
+		}
+	}
+	for _, name := range SortedMapKeys(op.Callbacks) {
+		callback := op.Callbacks[name]
+		if callback == nil || callback.Value == nil {
+			continue
+		}
+		for _, expression := range SortedMapKeys(callback.Value.Map()) {
+			p.pathItem(callback.Value.Value(expression), appendPointer(pointer, "callbacks", name, expression))
+		}
+	}
+}
+
+func (p specPointers) parameter(param *openapi3.Parameter, pointer string) {
+	if param == nil {
+		return
+	}
+	p.schema(param.Schema, appendPointer(pointer, "schema"))
+	p.content(param.Content, appendPointer(pointer, "content"))
+}
+
+func (p specPointers) requestBody(body *openapi3.RequestBody, pointer string) {
+	if body == nil || p.has(body) {
+		return
+	}
+	p[body] = pointer
+	p.content(body.Content, appendPointer(pointer, "content"))
+}
+
+func (p specPointers) response(response *openapi3.Response, pointer string) {
+	if response == nil || p.has(response) {
+		return
+	}
+	p[response] = pointer
+	for _, name := range SortedMapKeys(response.Headers) {
+		if header := response.Headers[name]; header != nil && header.Value != nil {
+			p.parameter(&header.Value.Parameter, appendPointer(pointer, "headers", name))
+		}
+	}
+	p.content(response.Content, appendPointer(pointer, "content"))
+}
+
+func (p specPointers) content(content openapi3.Content, pointer string) {
+	for _, contentType := range SortedMapKeys(content) {
+		mediaType := content[contentType]
+		if mediaType == nil || p.has(mediaType) {
+			continue
+		}
+		mediaTypePointer := appendPointer(pointer, contentType)
+		p[mediaType] = mediaTypePointer
+		p.schema(mediaType.Schema, appendPointer(mediaTypePointer, "schema"))
+	}
+}
+
+func (p specPointers) schema(sref *openapi3.SchemaRef, pointer string) {
+	if sref == nil || sref.Value == nil {
+		return
+	}
+	// a reference points to where the schema is defined
+	if sref.Ref != "" {
+		pointer = sref.Ref
+	}
+	schema := sref.Value
+	if p.has(schema) {
+		return
+	}
+	p[schema] = pointer
+
+	for _, name := range SortedMapKeys(schema.Properties) {
+		p.schema(schema.Properties[name], appendPointer(pointer, "properties", name))
+	}
+	p.schema(schema.Items, appendPointer(pointer, "items"))
+	p.schema(schema.AdditionalProperties.Schema, appendPointer(pointer, "additionalProperties"))
+	p.schema(schema.Not, appendPointer(pointer, "not"))
+	for i, element := range schema.AllOf {
+		p.schema(element, appendPointer(pointer, "allOf", fmt.Sprint(i)))
+	}
+	for i, element := range schema.AnyOf {
+		p.schema(element, appendPointer(pointer, "anyOf", fmt.Sprint(i)))
+	}
+	for i, element := range schema.OneOf {
+		p.schema(element, appendPointer(pointer, "oneOf", fmt.Sprint(i)))
+	}
+}
+
+// has returns whether a part of the spec has been indexed, which stops the
+// index from recursing through circular references.
+func (p specPointers) has(v interface{}) bool {
+	_, ok := p[v]
+	return ok
+}
@@ -0,0 +1,162 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/imports"
+)
+
+const diagnosticsOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Diagnostics
+paths:
+  /animals:
+    get:
+      operationId: listAnimals
+      responses:
+        '200':
+          description: The animals
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Animal'
+  /pets/{id}:
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/octet-stream:
+            schema:
+              type: string
+              format: binary
+      responses:
+        '200':
+          description: The pet
+          content:
+            text/csv:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        extra: {}
+        tags:
+          type: array
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+    Dog:
+      type: object
+      properties:
+        breed:
+          type: string
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: '#/components/schemas/Cat'
+          dog: '#/components/schemas/Dog'
+          bird: '#/components/schemas/Bird'
+`
+
+func TestDiagnostics(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(diagnosticsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	g, err := NewGenerator(Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+		},
+	})
+	require.NoError(t, err)
+
+	files, diagnostics, err := g.GenerateFilesWithDiagnostics(spec)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.ElementsMatch(t, []Diagnostic{
+		{
+			Rule:    DiagnosticUntypedSchema,
+			Message: "the schema has no `type`, so it's generated as `interface{}`",
+			Pointer: "#/components/schemas/Pet/properties/extra",
+		},
+		{
+			Rule:    DiagnosticArrayWithoutItems,
+			Message: "the array has no `items`, so its items are generated as `interface{}`",
+			Pointer: "#/components/schemas/Pet/properties/tags",
+		},
+		{
+			Rule:    DiagnosticDiscriminatorPropertyMissing,
+			Message: `the schema doesn't have the "kind" property of the discriminator of its union, so its value can't be told apart from the others`,
+			Pointer: "#/components/schemas/Dog",
+		},
+		{
+			Rule:    DiagnosticDiscriminatorMappingUnmatched,
+			Message: `the discriminator maps "bird" to #/components/schemas/Bird, which isn't one of the schemas of its union, so the mapping is ignored`,
+			Pointer: "#/components/schemas/Animal/discriminator/mapping/bird",
+		},
+		{
+			Rule:    DiagnosticUnsupportedContentType,
+			Message: "the schema of the application/xml request body is skipped, as its media type isn't supported, so the body is read and written as bytes, unless the `output-options.xml-bodies` are enabled",
+			Pointer: "#/paths/~1pets~1{id}/put/requestBody/content/application~1xml",
+		},
+		{
+			Rule:    DiagnosticUnsupportedContentType,
+			Message: "the schema of the text/csv response body is skipped, as its media type isn't supported, so the body is read and written as bytes",
+			Pointer: "#/paths/~1pets~1{id}/put/responses/200/content/text~1csv",
+		},
+	}, diagnostics)
+
+	// each generation has diagnostics of its own
+	spec, err = openapi3.NewLoader().LoadFromData([]byte(xmlOpenAPIDefinition))
+	require.NoError(t, err)
+	_, diagnostics, err = g.GenerateFilesWithDiagnostics(spec)
+	require.NoError(t, err)
+	assert.Len(t, diagnostics, 4)
+}
+
+func TestDiagnosticString(t *testing.T) {
+	assert.Equal(t, "#/components/schemas/Pet: the schema has no `type`, so it's generated as `interface{}` (untyped-schema)", Diagnostic{
+		Rule:    DiagnosticUntypedSchema,
+		Message: "the schema has no `type`, so it's generated as `interface{}`",
+		Pointer: "#/components/schemas/Pet",
+	}.String())
+}
+
+func TestCodeAroundError(t *testing.T) {
+	code := "package api\n\n// 3\n// 4\n// 5\n// 6\n// 7\n// 8\nfunc A( {\n}\n// 11\n// 12\n// 13\n// 14\n// 15\n// 16\n"
+	_, err := imports.Process("api.go", []byte(code), nil)
+	require.Error(t, err)
+
+	assert.Equal(t, "    4\t// 4\n    5\t// 5\n    6\t// 6\n    7\t// 7\n    8\t// 8\n    9\tfunc A( {\n   10\t}\n   11\t// 11\n   12\t// 12\n   13\t// 13\n   14\t// 14\n", codeAroundError(code, err))
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const enumsOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Enums
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum:
+        - available
+        - sold
+    Size:
+      type: integer
+      enum:
+        - 1
+        - 2
+`
+
+func TestGenerateEnumHelpers(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(enumsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "func (e Status) Valid() bool {")
+	assert.Contains(t, code, "case Available, Sold:")
+	assert.Contains(t, code, "func AllStatusValues() []Status {")
+	assert.Contains(t, code, "func ParseStatus(s string) (Status, error) {")
+	assert.Contains(t, code, "func (e Size) Valid() bool {")
+	assert.Contains(t, code, "func ParseSize(s string) (Size, error) {")
+	assert.Contains(t, code, `case "1":`)
+	assert.NotContains(t, code, "UnmarshalJSON")
+
+	t.Run("rejecting unknown values", func(t *testing.T) {
+		opts.OutputOptions.RejectUnknownEnumValues = true
+
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+
+		_, err = format.Source([]byte(code))
+		assert.NoError(t, err)
+
+		assert.Contains(t, code, "func (e *Status) UnmarshalJSON(data []byte) error {")
+		assert.Contains(t, code, "var value string")
+		assert.Contains(t, code, "func (e *Size) UnmarshalJSON(data []byte) error {")
+		assert.Contains(t, code, "var value int")
+	})
+}
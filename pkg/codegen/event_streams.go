@@ -0,0 +1,55 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// contentTypeEventStream is the media type of Server-Sent Events, whose
+// schema describes the data of each event.
+const contentTypeEventStream = "text/event-stream"
+
+// hasEventStreams returns whether any of the operations' responses are
+// streams of Server-Sent Events.
+func hasEventStreams(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		for _, response := range op.Responses {
+			for _, content := range response.Contents {
+				if content.IsEventStream() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// genResponseEventStreams generates the steps which return the reader of a
+// response's Server-Sent Events, before its body would otherwise be read, as
+// the events are read while they're sent.
+func (gs *generatorState) genResponseEventStreams(op *OperationDefinition) string {
+	typeDefinitions, err := gs.responseTypeDefinitions(op)
+	if err != nil {
+		panic(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	for _, typeDefinition := range typeDefinitions {
+		if !typeDefinition.IsEventStream() {
+			continue
+		}
+		if buffer.Len() == 0 {
+			fmt.Fprintf(buffer, "mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get(\"Content-Type\"))\n")
+		}
+		fmt.Fprintf(buffer, "if mediaType == \"%s\" && %s {\n", contentTypeEventStream, getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName))
+		fmt.Fprintf(buffer, "return &%s{\n", gs.genResponseTypeName(op.OperationId))
+		fmt.Fprintf(buffer, "HTTPResponse: rsp,\n")
+		fmt.Fprintf(buffer, "%s: newServerSentEventReader[%s](rsp.Body),\n", typeDefinition.TypeName, typeDefinition.Schema.TypeDecl())
+		fmt.Fprintf(buffer, "}, nil\n")
+		fmt.Fprintf(buffer, "}\n")
+	}
+	if buffer.Len() != 0 {
+		fmt.Fprintf(buffer, "\n")
+	}
+	return buffer.String()
+}
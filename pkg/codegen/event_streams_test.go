@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventStreamsOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Server-Sent Events
+paths:
+  /notifications:
+    get:
+      operationId: watchNotifications
+      responses:
+        '200':
+          description: The notifications, as they're sent
+          headers:
+            X-Stream-Id:
+              schema:
+                type: string
+          content:
+            text/event-stream:
+              schema:
+                $ref: '#/components/schemas/Notification'
+        default:
+          description: An error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /logs:
+    get:
+      operationId: tailLogs
+      responses:
+        '200':
+          $ref: '#/components/responses/LogLines'
+  /opaque:
+    get:
+      operationId: watchOpaque
+      responses:
+        '200':
+          description: Events which aren't described
+          content:
+            text/event-stream: {}
+components:
+  schemas:
+    Notification:
+      type: object
+      properties:
+        message:
+          type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+  responses:
+    LogLines:
+      description: The lines of the logs
+      content:
+        text/event-stream:
+          schema:
+            type: string
+`
+
+func TestDescribeEventStreams(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(eventStreamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	ops, err := OperationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	operations := map[string]OperationDefinition{}
+	for _, op := range ops {
+		operations[op.OperationId] = op
+	}
+
+	notifications := operations["WatchNotifications"].Responses[0].Contents[0]
+	assert.True(t, notifications.IsEventStream())
+	assert.Equal(t, "EventStream", notifications.NameTag)
+	assert.Equal(t, "Notification", notifications.Schema.TypeDecl())
+
+	logs := operations["TailLogs"].Responses[0].Contents[0]
+	assert.True(t, logs.IsEventStream())
+	assert.Equal(t, "string", logs.Schema.TypeDecl())
+
+	// without a schema, the events are an opaque body
+	opaque := operations["WatchOpaque"].Responses[0].Contents[0]
+	assert.False(t, opaque.IsEventStream())
+	assert.False(t, opaque.IsSupported())
+}
+
+func TestGenerateEventStreams(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(eventStreamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	for name, generate := range map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			generate.Client = true
+			generate.Strict = true
+			code, err := Generate(spec, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			require.NoError(t, err)
+
+			// the client and strict server share the reading and writing of
+			// events
+			assert.Equal(t, 1, strings.Count(code, "type ServerSentEventWriter[T any] struct {"))
+			assert.Equal(t, 1, strings.Count(code, "type ServerSentEventReader[T any] struct {"))
+			assert.NotContains(t, code, "func encodeJSONStream[T any](")
+
+			assert.Contains(t, code, "EventStream200 *ServerSentEventReader[Notification]")
+			assert.Contains(t, code, "EventStream200 *ServerSentEventReader[string]")
+			assert.Contains(t, code, "EventStream200: newServerSentEventReader[Notification](rsp.Body),")
+
+			assert.Regexp(t, `Body\s+func\(events \*ServerSentEventWriter\[Notification\]\) error`, code)
+			assert.Contains(t, code, "type LogLinesEventStreamResponse func(events *ServerSentEventWriter[string]) error")
+			assert.Contains(t, code, "type TailLogs200EventStreamResponse LogLinesEventStreamResponse")
+			assert.Contains(t, code, `"Cache-Control", "no-cache"`)
+			assert.Contains(t, code, "type WatchOpaque200TexteventStreamResponse struct {")
+		})
+	}
+}
+
+func TestEventStreamsAreOptional(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(streamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+			Strict:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "ServerSentEvent")
+	assert.NotContains(t, code, "EventStream")
+}
@@ -27,6 +27,12 @@ const (
 	// extOapiCodegenOnlyHonourGoName is to be used to explicitly enforce the generation of a field as the `x-go-name` extension has describe it.
 	// This is intended to be used alongside the `allow-unexported-struct-field-names` Compatibility option
 	extOapiCodegenOnlyHonourGoName = "x-oapi-codegen-only-honour-go-name"
+	// extOapiCodegenRetryable marks an operation as safe for the client to retry, even though its method isn't
+	extOapiCodegenRetryable = "x-oapi-codegen-retryable"
+	// extOapiCodegenPagination describes how to iterate over the pages of an operation's responses
+	extOapiCodegenPagination = "x-oapi-codegen-pagination"
+	// extOapiCodegenStream streams the items of a media type's JSON array, NDJSON or JSON text sequence, rather than buffering them
+	extOapiCodegenStream = "x-oapi-codegen-stream"
 )
 
 func extString(extPropValue interface{}) (string, error) {
@@ -120,3 +126,40 @@ func extParseOapiCodegenOnlyHonourGoName(extPropValue interface{}) (bool, error)
 	}
 	return onlyHonourGoName, nil
 }
+
+func extParseOapiCodegenRetryable(extPropValue interface{}) (bool, error) {
+	retryable, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return retryable, nil
+}
+
+func extParseOapiCodegenStream(extPropValue interface{}) (bool, error) {
+	stream, ok := extPropValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	return stream, nil
+}
+
+func extParseOapiCodegenPagination(extPropValue interface{}) (map[string]string, error) {
+	configI, ok := extPropValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	config := make(map[string]string, len(configI))
+	for k, v := range configI {
+		switch k {
+		case "type", "parameter", "next", "items":
+		default:
+			return nil, fmt.Errorf("unknown field %q", k)
+		}
+		vs, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert type: %T", v)
+		}
+		config[k] = vs
+	}
+	return config, nil
+}
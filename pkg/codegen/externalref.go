@@ -8,13 +8,13 @@ import (
 // ensureExternalRefsInRequestBodyDefinitions ensures that when an externalRef (`$ref` that points to a file that isn't the current spec) is encountered, we make sure we update our underlying `RefType` to make sure that we point to that type.
 // This only happens if we have a non-empty `ref` passed in, and that `ref` isn't pointing to something in our file
 // NOTE that the pointer here allows us to pass in a reference and edit in-place
-func ensureExternalRefsInRequestBodyDefinitions(defs *[]RequestBodyDefinition, ref string) {
+func (gs *generatorState) ensureExternalRefsInRequestBodyDefinitions(defs *[]RequestBodyDefinition, ref string) {
 	if ref == "" {
 		return
 	}
 
 	for i, rbd := range *defs {
-		ensureExternalRefsInSchema(&rbd.Schema, ref)
+		gs.ensureExternalRefsInSchema(&rbd.Schema, ref)
 
 		// make sure we then update it in-place
 		(*defs)[i] = rbd
@@ -24,14 +24,14 @@ func ensureExternalRefsInRequestBodyDefinitions(defs *[]RequestBodyDefinition, r
 // ensureExternalRefsInResponseDefinitions ensures that when an externalRef (`$ref` that points to a file that isn't the current spec) is encountered, we make sure we update our underlying `RefType` to make sure that we point to that type.
 // This only happens if we have a non-empty `ref` passed in, and that `ref` isn't pointing to something in our file
 // NOTE that the pointer here allows us to pass in a reference and edit in-place
-func ensureExternalRefsInResponseDefinitions(defs *[]ResponseDefinition, ref string) {
+func (gs *generatorState) ensureExternalRefsInResponseDefinitions(defs *[]ResponseDefinition, ref string) {
 	if ref == "" {
 		return
 	}
 
 	for i, rd := range *defs {
 		for j, rcd := range rd.Contents {
-			ensureExternalRefsInSchema(&rcd.Schema, ref)
+			gs.ensureExternalRefsInSchema(&rcd.Schema, ref)
 
 			// make sure we then update it in-place
 			rd.Contents[j] = rcd
@@ -45,13 +45,13 @@ func ensureExternalRefsInResponseDefinitions(defs *[]ResponseDefinition, ref str
 // ensureExternalRefsInParameterDefinitions ensures that when an externalRef (`$ref` that points to a file that isn't the current spec) is encountered, we make sure we update our underlying `RefType` to make sure that we point to that type.
 // This only happens if we have a non-empty `ref` passed in, and that `ref` isn't pointing to something in our file
 // NOTE that the pointer here allows us to pass in a reference and edit in-place
-func ensureExternalRefsInParameterDefinitions(defs *[]ParameterDefinition, ref string) {
+func (gs *generatorState) ensureExternalRefsInParameterDefinitions(defs *[]ParameterDefinition, ref string) {
 	if ref == "" {
 		return
 	}
 
 	for i, pd := range *defs {
-		ensureExternalRefsInSchema(&pd.Schema, ref)
+		gs.ensureExternalRefsInSchema(&pd.Schema, ref)
 
 		// make sure we then update it in-place
 		(*defs)[i] = pd
@@ -63,7 +63,7 @@ func ensureExternalRefsInParameterDefinitions(defs *[]ParameterDefinition, ref s
 // This only happens if we have a non-empty `ref` passed in, and that `ref` isn't pointing to something in our file
 //
 // NOTE that the pointer here allows us to pass in a reference and edit in-place
-func ensureExternalRefsInSchema(schema *Schema, ref string) {
+func (gs *generatorState) ensureExternalRefsInSchema(schema *Schema, ref string) {
 	if ref == "" {
 		return
 	}
@@ -74,7 +74,7 @@ func ensureExternalRefsInSchema(schema *Schema, ref string) {
 	}
 
 	parts := strings.SplitN(ref, "#", 2)
-	if pack, ok := globalState.importMapping[parts[0]]; ok {
+	if pack, ok := gs.importMapping[parts[0]]; ok {
 		schema.RefType = fmt.Sprintf("%s.%s", pack.Name, schema.GoType)
 	}
 }
@@ -0,0 +1,55 @@
+package codegen
+
+import (
+	"text/template"
+)
+
+// FakeClientMethodDefinition describes a method of the
+// ClientWithResponsesInterface, which the generated FakeClientWithResponses
+// stubs and records the calls of, when using the `generate.fake-client`
+// option.
+type FakeClientMethodDefinition struct {
+	OperationDefinition
+	// Name is the name of the method, such as `AddPetWithBodyWithResponse`
+	Name string
+	// GenericBody is whether the method takes the body as an `io.Reader`,
+	// with its content type
+	GenericBody bool
+	// Body is the request body that the method takes, when it takes a typed
+	// body
+	Body *RequestBodyDefinition
+}
+
+// GenerateFakeClient generates a FakeClientWithResponses, which implements
+// the ClientWithResponsesInterface with a stub for each of its methods, and
+// records the arguments of each call.
+func GenerateFakeClient(t *template.Template, ops []OperationDefinition) (string, error) {
+	var methods []FakeClientMethodDefinition
+	for _, op := range ops {
+		if !op.HasBody() {
+			methods = append(methods, FakeClientMethodDefinition{
+				OperationDefinition: op,
+				Name:                op.OperationId + "WithResponse",
+			})
+			continue
+		}
+
+		methods = append(methods, FakeClientMethodDefinition{
+			OperationDefinition: op,
+			Name:                op.OperationId + "WithBodyWithResponse",
+			GenericBody:         true,
+		})
+		for i := range op.Bodies {
+			if !op.Bodies[i].IsSupportedByClient() {
+				continue
+			}
+			methods = append(methods, FakeClientMethodDefinition{
+				OperationDefinition: op,
+				Name:                op.OperationId + op.Bodies[i].Suffix() + "WithResponse",
+				Body:                &op.Bodies[i],
+			})
+		}
+	}
+
+	return GenerateTemplates([]string{"client-fake.tmpl"}, t, methods)
+}
@@ -0,0 +1,90 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeClientOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Fake client
+paths:
+  /things/{kind}/{id}:
+    put:
+      operationId: putThing
+      parameters:
+        - name: kind
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+          text/plain:
+            schema:
+              type: string
+          multipart/form-data:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: The thing was put
+`
+
+func TestGenerateFakeClient(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(fakeClientOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Client:     true,
+			FakeClient: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "var _ ClientWithResponsesInterface = (*FakeClientWithResponses)(nil)")
+	assert.Contains(t, code, "PutThingWithBodyWithResponseFunc func(ctx context.Context, kind string, id int, params *PutThingParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutThingResponse, error)")
+	assert.Contains(t, code, "PutThingWithResponseFunc func(ctx context.Context, kind string, id int, params *PutThingParams, body PutThingJSONRequestBody, reqEditors ...RequestEditorFn) (*PutThingResponse, error)")
+	assert.Contains(t, code, "PutThingWithTextBodyWithResponseFunc func(ctx context.Context, kind string, id int, params *PutThingParams, body PutThingTextRequestBody, reqEditors ...RequestEditorFn) (*PutThingResponse, error)")
+	assert.NotContains(t, code, "PutThingWithMultipartBodyWithResponse")
+	assert.Contains(t, code, "func (f *FakeClientWithResponses) PutThingWithResponseCalls() []FakePutThingWithResponseCall {")
+	assert.Contains(t, code, "return stub(ctx, kind, id, params, contentType, bytes.NewReader(content), reqEditors...)")
+}
+
+func TestGenerateFakeClientRequiresClient(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			FakeClient: true,
+		},
+	}
+	assert.Error(t, opts.Validate())
+
+	opts.Generate.Client = true
+	assert.NoError(t, opts.Validate())
+}
@@ -0,0 +1,260 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// outputPart identifies the part of the generated code that an outputSection
+// belongs to, which decides the file that it is written to.
+type outputPart string
+
+const (
+	outputPartMain         outputPart = ""
+	outputPartModels       outputPart = "models"
+	outputPartClient       outputPart = "client"
+	outputPartServer       outputPart = "server"
+	outputPartStrictServer outputPart = "strict-server"
+	outputPartEmbeddedSpec outputPart = "embedded-spec"
+)
+
+// outputSection is a section of generated code, without any imports
+type outputSection struct {
+	part outputPart
+	code string
+}
+
+// GeneratedFile is a file of generated code, as returned by GenerateFiles.
+type GeneratedFile struct {
+	// Filename is the path of the file, as configured in the `output-options.layout`, or empty for the main output
+	Filename string
+	// PackageName is the package that the code is generated under
+	PackageName string
+	// Code is the generated code
+	Code string
+}
+
+// layoutFile collects the sections of generated code that are written to the
+// same file.
+type layoutFile struct {
+	GeneratedFile
+	// refersToModels indicates whether any of the sections use the models
+	refersToModels bool
+}
+
+// outputPackageName returns the package that a part of the generated code is
+// written to.
+func outputPackageName(opts Configuration, part outputPart) string {
+	layout := opts.OutputOptions.Layout
+	options := map[outputPart]*OutputFileOptions{
+		outputPartModels:       layout.Models,
+		outputPartClient:       layout.Client,
+		outputPartServer:       layout.Server,
+		outputPartStrictServer: layout.StrictServer,
+		outputPartEmbeddedSpec: layout.EmbeddedSpec,
+	}[part]
+	if options == nil || options.PackageName == "" {
+		return opts.PackageName
+	}
+	return options.PackageName
+}
+
+// modelAliases is the data for the model-aliases.tmpl template
+type modelAliases struct {
+	PackageName string
+	Types       []string
+	Constants   []string
+}
+
+// layoutFiles groups the sections of generated code into the files configured
+// in the OutputLayoutOptions, in the same order that they were generated, and
+// then adds the imports to each file, which are formatted by formatFiles.
+//
+// When the models are generated in a separate package, each of the other
+// packages that refer to them has aliases of the models generated, so the rest
+// of the generated code can remain unchanged.
+func (gs *generatorState) layoutFiles(t *template.Template, opts Configuration, externalImports []string, sections []outputSection) ([]GeneratedFile, error) {
+	layout := opts.OutputOptions.Layout
+
+	mainFile := &layoutFile{GeneratedFile: GeneratedFile{PackageName: opts.PackageName}}
+	files := []*layoutFile{mainFile}
+	filesByPart := map[outputPart]*layoutFile{
+		outputPartMain: mainFile,
+	}
+
+	parts := []struct {
+		part    outputPart
+		options *OutputFileOptions
+	}{
+		{outputPartModels, layout.Models},
+		{outputPartClient, layout.Client},
+		{outputPartServer, layout.Server},
+		{outputPartStrictServer, layout.StrictServer},
+		{outputPartEmbeddedSpec, layout.EmbeddedSpec},
+	}
+	for _, p := range parts {
+		if p.options == nil {
+			filesByPart[p.part] = mainFile
+			continue
+		}
+
+		f := &layoutFile{GeneratedFile: GeneratedFile{Filename: p.options.Filename, PackageName: p.options.PackageName}}
+		if f.PackageName == "" {
+			f.PackageName = opts.PackageName
+		}
+		files = append(files, f)
+		filesByPart[p.part] = f
+	}
+
+	for _, section := range sections {
+		f := filesByPart[section.part]
+		f.Code += section.code
+		if section.part != outputPartModels && section.part != outputPartEmbeddedSpec && section.code != "" {
+			f.refersToModels = true
+		}
+	}
+
+	modelsFile := filesByPart[outputPartModels]
+	var modelsImport goImport
+	if layout.Models != nil {
+		modelsImport = goImport{Name: modelsFile.PackageName, Path: layout.Models.ImportPath}
+	}
+
+	var aliases string
+	aliased := map[string]bool{
+		modelsFile.PackageName: true,
+	}
+	for _, f := range files {
+		if !f.refersToModels || aliased[f.PackageName] {
+			continue
+		}
+		aliased[f.PackageName] = true
+
+		if aliases == "" {
+			var err error
+			aliases, err = generateModelAliases(t, modelsFile.PackageName, modelsFile.Code)
+			if err != nil {
+				return nil, fmt.Errorf("error generating aliases for models: %w", err)
+			}
+		}
+		f.Code = aliases + f.Code
+	}
+
+	var generated []GeneratedFile
+	for _, f := range files {
+		// without a layout, the main output is always generated, to retain
+		// the existing behaviour
+		if f.Code == "" && !(f == mainFile && layout.IsZero()) {
+			continue
+		}
+
+		fileImports := externalImports
+		if f.PackageName != modelsFile.PackageName && modelsImport.Path != "" {
+			fileImports = append(append([]string{}, externalImports...), modelsImport.String())
+		}
+
+		importsOut, err := gs.generateImports(
+			t,
+			fileImports,
+			f.PackageName,
+			opts.NoVCSVersionOverride,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error generating imports: %w", err)
+		}
+
+		// remove any byte-order-marks which break Go-Code
+		f.GeneratedFile.Code = SanitizeCode(importsOut + f.Code)
+		generated = append(generated, f.GeneratedFile)
+	}
+
+	return generated, nil
+}
+
+// formatFiles formats the code of each file, and removes its unused imports,
+// unless the `output-options.skip-fmt` is set.
+func formatFiles(files []GeneratedFile, opts Configuration) error {
+	if opts.OutputOptions.SkipFmt {
+		return nil
+	}
+	for i, f := range files {
+		// The generation code produces unindented horrors. Use the Go Imports
+		// to make it all pretty.
+		outBytes, err := imports.Process(f.PackageName+".go", []byte(f.Code), nil)
+		if err != nil {
+			return fmt.Errorf("error formatting Go code: %w\n%s", err, codeAroundError(f.Code, err))
+		}
+		files[i].Code = string(outBytes)
+	}
+	return nil
+}
+
+// codeAroundError returns the lines of the code around the first position of
+// a syntax error, numbered, rather than all of the code, or all of the code
+// when the error has no position.
+func codeAroundError(code string, err error) string {
+	lines := strings.Split(code, "\n")
+	first, last := 1, len(lines)
+
+	var errs scanner.ErrorList
+	if errors.As(err, &errs) && len(errs) > 0 {
+		line := errs[0].Pos.Line
+		first, last = max(line-5, 1), min(line+5, len(lines))
+	}
+
+	var out strings.Builder
+	for i := first; i <= last; i++ {
+		fmt.Fprintf(&out, "%5d\t%s\n", i, lines[i-1])
+	}
+	return out.String()
+}
+
+// generateModelAliases generates aliases for each of the exported types and
+// constants declared in the generated code of the models.
+func generateModelAliases(t *template.Template, packageName string, modelsCode string) (string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package "+packageName+"\n"+SanitizeCode(modelsCode), parser.SkipObjectResolution)
+	if err != nil {
+		return "", fmt.Errorf("error parsing models: %w", err)
+	}
+
+	data := modelAliases{
+		PackageName: packageName,
+	}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if s.Name.IsExported() {
+					data.Types = append(data.Types, s.Name.Name)
+				}
+			case *ast.ValueSpec:
+				if genDecl.Tok != token.CONST {
+					continue
+				}
+				for _, name := range s.Names {
+					if name.IsExported() {
+						data.Constants = append(data.Constants, name.Name)
+					}
+				}
+			}
+		}
+	}
+
+	if len(data.Types) == 0 && len(data.Constants) == 0 {
+		return "", nil
+	}
+
+	return GenerateTemplates([]string{"model-aliases.tmpl"}, t, data)
+}
@@ -0,0 +1,112 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestGenerateFilesWithLayout(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+			EmbeddedSpec:  true,
+		},
+		OutputOptions: OutputOptions{
+			Layout: OutputLayoutOptions{
+				Models: &OutputFileOptions{
+					Filename:    "models/models.gen.go",
+					PackageName: "models",
+					ImportPath:  "example.com/api/models",
+				},
+				Client: &OutputFileOptions{
+					Filename:    "client/client.gen.go",
+					PackageName: "client",
+				},
+				Server: &OutputFileOptions{
+					Filename: "server.gen.go",
+				},
+			},
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/layout.yaml")
+	require.NoError(t, err)
+
+	files, err := GenerateFiles(swagger, opts)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	for _, file := range files {
+		_, err := format.Source([]byte(file.Code))
+		require.NoError(t, err, file.Filename)
+	}
+
+	// the main output contains the remaining code, which is the embedded spec
+	assert.Equal(t, "", files[0].Filename)
+	assert.Equal(t, "api", files[0].PackageName)
+	assert.Contains(t, files[0].Code, "package api")
+	assert.Contains(t, files[0].Code, "func GetSwagger() (")
+
+	assert.Equal(t, "models/models.gen.go", files[1].Filename)
+	assert.Equal(t, "models", files[1].PackageName)
+	assert.Contains(t, files[1].Code, "package models")
+	assert.Contains(t, files[1].Code, "type Pet struct {")
+	assert.NotContains(t, files[1].Code, "func NewClient(")
+
+	assert.Equal(t, "client/client.gen.go", files[2].Filename)
+	assert.Contains(t, files[2].Code, "package client")
+	assert.Contains(t, files[2].Code, `"example.com/api/models"`)
+	assert.Regexp(t, `Pet\s+= models.Pet\n`, files[2].Code)
+	assert.Contains(t, files[2].Code, "func NewClient(")
+	assert.NotContains(t, files[2].Code, "type Pet struct {")
+
+	assert.Equal(t, "server.gen.go", files[3].Filename)
+	assert.Contains(t, files[3].Code, "package api")
+	assert.Regexp(t, `Pet\s+= models.Pet\n`, files[3].Code)
+	assert.Contains(t, files[3].Code, "type ServerInterface interface {")
+}
+
+func TestGenerateWithLayout(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			Layout: OutputLayoutOptions{
+				Models: &OutputFileOptions{
+					Filename: "models.gen.go",
+				},
+			},
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/layout.yaml")
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, opts)
+	require.Error(t, err)
+}
+
+func TestOutputLayoutOptionsValidate(t *testing.T) {
+	assert.Nil(t, OutputLayoutOptions{}.Validate())
+
+	problems := OutputLayoutOptions{
+		Models:       &OutputFileOptions{PackageName: "models"},
+		Client:       &OutputFileOptions{Filename: "client.gen.go", PackageName: "client"},
+		Server:       &OutputFileOptions{Filename: "client.gen.go", PackageName: "server"},
+		StrictServer: &OutputFileOptions{Filename: "strict.gen.go"},
+	}.Validate()
+
+	assert.Contains(t, problems, "layout.models")
+	assert.Contains(t, problems, "layout.client")
+	assert.Contains(t, problems, "layout.server")
+	assert.NotContains(t, problems, "layout.strict-server")
+	assert.NotContains(t, problems, "layout.embedded-spec")
+}
@@ -11,19 +11,23 @@ import (
 // MergeSchemas merges all the fields in the schemas supplied into one giant schema.
 // The idea is that we merge all fields together into one schema.
 func MergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
+	return packageGeneratorState().mergeSchemas(allOf, path)
+}
+
+func (gs *generatorState) mergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 	// If someone asked for the old way, for backward compatibility, return the
 	// old style result.
-	if globalState.options.Compatibility.OldMergeSchemas {
-		return mergeSchemasV1(allOf, path)
+	if gs.options.Compatibility.OldMergeSchemas {
+		return gs.mergeSchemasV1(allOf, path)
 	}
-	return mergeSchemas(allOf, path)
+	return gs.mergeSchemasV2(allOf, path)
 }
 
-func mergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
+func (gs *generatorState) mergeSchemasV2(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 	n := len(allOf)
 
 	if n == 1 {
-		return GenerateGoSchema(allOf[0], path)
+		return gs.generateGoSchema(allOf[0], path)
 	}
 
 	schema, err := valueWithPropagatedRef(allOf[0])
@@ -42,7 +46,7 @@ func mergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 			return Schema{}, fmt.Errorf("error merging schemas for AllOf: %w", err)
 		}
 	}
-	return GenerateGoSchema(openapi3.NewSchemaRef("", &schema), path)
+	return gs.generateGoSchema(openapi3.NewSchemaRef("", &schema), path)
 }
 
 // valueWithPropagatedRef returns a copy of ref schema with its Properties refs
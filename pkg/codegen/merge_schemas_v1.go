@@ -8,7 +8,7 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func mergeSchemasV1(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
+func (gs *generatorState) mergeSchemasV1(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 	var outSchema Schema
 	for _, schemaOrRef := range allOf {
 		ref := schemaOrRef.Ref
@@ -16,13 +16,13 @@ func mergeSchemasV1(allOf []*openapi3.SchemaRef, path []string) (Schema, error)
 		var refType string
 		var err error
 		if IsGoTypeReference(ref) {
-			refType, err = RefPathToGoType(ref)
+			refType, err = gs.refPathToGoType(ref, true)
 			if err != nil {
 				return Schema{}, fmt.Errorf("error converting reference path to a go type: %w", err)
 			}
 		}
 
-		schema, err := GenerateGoSchema(schemaOrRef, path)
+		schema, err := gs.generateGoSchema(schemaOrRef, path)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error generating Go schema in allOf: %w", err)
 		}
@@ -53,7 +53,7 @@ func mergeSchemasV1(allOf []*openapi3.SchemaRef, path []string) (Schema, error)
 
 	// Now, we generate the struct which merges together all the fields.
 	var err error
-	outSchema.GoType, err = GenStructFromAllOf(allOf, path)
+	outSchema.GoType, err = gs.genStructFromAllOf(allOf, path)
 	if err != nil {
 		return Schema{}, fmt.Errorf("unable to generate aggregate type for AllOf: %w", err)
 	}
@@ -64,6 +64,10 @@ func mergeSchemasV1(allOf []*openapi3.SchemaRef, path []string) (Schema, error)
 // input array. In the case of Ref objects, we use an embedded struct, otherwise,
 // we inline the fields.
 func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, error) {
+	return packageGeneratorState().genStructFromAllOf(allOf, path)
+}
+
+func (gs *generatorState) genStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, error) {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
 	for _, schemaOrRef := range allOf {
@@ -75,7 +79,7 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, err
 			//   InlinedMember
 			//   ...
 			// }
-			goType, err := RefPathToGoType(ref)
+			goType, err := gs.refPathToGoType(ref, true)
 			if err != nil {
 				return "", err
 			}
@@ -86,12 +90,12 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, err
 		} else {
 			// Inline all the fields from the schema into the output struct,
 			// just like in the simple case of generating an object.
-			goSchema, err := GenerateGoSchema(schemaOrRef, path)
+			goSchema, err := gs.generateGoSchema(schemaOrRef, path)
 			if err != nil {
 				return "", err
 			}
 			objectParts = append(objectParts, "   // Embedded fields due to inline allOf schema")
-			objectParts = append(objectParts, GenFieldsFromProperties(goSchema.Properties)...)
+			objectParts = append(objectParts, gs.genFieldsFromProperties(goSchema.Properties)...)
 
 			if goSchema.HasAdditionalProperties {
 				addPropsType := goSchema.AdditionalPropertiesType.GoType
@@ -0,0 +1,409 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// MockOperationDefinition describes the responses that the generated
+// MockServer can respond to an operation with, when using the
+// `generate.mock-server` option.
+type MockOperationDefinition struct {
+	OperationDefinition
+	// MockResponses are the responses, in order of preference, so that the
+	// first is used unless a request prefers another by its example's name
+	MockResponses []MockResponseDefinition
+}
+
+// MockResponseDefinition describes a response of the MockServer, built from an
+// example of one of the operation's responses, or from its schema.
+type MockResponseDefinition struct {
+	// Example is the name of the example, which is empty for the `example` of
+	// a media type, or when the response is built from the schema
+	Example     string
+	StatusCode  int
+	ContentType string
+	// Headers are the values of the response's headers, by their name
+	Headers map[string]string
+	// Body is the encoded body of the response
+	Body string
+}
+
+// BodyLiteral returns the body as a Go string literal, which is a raw string
+// literal where possible, so that it's readable.
+func (r MockResponseDefinition) BodyLiteral() string {
+	if strconv.CanBackquote(r.Body) {
+		return "`" + r.Body + "`"
+	}
+	return strconv.Quote(r.Body)
+}
+
+// GenerateMockServer generates a MockServer, which implements the
+// StrictServerInterface by responding with the examples of each operation's
+// responses, or values built from their schemas where there are none.
+func GenerateMockServer(t *template.Template, ops []OperationDefinition) (string, error) {
+	mocks := make([]MockOperationDefinition, len(ops))
+	for i, op := range ops {
+		responses, err := mockResponses(op)
+		if err != nil {
+			return "", fmt.Errorf("error generating mock responses for %s: %w", op.OperationId, err)
+		}
+		mocks[i] = MockOperationDefinition{
+			OperationDefinition: op,
+			MockResponses:       responses,
+		}
+	}
+
+	return GenerateTemplates([]string{"strict/strict-mock-server.tmpl"}, t, mocks)
+}
+
+// mockResponses returns the responses that the operation can be mocked with,
+// starting with its successful responses.
+func mockResponses(op OperationDefinition) ([]MockResponseDefinition, error) {
+	responses := make([]ResponseDefinition, len(op.Responses))
+	copy(responses, op.Responses)
+	// the responses are already sorted by their status code, so only need to
+	// be grouped, which keeps `2XX` after any explicit successful status
+	sort.SliceStable(responses, func(i, j int) bool {
+		return mockStatusRank(responses[i].StatusCode) < mockStatusRank(responses[j].StatusCode)
+	})
+
+	var mocks []MockResponseDefinition
+	for _, response := range responses {
+		var spec *openapi3.Response
+		if op.Spec != nil && op.Spec.Responses != nil {
+			if ref := op.Spec.Responses.Value(response.StatusCode); ref != nil {
+				spec = ref.Value
+			}
+		}
+		if spec == nil {
+			continue
+		}
+
+		statusCode := mockStatusCode(response.StatusCode, len(responses) == 1)
+		headers := mockHeaders(spec.Headers)
+
+		if len(response.Contents) == 0 {
+			mocks = append(mocks, MockResponseDefinition{
+				StatusCode: statusCode,
+				Headers:    headers,
+			})
+			continue
+		}
+
+		for _, content := range response.Contents {
+			// multipart bodies need to be written with a boundary, so can't be
+			// mocked from an example
+			if content.NameTag == "Multipart" {
+				continue
+			}
+			mediaType := spec.Content.Get(content.ContentType)
+			if mediaType == nil {
+				continue
+			}
+
+			contentType := content.ContentType
+			if !content.HasFixedContentType() {
+				contentType = ""
+			}
+
+			for _, example := range mockMediaTypeExamples(mediaType) {
+				body, err := mockEncode(content, example.value)
+				if err != nil {
+					return nil, fmt.Errorf("error encoding the example %q of the %s response for %s: %w", example.name, response.StatusCode, content.ContentType, err)
+				}
+				mocks = append(mocks, MockResponseDefinition{
+					Example:     example.name,
+					StatusCode:  statusCode,
+					ContentType: contentType,
+					Headers:     headers,
+					Body:        body,
+				})
+			}
+		}
+	}
+	return mocks, nil
+}
+
+// mockStatusRank orders the successful responses first, and the `default`
+// response last.
+func mockStatusRank(statusCode string) int {
+	switch {
+	case strings.HasPrefix(statusCode, "2"):
+		return 0
+	case statusCode == "default":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// mockStatusCode returns the status code to respond with for a response, which
+// is the first of a range, such as `4XX`, or for the `default` response, is
+// successful if it's the only response, and otherwise an error.
+func mockStatusCode(statusCode string, only bool) int {
+	if code, err := strconv.Atoi(statusCode); err == nil {
+		return code
+	}
+	if len(statusCode) == 3 && strings.HasSuffix(strings.ToUpper(statusCode), "XX") {
+		if class, err := strconv.Atoi(statusCode[:1]); err == nil {
+			return class * 100
+		}
+	}
+	if only {
+		return 200
+	}
+	return 500
+}
+
+type mockExample struct {
+	name  string
+	value interface{}
+}
+
+// mockMediaTypeExamples returns the `example`, followed by each of the
+// `examples` by their name, of a media type, or when it has neither, a value
+// built from its schema.
+func mockMediaTypeExamples(mediaType *openapi3.MediaType) []mockExample {
+	var examples []mockExample
+	if mediaType.Example != nil {
+		examples = append(examples, mockExample{value: mediaType.Example})
+	}
+	for _, name := range SortedMapKeys(mediaType.Examples) {
+		if example := mediaType.Examples[name]; example != nil && example.Value != nil && example.Value.Value != nil {
+			examples = append(examples, mockExample{name: name, value: example.Value.Value})
+		}
+	}
+	if len(examples) == 0 {
+		examples = append(examples, mockExample{value: mockSchemaValue(mediaType.Schema)})
+	}
+	return examples
+}
+
+// mockHeaders returns the value of each of a response's headers, from their
+// example, or built from their schema.
+func mockHeaders(headers openapi3.Headers) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(headers))
+	for name, header := range headers {
+		if header == nil || header.Value == nil {
+			continue
+		}
+		value := header.Value.Example
+		if value == nil {
+			for _, example := range SortedMapKeys(header.Value.Examples) {
+				if ref := header.Value.Examples[example]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+					value = ref.Value.Value
+					break
+				}
+			}
+		}
+		if value == nil {
+			value = mockSchemaValue(header.Value.Schema)
+		}
+		if value != nil {
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	return values
+}
+
+// mockEncode encodes an example as the body of the response's content.
+func mockEncode(content ResponseContentDefinition, value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	switch {
+	case content.IsJSON():
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case content.NameTag == "Formdata":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("a form must be an object, but was %T", value)
+		}
+		form := url.Values{}
+		for _, key := range SortedMapKeys(object) {
+			form.Set(key, fmt.Sprint(object[key]))
+		}
+		return form.Encode(), nil
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		// other than text, structured examples have no obvious encoding
+		if content.NameTag != "Text" {
+			return "", nil
+		}
+		return fmt.Sprint(value), nil
+	}
+}
+
+// mockSchemaValue builds a value which satisfies a schema, from its `example`,
+// `default` or `enum`, or otherwise a placeholder for its type.
+func mockSchemaValue(ref *openapi3.SchemaRef) interface{} {
+	return mockSchemaValueWithin(ref, nil)
+}
+
+// mockSchemaValueWithin builds a value for the schema, when it's within each
+// of the schemas in parents, so that recursive schemas end.
+func mockSchemaValueWithin(ref *openapi3.SchemaRef, parents []*openapi3.Schema) interface{} {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	schema := ref.Value
+	for _, parent := range parents {
+		if parent == schema {
+			return nil
+		}
+	}
+	parents = append(parents, schema)
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		merged := make(map[string]interface{})
+		for _, element := range schema.AllOf {
+			value := mockSchemaValueWithin(element, parents)
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return value
+			}
+			for k, v := range object {
+				merged[k] = v
+			}
+		}
+		for k, v := range mockObjectValue(schema, parents) {
+			merged[k] = v
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return mockUnionValue(schema, schema.OneOf, parents)
+	case len(schema.AnyOf) > 0:
+		return mockUnionValue(schema, schema.AnyOf, parents)
+	}
+
+	switch {
+	case schema.Type.Is("object") || len(schema.Properties) > 0:
+		return mockObjectValue(schema, parents)
+	case schema.Type.Is("array"):
+		if schema.Items == nil || (schema.MaxItems != nil && *schema.MaxItems == 0) {
+			return []interface{}{}
+		}
+		item := mockSchemaValueWithin(schema.Items, parents)
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case schema.Type.Is("string"):
+		return mockStringValue(schema)
+	case schema.Type.Is("integer"):
+		return math.Ceil(mockNumberValue(schema))
+	case schema.Type.Is("number"):
+		return mockNumberValue(schema)
+	case schema.Type.Is("boolean"):
+		return true
+	default:
+		return nil
+	}
+}
+
+// mockObjectValue builds an object with a value for each of the properties of
+// the schema.
+func mockObjectValue(schema *openapi3.Schema, parents []*openapi3.Schema) map[string]interface{} {
+	object := make(map[string]interface{}, len(schema.Properties))
+	for name, property := range schema.Properties {
+		if value := mockSchemaValueWithin(property, parents); value != nil {
+			object[name] = value
+		}
+	}
+	return object
+}
+
+// mockUnionValue builds a value for the first element of a `oneOf` or
+// `anyOf`, which has its discriminator set.
+func mockUnionValue(schema *openapi3.Schema, elements openapi3.SchemaRefs, parents []*openapi3.Schema) interface{} {
+	value := mockSchemaValueWithin(elements[0], parents)
+	object, ok := value.(map[string]interface{})
+	if !ok || schema.Discriminator == nil || elements[0].Ref == "" {
+		return value
+	}
+
+	discriminator := elements[0].Ref[strings.LastIndex(elements[0].Ref, "/")+1:]
+	for _, mapping := range SortedMapKeys(schema.Discriminator.Mapping) {
+		if schema.Discriminator.Mapping[mapping] == elements[0].Ref {
+			discriminator = mapping
+			break
+		}
+	}
+	object[schema.Discriminator.PropertyName] = discriminator
+	return object
+}
+
+func mockStringValue(schema *openapi3.Schema) string {
+	var value string
+	switch schema.Format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "127.0.0.1"
+	case "ipv6":
+		return "::1"
+	case "byte", "binary":
+		return ""
+	default:
+		value = "string"
+	}
+
+	if length := uint64(len(value)); length < schema.MinLength {
+		value += strings.Repeat("x", int(schema.MinLength-length))
+	}
+	if schema.MaxLength != nil && uint64(len(value)) > *schema.MaxLength {
+		value = value[:*schema.MaxLength]
+	}
+	return value
+}
+
+func mockNumberValue(schema *openapi3.Schema) float64 {
+	switch {
+	case schema.Min != nil && schema.ExclusiveMin:
+		return *schema.Min + 1
+	case schema.Min != nil:
+		return *schema.Min
+	case schema.Max != nil && schema.ExclusiveMax && *schema.Max <= 0:
+		return *schema.Max - 1
+	case schema.Max != nil && *schema.Max < 0:
+		return *schema.Max
+	default:
+		return 0
+	}
+}
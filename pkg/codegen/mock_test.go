@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mockOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Mock server
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '4XX':
+          description: An error
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  message:
+                    type: string
+              examples:
+                notFound:
+                  value:
+                    message: not found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          minLength: 8
+        age:
+          type: integer
+          minimum: 1
+          exclusiveMinimum: true
+        born:
+          type: string
+          format: date-time
+        parent:
+          $ref: '#/components/schemas/Pet'
+`
+
+func TestGenerateMockServer(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(mockOpenAPIDefinition))
+	require.NoError(t, err)
+
+	servers := map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"chi":      {ChiServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"gorilla":  {GorillaServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	}
+
+	for name, generate := range servers {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			generate.Strict = true
+			generate.MockServer = true
+
+			code, err := Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			assert.NoError(t, err)
+
+			assert.Contains(t, code, "var _ StrictServerInterface = (*MockServer)(nil)")
+			assert.Contains(t, code, "func (m *MockServer) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {")
+			assert.Contains(t, code, "func (response mockResponse) VisitGetPetResponse(")
+			assert.Contains(t, code, "func MockServerMiddleware(")
+			assert.Contains(t, code, "{statusCode: 200, contentType: \"application/json\", body: `{\"age\":2,\"born\":\"2024-01-01T00:00:00Z\",\"name\":\"stringxx\"}`},")
+			assert.Contains(t, code, "{example: \"notFound\", statusCode: 400, contentType: \"application/json\", body: `{\"message\":\"not found\"}`},")
+		})
+	}
+}
+
+func TestGenerateMockServerRequiresStrictServer(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Models:        true,
+			MockServer:    true,
+		},
+	}
+	assert.Error(t, opts.Validate())
+
+	opts.Generate.Strict = true
+	assert.NoError(t, opts.Validate())
+}
+
+func TestMockStatusCode(t *testing.T) {
+	assert.Equal(t, 201, mockStatusCode("201", false))
+	assert.Equal(t, 400, mockStatusCode("4XX", false))
+	assert.Equal(t, 200, mockStatusCode("default", true))
+	assert.Equal(t, 500, mockStatusCode("default", false))
+}
+
+func TestMockSchemaValue(t *testing.T) {
+	maxLength := uint64(3)
+	tests := map[string]struct {
+		schema   *openapi3.Schema
+		expected interface{}
+	}{
+		"example": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "Tom", Default: "Felix"},
+			expected: "Tom",
+		},
+		"default": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"string"}, Default: "Felix"},
+			expected: "Felix",
+		},
+		"enum": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []interface{}{"cat", "dog"}},
+			expected: "cat",
+		},
+		"maximum length": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"string"}, MaxLength: &maxLength},
+			expected: "str",
+		},
+		"uuid": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "uuid"},
+			expected: "00000000-0000-0000-0000-000000000000",
+		},
+		"boolean": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"boolean"}},
+			expected: true,
+		},
+		"array": {
+			schema:   &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"integer"}})},
+			expected: []interface{}{float64(0)},
+		},
+		"allOf": {
+			schema: &openapi3.Schema{AllOf: openapi3.SchemaRefs{
+				openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"a": openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"boolean"}})}}),
+				openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"b": openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "b"})}}),
+			}},
+			expected: map[string]interface{}{"a": true, "b": "b"},
+		},
+		"oneOf with a discriminator": {
+			schema: &openapi3.Schema{
+				OneOf: openapi3.SchemaRefs{
+					openapi3.NewSchemaRef("#/components/schemas/Cat", &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{"kind": openapi3.NewSchemaRef("", &openapi3.Schema{Type: &openapi3.Types{"string"}})}}),
+				},
+				Discriminator: &openapi3.Discriminator{
+					PropertyName: "kind",
+					Mapping:      openapi3.StringMap{"cat": "#/components/schemas/Cat"},
+				},
+			},
+			expected: map[string]interface{}{"kind": "cat"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, mockSchemaValue(openapi3.NewSchemaRef("", test.schema)))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// NegotiatedResponseDefinition describes a response which has several media
+// types for the same Go type, so that the strict server can choose the one
+// which is preferred by the Accept header of each request.
+type NegotiatedResponseDefinition struct {
+	// ContentTypes are the media types of the response, in the order they're
+	// preferred when a request accepts several of them, which starts with
+	// `application/json`, if it's one of them
+	ContentTypes []string
+	// Schema is the Go type of the body, in each of the media types
+	Schema Schema
+}
+
+// ContentTypesList returns the media types, separated by commas.
+func (n NegotiatedResponseDefinition) ContentTypesList() string {
+	return strings.Join(n.ContentTypes, ", ")
+}
+
+// describeNegotiatedResponse describes the negotiation of a response's media
+// types, or returns nil when it has a single media type, or when they can't
+// be negotiated, as they have different Go types, or they're written in
+// their own way, such as streams or multipart bodies.
+func (gs *generatorState) describeNegotiatedResponse(response *openapi3.Response, contents []ResponseContentDefinition, path []string) (*NegotiatedResponseDefinition, error) {
+	if len(contents) < 2 {
+		return nil, nil
+	}
+
+	var contentTypes []string
+	for _, content := range contents {
+		if !content.HasFixedContentType() || content.IsStream() || content.IsEventStream() ||
+			content.NameTag == "Multipart" || content.NameTag == "Formdata" {
+			return nil, nil
+		}
+		if mediaType := response.Content.Get(content.ContentType); mediaType == nil || mediaType.Schema == nil {
+			return nil, nil
+		}
+		contentTypes = append(contentTypes, content.ContentType)
+	}
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return contentTypes[i] == "application/json" && contentTypes[j] != "application/json"
+	})
+
+	var negotiated *NegotiatedResponseDefinition
+	for _, contentType := range contentTypes {
+		schema, err := gs.generateGoSchema(response.Content.Get(contentType).Schema, path)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the body of the %s media type: %w", contentType, err)
+		}
+		if negotiated == nil {
+			negotiated = &NegotiatedResponseDefinition{Schema: schema}
+		} else if schema.TypeDecl() != negotiated.Schema.TypeDecl() {
+			return nil, nil
+		}
+		negotiated.ContentTypes = append(negotiated.ContentTypes, contentType)
+	}
+	return negotiated, nil
+}
+
+// HasNegotiatedResponses returns whether any of the operation's responses
+// have media types which are negotiated.
+func (o OperationDefinition) HasNegotiatedResponses() bool {
+	for _, response := range o.Responses {
+		if response.Negotiated != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNegotiatedResponses returns whether any of the operations' responses
+// have media types which are negotiated.
+func hasNegotiatedResponses(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		if op.HasNegotiatedResponses() {
+			return true
+		}
+	}
+	return false
+}
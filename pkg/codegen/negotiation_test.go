@@ -0,0 +1,163 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const negotiationOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Negotiation
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: The pet
+          headers:
+            X-Version:
+              schema:
+                type: integer
+          content:
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/cbor:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets, which are different in each media type
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+            text/plain:
+              schema:
+                type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+  responses:
+    Error:
+      description: An error
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            properties:
+              detail:
+                type: string
+        application/problem+xml:
+          schema:
+            type: object
+            properties:
+              detail:
+                type: string
+`
+
+func TestDescribeNegotiatedResponses(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(negotiationOpenAPIDefinition))
+	require.NoError(t, err)
+
+	ops, err := OperationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	operations := map[string]OperationDefinition{}
+	for _, op := range ops {
+		operations[op.OperationId] = op
+	}
+
+	getPet := operations["GetPet"]
+	assert.True(t, getPet.HasNegotiatedResponses())
+	negotiated := getPet.Responses[0].Negotiated
+	require.NotNil(t, negotiated)
+	// JSON is preferred, and then the rest are in order
+	assert.Equal(t, []string{"application/json", "application/cbor", "application/xml"}, negotiated.ContentTypes)
+	assert.Equal(t, "Pet", negotiated.Schema.TypeDecl())
+
+	errorResponse := getPet.Responses[1].Negotiated
+	require.NotNil(t, errorResponse)
+	assert.Equal(t, []string{"application/problem+json", "application/problem+xml"}, errorResponse.ContentTypes)
+
+	// the media types of the pets have different Go types
+	assert.False(t, operations["ListPets"].HasNegotiatedResponses())
+}
+
+func TestGenerateNegotiatedResponses(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(negotiationOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			StdHTTPServer: true,
+			Strict:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "Codecs map[string]StrictHTTPCodec")
+	assert.Contains(t, code, "func DefaultStrictHTTPCodecs() map[string]StrictHTTPCodec {")
+	assert.Contains(t, code, `negotiated.visitNegotiated(w, r.Header.Get("Accept"), sh.codecs())`)
+
+	assert.Regexp(t, `type GetPet200NegotiatedResponse struct \{\s+Body\s+Pet\s+Headers GetPet200ResponseHeaders\s+\}`, code)
+	assert.Contains(t, code, `negotiateStrictHTTPCodec(accept, []string{"application/json", "application/cbor", "application/xml"}, codecs)`)
+	assert.Regexp(t, `type GetPetdefaultNegotiatedResponse struct \{\s+Body\s+struct \{\s+Detail \*string`, code)
+	assert.Contains(t, code, "StatusCode int")
+	assert.NotContains(t, code, "ListPets200NegotiatedResponse")
+}
+
+func TestNegotiatedResponsesAreOnlyForNetHTTP(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(negotiationOpenAPIDefinition))
+	require.NoError(t, err)
+
+	for name, generate := range map[string]GenerateOptions{
+		"echo": {EchoServer: true},
+		"gin":  {GinServer: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			generate.Strict = true
+			code, err := Generate(spec, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+			})
+			require.NoError(t, err)
+
+			assert.NotContains(t, code, "NegotiatedResponse")
+			assert.NotContains(t, code, "StrictHTTPCodec")
+		})
+	}
+}
@@ -34,6 +34,10 @@ type ParameterDefinition struct {
 	Required  bool   // Is this a required parameter?
 	Spec      *openapi3.Parameter
 	Schema    Schema
+
+	// state is the state of the generation which the parameter was described
+	// in
+	state *generatorState
 }
 
 // TypeDef is here as an adapter after a large refactoring so that I don't
@@ -132,7 +136,7 @@ func (pd ParameterDefinition) GoName() string {
 			goName = extGoFieldName
 		}
 	}
-	return SchemaNameToTypeName(goName)
+	return pd.state.orDefault().schemaNameToTypeName(goName)
 }
 
 // Deprecated: Use HasOptionalPointer, as it is clearer what the intent is.
@@ -161,11 +165,15 @@ func (p ParameterDefinitions) FindByName(name string) *ParameterDefinition {
 // descriptors into a flat list. This makes it a lot easier to traverse the
 // data in the template engine.
 func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
+	return packageGeneratorState().describeParameters(params, path)
+}
+
+func (gs *generatorState) describeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
 	outParams := make([]ParameterDefinition, 0)
 	for _, paramOrRef := range params {
 		param := paramOrRef.Value
 
-		goType, err := paramToGoType(param, append(path, param.Name))
+		goType, err := gs.paramToGoType(param, append(path, param.Name))
 		if err != nil {
 			return nil, fmt.Errorf("error generating type for param (%s): %s",
 				param.Name, err)
@@ -177,13 +185,14 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 			Required:  param.Required,
 			Spec:      param,
 			Schema:    goType,
+			state:     gs,
 		}
 
 		// If this is a reference to a predefined type, simply use the reference
 		// name as the type. $ref: "#/components/schemas/custom_type" becomes
 		// "CustomType".
 		if IsGoTypeReference(paramOrRef.Ref) {
-			goType, err := RefPathToGoType(paramOrRef.Ref)
+			goType, err := gs.refPathToGoType(paramOrRef.Ref, true)
 			if err != nil {
 				return nil, fmt.Errorf("error dereferencing (%s) for param (%s): %s",
 					paramOrRef.Ref, param.Name, err)
@@ -213,24 +222,47 @@ func DescribeSecurityDefinition(securityRequirements openapi3.SecurityRequiremen
 	return outDefs
 }
 
+// DescribeSecurityRequirements describes each of the alternative security
+// requirements, any one of which needs to be met, as the security schemes
+// which need to be used together to meet it. An empty requirement can be met
+// without any credentials.
+func DescribeSecurityRequirements(securityRequirements openapi3.SecurityRequirements) [][]SecurityDefinition {
+	outReqs := make([][]SecurityDefinition, 0, len(securityRequirements))
+
+	for _, sr := range securityRequirements {
+		outReqs = append(outReqs, DescribeSecurityDefinition(openapi3.SecurityRequirements{sr}))
+	}
+
+	return outReqs
+}
+
 // OperationDefinition describes an Operation
 type OperationDefinition struct {
-	// OperationId is the `operationId` field from the OpenAPI Specification, after going through a `nameNormalizer`, and will be used to generate function names
+	// OperationId is the `operationId` field from the OpenAPI Specification, after going through a `name-normalizer`, and will be used to generate function names
 	OperationId string
 
-	PathParams          []ParameterDefinition // Parameters in the path, eg, /path/:param
-	HeaderParams        []ParameterDefinition // Parameters in HTTP headers
-	QueryParams         []ParameterDefinition // Parameters in the query, /path?param
-	CookieParams        []ParameterDefinition // Parameters in cookies
-	TypeDefinitions     []TypeDefinition      // These are all the types we need to define for this operation
-	SecurityDefinitions []SecurityDefinition  // These are the security providers
-	BodyRequired        bool
-	Bodies              []RequestBodyDefinition // The list of bodies for which to generate handlers.
-	Responses           []ResponseDefinition    // The list of responses that can be accepted by handlers.
-	Summary             string                  // Summary string from Swagger, used to generate a comment
-	Method              string                  // GET, POST, DELETE, etc.
-	Path                string                  // The Swagger path for the operation, like /resource/{id}
-	Spec                *openapi3.Operation
+	PathParams           []ParameterDefinition  // Parameters in the path, eg, /path/:param
+	HeaderParams         []ParameterDefinition  // Parameters in HTTP headers
+	QueryParams          []ParameterDefinition  // Parameters in the query, /path?param
+	CookieParams         []ParameterDefinition  // Parameters in cookies
+	TypeDefinitions      []TypeDefinition       // These are all the types we need to define for this operation
+	SecurityDefinitions  []SecurityDefinition   // These are the security providers
+	SecurityRequirements [][]SecurityDefinition // These are the alternative sets of security providers, the providers of each of which are used together
+	BodyRequired         bool
+	Bodies               []RequestBodyDefinition // The list of bodies for which to generate handlers.
+	Responses            []ResponseDefinition    // The list of responses that can be accepted by handlers.
+	Summary              string                  // Summary string from Swagger, used to generate a comment
+	Method               string                  // GET, POST, DELETE, etc.
+	Path                 string                  // The Swagger path for the operation, like /resource/{id}, the name of a webhook, or the runtime expression of a callback
+	IsWebhook            bool                    // Whether this operation is a webhook, rather than a path on the server
+	Callback             *CallbackDefinition     // Set when this operation is a callback of another operation
+	Retryable            bool                    // Whether the client can retry this operation, regardless of its method, from the `x-oapi-codegen-retryable` extension
+	Pagination           *PaginationDefinition   // How the client iterates over the pages of this operation, from the `x-oapi-codegen-pagination` extension
+	Spec                 *openapi3.Operation
+
+	// state is the state of the generation which the operation was described
+	// in
+	state *generatorState
 }
 
 // Params returns the list of all parameters except Path parameters. Path parameters
@@ -281,6 +313,10 @@ func (o *OperationDefinition) SummaryAsComment() string {
 // response object for automatic deserialization of responses in the generated
 // Client code. See "client-with-responses.tmpl".
 func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefinition, error) {
+	return o.state.orDefault().responseTypeDefinitions(o)
+}
+
+func (gs *generatorState) responseTypeDefinitions(o *OperationDefinition) ([]ResponseTypeDefinition, error) {
 	var tds []ResponseTypeDefinition
 
 	if o.Spec == nil || o.Spec.Responses == nil {
@@ -305,7 +341,7 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 				contentType := responseRef.Value.Content[contentTypeName]
 				// We can only generate a type if we have a schema:
 				if contentType.Schema != nil {
-					responseSchema, err := GenerateGoSchema(contentType.Schema, []string{o.OperationId, responseName})
+					responseSchema, err := gs.generateGoSchema(contentType.Schema, []string{o.OperationId, responseName})
 					if err != nil {
 						return nil, fmt.Errorf("unable to determine Go type for %s.%s: %w", o.OperationId, contentTypeName, err)
 					}
@@ -315,21 +351,29 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 
 					// HAL+JSON:
 					case StringInArray(contentTypeName, contentTypesHalJSON):
-						typeName = fmt.Sprintf("HALJSON%s", nameNormalizer(responseName))
+						typeName = fmt.Sprintf("HALJSON%s", gs.nameNormalizer(responseName))
 					case contentTypeName == "application/json":
 						// if it's the standard application/json
-						typeName = fmt.Sprintf("JSON%s", nameNormalizer(responseName))
+						typeName = fmt.Sprintf("JSON%s", gs.nameNormalizer(responseName))
 					// Vendored JSON
 					case StringInArray(contentTypeName, contentTypesJSON) || util.IsMediaTypeJson(contentTypeName):
-						baseTypeName := fmt.Sprintf("%s%s", nameNormalizer(contentTypeName), nameNormalizer(responseName))
+						baseTypeName := fmt.Sprintf("%s%s", gs.nameNormalizer(contentTypeName), gs.nameNormalizer(responseName))
 
 						typeName = strings.ReplaceAll(baseTypeName, "Json", "JSON")
 					// YAML:
 					case StringInArray(contentTypeName, contentTypesYAML):
-						typeName = fmt.Sprintf("YAML%s", nameNormalizer(responseName))
+						typeName = fmt.Sprintf("YAML%s", gs.nameNormalizer(responseName))
 					// XML:
 					case StringInArray(contentTypeName, contentTypesXML):
-						typeName = fmt.Sprintf("XML%s", nameNormalizer(responseName))
+						typeName = fmt.Sprintf("XML%s", gs.nameNormalizer(responseName))
+					// Vendored XML:
+					case gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentTypeName):
+						baseTypeName := fmt.Sprintf("%s%s", gs.nameNormalizer(contentTypeName), gs.nameNormalizer(responseName))
+
+						typeName = strings.ReplaceAll(baseTypeName, "Xml", "XML")
+					// Server-Sent Events:
+					case contentTypeName == contentTypeEventStream:
+						typeName = fmt.Sprintf("EventStream%s", gs.nameNormalizer(responseName))
 					default:
 						continue
 					}
@@ -343,13 +387,16 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 						ContentTypeName:           contentTypeName,
 						AdditionalTypeDefinitions: responseSchema.GetAdditionalTypeDefs(),
 					}
-					if IsGoTypeReference(responseRef.Ref) {
-						refType, err := RefPathToGoType(responseRef.Ref)
+					// only the JSON of a component's response has a type,
+					// as the data of Server-Sent Events are described by
+					// their schema
+					if IsGoTypeReference(responseRef.Ref) && !td.IsEventStream() {
+						refType, err := gs.refPathToGoType(responseRef.Ref, true)
 						if err != nil {
 							return nil, fmt.Errorf("error dereferencing response Ref: %w", err)
 						}
 						if jsonCount > 1 && util.IsMediaTypeJson(contentTypeName) {
-							refType += mediaTypeToCamelCase(contentTypeName)
+							refType += gs.mediaTypeToCamelCase(contentTypeName)
 						}
 						td.Schema.RefType = refType
 					}
@@ -391,6 +438,14 @@ type RequestBodyDefinition struct {
 
 	// Contains encoding options for formdata
 	Encoding map[string]RequestBodyEncoding
+
+	// Stream describes the items of the body, when they're streamed rather
+	// than buffered, using the `x-oapi-codegen-stream` extension
+	Stream *StreamDefinition
+
+	// XML describes the root element of the body, when it's an XML media
+	// type, and `xml-bodies` are generated
+	XML *XMLElementDefinition
 }
 
 // TypeDef returns the Go type definition for a request body
@@ -421,7 +476,7 @@ func (r RequestBodyDefinition) Suffix() string {
 
 // IsSupportedByClient returns true if we support this content type for client. Otherwise only generic method will ge generated
 func (r RequestBodyDefinition) IsSupportedByClient() bool {
-	return r.IsJSON() || r.NameTag == "Formdata" || r.NameTag == "Text"
+	return r.IsJSON() || r.IsXML() || r.NameTag == "Formdata" || r.NameTag == "Text"
 }
 
 // IsJSON returns whether this is a JSON media type, for instance:
@@ -432,6 +487,20 @@ func (r RequestBodyDefinition) IsJSON() bool {
 	return util.IsMediaTypeJson(r.ContentType)
 }
 
+// IsXML returns whether this is an XML media type, which is encoded and
+// decoded as XML, for instance:
+// - application/xml
+// - text/xml
+// - application/atom+xml
+func (r RequestBodyDefinition) IsXML() bool {
+	return r.XML != nil
+}
+
+// IsStream returns whether the items of the body are streamed.
+func (r RequestBodyDefinition) IsStream() bool {
+	return r.Stream != nil
+}
+
 // IsSupported returns true if we support this content type for server. Otherwise io.Reader will be generated
 func (r RequestBodyDefinition) IsSupported() bool {
 	return r.NameTag != ""
@@ -454,6 +523,14 @@ type ResponseDefinition struct {
 	Contents    []ResponseContentDefinition
 	Headers     []ResponseHeaderDefinition
 	Ref         string
+
+	// Negotiated describes the media types of the response, when the strict
+	// server chooses between them with the Accept header of each request
+	Negotiated *NegotiatedResponseDefinition
+
+	// state is the state of the generation which the response was described
+	// in
+	state *generatorState
 }
 
 func (r ResponseDefinition) HasFixedStatusCode() bool {
@@ -462,7 +539,7 @@ func (r ResponseDefinition) HasFixedStatusCode() bool {
 }
 
 func (r ResponseDefinition) GoName() string {
-	return SchemaNameToTypeName(r.StatusCode)
+	return r.state.orDefault().schemaNameToTypeName(r.StatusCode)
 }
 
 func (r ResponseDefinition) IsRef() bool {
@@ -486,6 +563,18 @@ type ResponseContentDefinition struct {
 	// When we generate type names, we need a Tag for it, such as JSON, in
 	// which case we will produce "Response200JSONContent".
 	NameTag string
+
+	// Stream describes the items of the content, when they're streamed
+	// rather than buffered, using the `x-oapi-codegen-stream` extension
+	Stream *StreamDefinition
+
+	// XML describes the root element of the content, when it's an XML media
+	// type, and `xml-bodies` are generated
+	XML *XMLElementDefinition
+
+	// state is the state of the generation which the content was described
+	// in
+	state *generatorState
 }
 
 // TypeDef returns the Go type definition for a request body
@@ -509,7 +598,7 @@ func (r ResponseContentDefinition) NameTagOrContentType() string {
 	if r.NameTag != "" {
 		return r.NameTag
 	}
-	return SchemaNameToTypeName(r.ContentType)
+	return r.state.orDefault().schemaNameToTypeName(r.ContentType)
 }
 
 // IsJSON returns whether this is a JSON media type, for instance:
@@ -520,6 +609,26 @@ func (r ResponseContentDefinition) IsJSON() bool {
 	return util.IsMediaTypeJson(r.ContentType)
 }
 
+// IsXML returns whether this is an XML media type, which is encoded and
+// decoded as XML, for instance:
+// - application/xml
+// - text/xml
+// - application/problem+xml
+func (r ResponseContentDefinition) IsXML() bool {
+	return r.XML != nil
+}
+
+// IsStream returns whether the items of the content are streamed.
+func (r ResponseContentDefinition) IsStream() bool {
+	return r.Stream != nil
+}
+
+// IsEventStream returns whether the content is a stream of Server-Sent
+// Events, whose data is described by the schema.
+func (r ResponseContentDefinition) IsEventStream() bool {
+	return r.NameTag == "EventStream"
+}
+
 type ResponseHeaderDefinition struct {
 	Name   string
 	GoName string
@@ -540,11 +649,17 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 
 // OperationDefinitions returns all operations for a swagger definition.
 func OperationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]OperationDefinition, error) {
+	gs := packageGeneratorState()
+	gs.spec = swagger
+	return gs.operationDefinitions(swagger, initialismOverrides)
+}
+
+func (gs *generatorState) operationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]OperationDefinition, error) {
 	var operations []OperationDefinition
 
 	var toCamelCaseFunc func(string) string
 	if initialismOverrides {
-		toCamelCaseFunc = ToCamelCaseWithInitialism
+		toCamelCaseFunc = gs.toCamelCaseWithInitialism
 	} else {
 		toCamelCaseFunc = ToCamelCase
 	}
@@ -555,125 +670,154 @@ func OperationDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]Oper
 
 	for _, requestPath := range SortedMapKeys(swagger.Paths.Map()) {
 		pathItem := swagger.Paths.Value(requestPath)
-		// These are parameters defined for all methods on a given path. They
-		// are shared by all methods.
-		globalParams, err := DescribeParameters(pathItem.Parameters, nil)
+		pathOperations, err := gs.describePathItemOperations(swagger, requestPath, pathItem, toCamelCaseFunc)
 		if err != nil {
-			return nil, fmt.Errorf("error describing global parameters for %s: %s",
-				requestPath, err)
+			return nil, err
 		}
+		operations = append(operations, pathOperations...)
+	}
+	return operations, nil
+}
 
-		// Each path can have a number of operations, POST, GET, OPTIONS, etc.
-		pathOps := pathItem.Operations()
-		for _, opName := range SortedMapKeys(pathOps) {
-			// NOTE that this is a reference to the existing copy of the Operation, so any modifications will modify our shared copy of the spec
-			op := pathOps[opName]
+// describePathItemOperations generates an OperationDefinition for each operation
+// of the given PathItem. The requestPath is the key the PathItem was found
+// under, which is a URL path for `paths`, but may also be the name of a webhook.
+func (gs *generatorState) describePathItemOperations(swagger *openapi3.T, requestPath string, pathItem *openapi3.PathItem, toCamelCaseFunc func(string) string) ([]OperationDefinition, error) {
+	var operations []OperationDefinition
 
-			if pathItem.Servers != nil {
-				op.Servers = &pathItem.Servers
-			}
-			// take a copy of operationId, so we don't modify the underlying spec
-			operationId := op.OperationID
-			// We rely on OperationID to generate function names, it's required
-			if operationId == "" {
-				operationId, err = generateDefaultOperationID(opName, requestPath, toCamelCaseFunc)
-				if err != nil {
-					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
-						opName, requestPath, err)
-				}
-			} else {
-				operationId = nameNormalizer(operationId)
-			}
-			operationId = typeNamePrefix(operationId) + operationId
+	// These are parameters defined for all methods on a given path. They
+	// are shared by all methods.
+	globalParams, err := gs.describeParameters(pathItem.Parameters, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error describing global parameters for %s: %s",
+			requestPath, err)
+	}
 
-			if !globalState.options.Compatibility.PreserveOriginalOperationIdCasingInEmbeddedSpec {
-				// update the existing, shared, copy of the spec if we're not wanting to preserve it
-				op.OperationID = operationId
-			}
+	// Each path can have a number of operations, POST, GET, OPTIONS, etc.
+	pathOps := pathItem.Operations()
+	for _, opName := range SortedMapKeys(pathOps) {
+		// NOTE that this is a reference to the existing copy of the Operation, so any modifications will modify our shared copy of the spec
+		op := pathOps[opName]
 
-			// These are parameters defined for the specific path method that
-			// we're iterating over.
-			localParams, err := DescribeParameters(op.Parameters, []string{operationId + "Params"})
+		if pathItem.Servers != nil {
+			op.Servers = &pathItem.Servers
+		}
+		// take a copy of operationId, so we don't modify the underlying spec
+		operationId := op.OperationID
+		// We rely on OperationID to generate function names, it's required
+		if operationId == "" {
+			operationId, err = gs.generateDefaultOperationID(opName, requestPath, toCamelCaseFunc)
 			if err != nil {
-				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
+				return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
 					opName, requestPath, err)
 			}
-			// All the parameters required by a handler are the union of the
-			// global parameters and the local parameters.
-			allParams, err := CombineOperationParameters(globalParams, localParams)
-			if err != nil {
-				return nil, err
-			}
+		} else {
+			operationId = gs.nameNormalizer(operationId)
+		}
+		operationId = typeNamePrefix(operationId) + operationId
 
-			ensureExternalRefsInParameterDefinitions(&allParams, pathItem.Ref)
+		if !gs.options.Compatibility.PreserveOriginalOperationIdCasingInEmbeddedSpec {
+			// update the existing, shared, copy of the spec if we're not wanting to preserve it
+			op.OperationID = operationId
+		}
 
-			// Order the path parameters to match the order as specified in
-			// the path, not in the swagger spec, and validate that the parameter
-			// names match, as downstream code depends on that.
-			pathParams := FilterParameterDefinitionByType(allParams, "path")
-			pathParams, err = SortParamsByPath(requestPath, pathParams)
-			if err != nil {
-				return nil, err
-			}
+		// These are parameters defined for the specific path method that
+		// we're iterating over.
+		localParams, err := gs.describeParameters(op.Parameters, []string{operationId + "Params"})
+		if err != nil {
+			return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
+				opName, requestPath, err)
+		}
+		// All the parameters required by a handler are the union of the
+		// global parameters and the local parameters.
+		allParams, err := CombineOperationParameters(globalParams, localParams)
+		if err != nil {
+			return nil, err
+		}
 
-			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody)
-			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions: %w", err)
-			}
+		gs.ensureExternalRefsInParameterDefinitions(&allParams, pathItem.Ref)
 
-			ensureExternalRefsInRequestBodyDefinitions(&bodyDefinitions, pathItem.Ref)
+		// Order the path parameters to match the order as specified in
+		// the path, not in the swagger spec, and validate that the parameter
+		// names match, as downstream code depends on that.
+		pathParams := FilterParameterDefinitionByType(allParams, "path")
+		pathParams, err = SortParamsByPath(requestPath, pathParams)
+		if err != nil {
+			return nil, err
+		}
 
-			responseDefinitions, err := GenerateResponseDefinitions(operationId, op.Responses.Map())
-			if err != nil {
-				return nil, fmt.Errorf("error generating response definitions: %w", err)
-			}
+		bodyDefinitions, typeDefinitions, err := gs.generateBodyDefinitions(operationId, op.RequestBody)
+		if err != nil {
+			return nil, fmt.Errorf("error generating body definitions: %w", err)
+		}
 
-			ensureExternalRefsInResponseDefinitions(&responseDefinitions, pathItem.Ref)
-
-			opDef := OperationDefinition{
-				PathParams:   pathParams,
-				HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
-				QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
-				CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
-				OperationId:  nameNormalizer(operationId),
-				// Replace newlines in summary.
-				Summary:         op.Summary,
-				Method:          opName,
-				Path:            requestPath,
-				Spec:            op,
-				Bodies:          bodyDefinitions,
-				Responses:       responseDefinitions,
-				TypeDefinitions: typeDefinitions,
-			}
+		gs.ensureExternalRefsInRequestBodyDefinitions(&bodyDefinitions, pathItem.Ref)
 
-			// check for overrides of SecurityDefinitions.
-			// See: "Step 2. Applying security:" from the spec:
-			// https://swagger.io/docs/specification/authentication/
-			if op.Security != nil {
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
-			} else {
-				// use global securityDefinitions
-				// globalSecurityDefinitions contains the top-level securityDefinitions.
-				// They are the default securityPermissions which are injected into each
-				// path, except for the case where a path explicitly overrides them.
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+		responseDefinitions, err := gs.generateResponseDefinitions(operationId, op.Responses.Map())
+		if err != nil {
+			return nil, fmt.Errorf("error generating response definitions: %w", err)
+		}
 
-			}
+		gs.ensureExternalRefsInResponseDefinitions(&responseDefinitions, pathItem.Ref)
+
+		opDef := OperationDefinition{
+			PathParams:   pathParams,
+			HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
+			QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
+			CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
+			OperationId:  gs.nameNormalizer(operationId),
+			// Replace newlines in summary.
+			Summary:         op.Summary,
+			Method:          opName,
+			Path:            requestPath,
+			Spec:            op,
+			Bodies:          bodyDefinitions,
+			Responses:       responseDefinitions,
+			TypeDefinitions: typeDefinitions,
+			state:           gs,
+		}
 
-			if op.RequestBody != nil {
-				opDef.BodyRequired = op.RequestBody.Value.Required
-			}
+		// check for overrides of SecurityDefinitions.
+		// See: "Step 2. Applying security:" from the spec:
+		// https://swagger.io/docs/specification/authentication/
+		if op.Security != nil {
+			opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
+			opDef.SecurityRequirements = DescribeSecurityRequirements(*op.Security)
+		} else {
+			// use global securityDefinitions
+			// globalSecurityDefinitions contains the top-level securityDefinitions.
+			// They are the default securityPermissions which are injected into each
+			// path, except for the case where a path explicitly overrides them.
+			opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+			opDef.SecurityRequirements = DescribeSecurityRequirements(swagger.Security)
 
-			// Generate all the type definitions needed for this operation
-			opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
+		}
 
-			operations = append(operations, opDef)
+		if op.RequestBody != nil {
+			opDef.BodyRequired = op.RequestBody.Value.Required
 		}
+
+		if extension, ok := op.Extensions[extOapiCodegenRetryable]; ok {
+			opDef.Retryable, err = extParseOapiCodegenRetryable(extension)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q in %s: %w", extOapiCodegenRetryable, operationId, err)
+			}
+		}
+
+		opDef.Pagination, err = gs.describePagination(opDef)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q in %s: %w", extOapiCodegenPagination, operationId, err)
+		}
+
+		// Generate all the type definitions needed for this operation
+		opDef.TypeDefinitions = append(opDef.TypeDefinitions, gs.generateTypeDefsForOperation(opDef)...)
+
+		operations = append(operations, opDef)
 	}
 	return operations, nil
 }
 
-func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFunc func(string) string) (string, error) {
+func (gs *generatorState) generateDefaultOperationID(opName string, requestPath string, toCamelCaseFunc func(string) string) (string, error) {
 	var operationId = strings.ToLower(opName)
 
 	if opName == "" {
@@ -690,12 +834,16 @@ func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFu
 		}
 	}
 
-	return nameNormalizer(operationId), nil
+	return gs.nameNormalizer(operationId), nil
 }
 
 // GenerateBodyDefinitions turns the Swagger body definitions into a list of our body
 // definitions which will be used for code generation.
 func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
+	return packageGeneratorState().generateBodyDefinitions(operationID, bodyOrRef)
+}
+
+func (gs *generatorState) generateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
 	if bodyOrRef == nil {
 		return nil, nil, nil
 	}
@@ -709,12 +857,27 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		var tag string
 		var defaultBody bool
 
+		streamed, err := isStreamed(content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating request body definition for %s: %w", contentType, err)
+		}
+		format, streamTag := streamFormat(contentType)
+		if streamed && format == "" {
+			return nil, nil, fmt.Errorf("error generating request body definition: %w", errNotStreamable(contentType))
+		}
+
 		switch {
 		case contentType == "application/json":
 			tag = "JSON"
 			defaultBody = true
 		case util.IsMediaTypeJson(contentType):
-			tag = mediaTypeToCamelCase(contentType)
+			tag = gs.mediaTypeToCamelCase(contentType)
+		case gs.options.OutputOptions.XMLBodies && contentType == "application/xml":
+			tag = "XML"
+		case gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentType):
+			tag = gs.mediaTypeToCamelCase(contentType)
+		case streamed && streamTag != "":
+			tag = streamTag
 		case strings.HasPrefix(contentType, "multipart/"):
 			tag = "Multipart"
 		case contentType == "application/x-www-form-urlencoded":
@@ -722,6 +885,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		case contentType == "text/plain":
 			tag = "Text"
 		default:
+			gs.diagnoseUnsupportedContentType(content, contentType, "request")
 			bd := RequestBodyDefinition{
 				Required:    body.Required,
 				ContentType: contentType,
@@ -731,7 +895,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		}
 
 		bodyTypeName := operationID + tag + "Body"
-		bodySchema, err := GenerateGoSchema(content.Schema, []string{bodyTypeName})
+		bodySchema, err := gs.generateGoSchema(content.Schema, []string{bodyTypeName})
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
 		}
@@ -739,7 +903,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		// If the body is a pre-defined type
 		if content.Schema != nil && IsGoTypeReference(content.Schema.Ref) {
 			// Convert the reference path to Go type
-			refType, err := RefPathToGoType(content.Schema.Ref)
+			refType, err := gs.refPathToGoType(content.Schema.Ref, true)
 			if err != nil {
 				return nil, nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", content.Schema.Ref, err)
 			}
@@ -758,7 +922,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 				}
 
 				// Regenerate the Golang struct adding the new form tag.
-				bodySchema.GoType = GenStructFromSchema(bodySchema)
+				bodySchema.GoType = gs.genStructFromSchema(bodySchema)
 			}
 
 			td := TypeDefinition{
@@ -777,6 +941,16 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 			ContentType: contentType,
 			Default:     defaultBody,
 		}
+		if gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentType) {
+			bd.XML = describeXMLElement(content.Schema, bodyTypeName)
+		}
+
+		if streamed {
+			bd.Stream, err = gs.describeStream(contentType, content, bodySchema, []string{bodyTypeName})
+			if err != nil {
+				return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
+			}
+		}
 
 		if len(content.Encoding) != 0 {
 			bd.Encoding = make(map[string]RequestBodyEncoding)
@@ -795,6 +969,10 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 }
 
 func GenerateResponseDefinitions(operationID string, responses map[string]*openapi3.ResponseRef) ([]ResponseDefinition, error) {
+	return packageGeneratorState().generateResponseDefinitions(operationID, responses)
+}
+
+func (gs *generatorState) generateResponseDefinitions(operationID string, responses map[string]*openapi3.ResponseRef) ([]ResponseDefinition, error) {
 	var responseDefinitions []ResponseDefinition
 	// do not let multiple status codes ref to same response, it will break the type switch
 	refSet := make(map[string]struct{})
@@ -811,27 +989,48 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 		for _, contentType := range SortedMapKeys(response.Content) {
 			content := response.Content[contentType]
 			var tag string
+
+			streamed, err := isStreamed(content)
+			if err != nil {
+				return nil, fmt.Errorf("error generating response definition for %s: %w", contentType, err)
+			}
+			format, streamTag := streamFormat(contentType)
+			if streamed && format == "" {
+				return nil, fmt.Errorf("error generating response definition: %w", errNotStreamable(contentType))
+			}
+
 			switch {
 			case contentType == "application/json":
 				tag = "JSON"
 			case util.IsMediaTypeJson(contentType):
-				tag = mediaTypeToCamelCase(contentType)
+				tag = gs.mediaTypeToCamelCase(contentType)
+			case gs.options.OutputOptions.XMLBodies && contentType == "application/xml":
+				tag = "XML"
+			case gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentType):
+				tag = gs.mediaTypeToCamelCase(contentType)
+			case streamed && streamTag != "":
+				tag = streamTag
 			case contentType == "application/x-www-form-urlencoded":
 				tag = "Formdata"
 			case strings.HasPrefix(contentType, "multipart/"):
 				tag = "Multipart"
 			case contentType == "text/plain":
 				tag = "Text"
+			case contentType == contentTypeEventStream && content.Schema != nil:
+				// the schema describes the data of each event
+				tag = "EventStream"
 			default:
+				gs.diagnoseUnsupportedContentType(content, contentType, "response")
 				rcd := ResponseContentDefinition{
 					ContentType: contentType,
+					state:       gs,
 				}
 				responseContentDefinitions = append(responseContentDefinitions, rcd)
 				continue
 			}
 
 			responseTypeName := operationID + statusCode + tag + "Response"
-			contentSchema, err := GenerateGoSchema(content.Schema, []string{responseTypeName})
+			contentSchema, err := gs.generateGoSchema(content.Schema, []string{responseTypeName})
 			if err != nil {
 				return nil, fmt.Errorf("error generating request body definition: %w", err)
 			}
@@ -840,6 +1039,17 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 				ContentType: contentType,
 				NameTag:     tag,
 				Schema:      contentSchema,
+				state:       gs,
+			}
+			if gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentType) {
+				rcd.XML = describeXMLElement(content.Schema, responseTypeName)
+			}
+
+			if streamed {
+				rcd.Stream, err = gs.describeStream(contentType, content, contentSchema, []string{responseTypeName})
+				if err != nil {
+					return nil, fmt.Errorf("error generating response definition: %w", err)
+				}
 			}
 
 			responseContentDefinitions = append(responseContentDefinitions, rcd)
@@ -848,11 +1058,11 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 		var responseHeaderDefinitions []ResponseHeaderDefinition
 		for _, headerName := range SortedMapKeys(response.Headers) {
 			header := response.Headers[headerName]
-			contentSchema, err := GenerateGoSchema(header.Value.Schema, []string{})
+			contentSchema, err := gs.generateGoSchema(header.Value.Schema, []string{})
 			if err != nil {
 				return nil, fmt.Errorf("error generating response header definition: %w", err)
 			}
-			headerDefinition := ResponseHeaderDefinition{Name: headerName, GoName: SchemaNameToTypeName(headerName), Schema: contentSchema}
+			headerDefinition := ResponseHeaderDefinition{Name: headerName, GoName: gs.schemaNameToTypeName(headerName), Schema: contentSchema}
 			responseHeaderDefinitions = append(responseHeaderDefinitions, headerDefinition)
 		}
 
@@ -860,13 +1070,19 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 			StatusCode: statusCode,
 			Contents:   responseContentDefinitions,
 			Headers:    responseHeaderDefinitions,
+			state:      gs,
 		}
 		if response.Description != nil {
 			rd.Description = *response.Description
 		}
+		negotiated, err := gs.describeNegotiatedResponse(response, responseContentDefinitions, []string{operationID + statusCode + "NegotiatedResponse"})
+		if err != nil {
+			return nil, fmt.Errorf("error generating response definition for %s: %w", statusCode, err)
+		}
+		rd.Negotiated = negotiated
 		if IsGoTypeReference(responseOrRef.Ref) {
 			// Convert the reference path to Go type
-			refType, err := RefPathToGoType(responseOrRef.Ref)
+			refType, err := gs.refPathToGoType(responseOrRef.Ref, true)
 			if err != nil {
 				return nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", responseOrRef.Ref, err)
 			}
@@ -885,10 +1101,14 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 }
 
 func GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
+	return packageGeneratorState().generateTypeDefsForOperation(op)
+}
+
+func (gs *generatorState) generateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
 	var typeDefs []TypeDefinition
 	// Start with the params object itself
 	if len(op.Params()) != 0 {
-		typeDefs = append(typeDefs, GenerateParamsTypes(op)...)
+		typeDefs = append(typeDefs, gs.generateParamsTypes(op)...)
 	}
 
 	// Now, go through all the additional types we need to declare.
@@ -905,6 +1125,10 @@ func GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
 // GenerateParamsTypes defines the schema for a parameters definition object
 // which encapsulates all the query, header and cookie parameters for an operation.
 func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
+	return packageGeneratorState().generateParamsTypes(op)
+}
+
+func (gs *generatorState) generateParamsTypes(op OperationDefinition) []TypeDefinition {
 	var typeDefs []TypeDefinition
 
 	objectParams := op.QueryParams
@@ -932,12 +1156,13 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 			Schema:        pSchema,
 			NeedsFormTag:  param.Style() == "form",
 			Extensions:    param.Spec.Extensions,
+			state:         gs,
 		}
 		s.Properties = append(s.Properties, prop)
 	}
 
 	s.Description = op.Spec.Description
-	s.GoType = GenStructFromSchema(s)
+	s.GoType = gs.genStructFromSchema(s)
 
 	td := TypeDefinition{
 		TypeName: typeName,
@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// PaginationType is the way in which the pages of an operation are requested,
+// from the `type` of the `x-oapi-codegen-pagination` extension.
+type PaginationType string
+
+const (
+	// PaginationTypeCursor requests each page by setting the `parameter` to the
+	// cursor found at the `next` field of the previous page, until there's none
+	PaginationTypeCursor PaginationType = "cursor"
+	// PaginationTypeOffset requests each page by increasing the `parameter` by
+	// the number of `items` in the previous page, until a page has none
+	PaginationTypeOffset PaginationType = "offset"
+	// PaginationTypeLink requests each page from the URL of the `next` link, in
+	// the `Link` header of the previous page, until there's none
+	PaginationTypeLink PaginationType = "link"
+)
+
+// PaginationDefinition describes how to iterate over the pages of an
+// operation's responses, from its `x-oapi-codegen-pagination` extension, such
+// as:
+//
+//	x-oapi-codegen-pagination:
+//	  type: cursor
+//	  parameter: cursor
+//	  next: meta.nextCursor
+//	  items: data
+type PaginationDefinition struct {
+	Type PaginationType
+	// Parameter is the query parameter which requests a page, for the cursor
+	// and offset types
+	Parameter *ParameterDefinition
+	// NextPath is the path to the field of a successful response's JSON body
+	// with the cursor of the next page, for the cursor type
+	NextPath []string
+	// ItemsPath is the path to the field of a successful response's JSON body
+	// with the items of the page, which is empty when the body is the items
+	ItemsPath []string
+	// ItemType is the Go type of each of the items, which is empty when the
+	// items of the pages aren't described
+	ItemType string
+}
+
+// HasItems returns whether the items of each page can be iterated over.
+func (p PaginationDefinition) HasItems() bool {
+	return p.ItemType != ""
+}
+
+// describePagination parses the `x-oapi-codegen-pagination` extension of an
+// operation, if it has one.
+func (gs *generatorState) describePagination(op OperationDefinition) (*PaginationDefinition, error) {
+	extension, ok := op.Spec.Extensions[extOapiCodegenPagination]
+	if !ok {
+		return nil, nil
+	}
+	config, err := extParseOapiCodegenPagination(extension)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := &PaginationDefinition{
+		Type:      PaginationType(config["type"]),
+		NextPath:  splitPaginationPath(config["next"]),
+		ItemsPath: splitPaginationPath(config["items"]),
+	}
+
+	switch pagination.Type {
+	case PaginationTypeCursor, PaginationTypeOffset:
+		for i := range op.QueryParams {
+			if op.QueryParams[i].ParamName == config["parameter"] {
+				pagination.Parameter = &op.QueryParams[i]
+			}
+		}
+		if pagination.Parameter == nil {
+			return nil, fmt.Errorf("the %s pagination needs the `parameter` to be one of the operation's query parameters, but it's %q", pagination.Type, config["parameter"])
+		}
+	case PaginationTypeLink:
+	default:
+		return nil, fmt.Errorf("the `type` must be one of %q, %q or %q, but it's %q", PaginationTypeCursor, PaginationTypeOffset, PaginationTypeLink, pagination.Type)
+	}
+	if pagination.Type == PaginationTypeCursor && len(pagination.NextPath) == 0 {
+		return nil, fmt.Errorf("the cursor pagination needs the `next` field of the response, which has the next cursor")
+	}
+	if pagination.Type == PaginationTypeOffset && config["items"] == "" {
+		return nil, fmt.Errorf("the offset pagination needs the `items` field of the response, which has the page's items")
+	}
+
+	body := paginationResponseSchema(op)
+	if body == nil {
+		return nil, fmt.Errorf("the operation needs a successful response with a JSON body to be paginated")
+	}
+	if len(pagination.NextPath) > 0 {
+		if _, err := paginationField(body, pagination.NextPath); err != nil {
+			return nil, fmt.Errorf("can't find the `next` field: %w", err)
+		}
+	}
+
+	items, err := paginationField(body, pagination.ItemsPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't find the `items` field: %w", err)
+	}
+	if items.Value == nil || !items.Value.Type.Is("array") {
+		// the body isn't the items, when there's no `items` field
+		if config["items"] != "" {
+			return nil, fmt.Errorf("the `items` field must be an array")
+		}
+		return pagination, nil
+	}
+	itemSchema, err := gs.generateGoSchema(items.Value.Items, []string{op.OperationId, "Item"})
+	if err != nil {
+		return nil, fmt.Errorf("error generating the type of the items: %w", err)
+	}
+	pagination.ItemType = itemSchema.TypeDecl()
+
+	return pagination, nil
+}
+
+func splitPaginationPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// paginationResponseSchema returns the schema of the JSON body of the first
+// successful response of the operation.
+func paginationResponseSchema(op OperationDefinition) *openapi3.SchemaRef {
+	if op.Spec.Responses == nil {
+		return nil
+	}
+	for _, code := range SortedMapKeys(op.Spec.Responses.Map()) {
+		response := op.Spec.Responses.Value(code)
+		if !strings.HasPrefix(code, "2") || response == nil || response.Value == nil {
+			continue
+		}
+		if mediaType := response.Value.Content.Get("application/json"); mediaType != nil && mediaType.Schema != nil {
+			return mediaType.Schema
+		}
+	}
+	return nil
+}
+
+// paginationField returns the schema of the field at the path within the
+// schema, looking through the properties of the schema, and of each of its
+// `allOf` elements.
+func paginationField(schema *openapi3.SchemaRef, path []string) (*openapi3.SchemaRef, error) {
+	for i, name := range path {
+		var field *openapi3.SchemaRef
+		if schema != nil && schema.Value != nil {
+			field = schema.Value.Properties[name]
+			for _, element := range schema.Value.AllOf {
+				if field == nil && element.Value != nil {
+					field = element.Value.Properties[name]
+				}
+			}
+		}
+		if field == nil {
+			return nil, fmt.Errorf("the response has no field %s", strings.Join(path[:i+1], "."))
+		}
+		schema = field
+	}
+	return schema, nil
+}
+
+// GeneratePagination generates the `<Op>Pages` and `<Op>Items` methods of
+// the ClientWithResponses, for each operation with the
+// `x-oapi-codegen-pagination` extension.
+func GeneratePagination(t *template.Template, ops []OperationDefinition) (string, error) {
+	var paginated []OperationDefinition
+	for _, op := range ops {
+		if op.Pagination != nil {
+			paginated = append(paginated, op)
+		}
+	}
+	if len(paginated) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"client-pagination.tmpl"}, t, paginated)
+}
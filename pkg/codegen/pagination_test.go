@@ -0,0 +1,227 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paginationOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pagination
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-oapi-codegen-pagination:
+        type: cursor
+        parameter: cursor
+        next: meta.nextCursor
+        items: data
+      parameters:
+        - name: cursor
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: A page of pets
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - type: object
+                    properties:
+                      data:
+                        type: array
+                        items:
+                          $ref: '#/components/schemas/Pet'
+                  - type: object
+                    properties:
+                      meta:
+                        type: object
+                        properties:
+                          nextCursor:
+                            type: string
+  /owners/{ownerId}/pets:
+    get:
+      operationId: listOwnerPets
+      x-oapi-codegen-pagination:
+        type: offset
+        parameter: offset
+        items: pets
+      parameters:
+        - name: ownerId
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: offset
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: A page of the owner's pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pets:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Pet'
+  /toys:
+    get:
+      operationId: listToys
+      x-oapi-codegen-pagination:
+        type: link
+      responses:
+        '200':
+          description: A page of toys
+          content:
+            application/json:
+              schema:
+                type: object
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        '200':
+          description: The owners
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestGeneratePagination(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(paginationOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "func (c *ClientWithResponses) ListPetsPages(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[*ListPetsResponse, error] {")
+	assert.Contains(t, code, `cursor, err := paginationField(response.Body, "meta", "nextCursor")`)
+	assert.Contains(t, code, "if err := json.Unmarshal(cursor, &pageParams.Cursor); err != nil {")
+	assert.Contains(t, code, "func (c *ClientWithResponses) ListPetsItems(ctx context.Context, params *ListPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[Pet, error] {")
+
+	assert.Contains(t, code, "func (c *ClientWithResponses) ListOwnerPetsPages(ctx context.Context, ownerId string, params *ListOwnerPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[*ListOwnerPetsResponse, error] {")
+	assert.Contains(t, code, "offset, err := paginationOffset(pageParams.Offset)")
+	assert.Contains(t, code, "func (c *ClientWithResponses) ListOwnerPetsItems(ctx context.Context, ownerId string, params *ListOwnerPetsParams, reqEditors ...RequestEditorFn) iter.Seq2[Pet, error] {")
+
+	// the body of the toys isn't an array, so only its pages can be iterated over
+	assert.Contains(t, code, "func (c *ClientWithResponses) ListToysPages(ctx context.Context, reqEditors ...RequestEditorFn) iter.Seq2[*ListToysResponse, error] {")
+	assert.Contains(t, code, "if next, err = paginationNextLink(response.HTTPResponse); err != nil {")
+	assert.NotContains(t, code, "ListToysItems")
+
+	assert.NotContains(t, code, "ListOwnersPages")
+}
+
+func TestGeneratePaginationWithoutPaginatedOperations(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(paginationOpenAPIDefinition))
+	require.NoError(t, err)
+	for _, path := range swagger.Paths.Map() {
+		delete(path.Get.Extensions, extOapiCodegenPagination)
+	}
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, code, "paginationField")
+	assert.NotContains(t, code, "iter.Seq2")
+}
+
+func TestInvalidPagination(t *testing.T) {
+	tests := map[string]struct {
+		path       string
+		pagination map[string]interface{}
+		err        string
+	}{
+		"unknown type": {
+			path:       "/toys",
+			pagination: map[string]interface{}{"type": "page"},
+			err:        "the `type` must be one of",
+		},
+		"unknown key": {
+			path:       "/toys",
+			pagination: map[string]interface{}{"type": "link", "size": "limit"},
+			err:        "size",
+		},
+		"missing parameter": {
+			path:       "/pets",
+			pagination: map[string]interface{}{"type": "cursor", "parameter": "after", "next": "meta.nextCursor"},
+			err:        `but it's "after"`,
+		},
+		"missing next": {
+			path:       "/pets",
+			pagination: map[string]interface{}{"type": "cursor", "parameter": "cursor"},
+			err:        "needs the `next` field",
+		},
+		"unknown next": {
+			path:       "/pets",
+			pagination: map[string]interface{}{"type": "cursor", "parameter": "cursor", "next": "meta.cursor"},
+			err:        "the response has no field meta.cursor",
+		},
+		"missing items": {
+			path:       "/owners/{ownerId}/pets",
+			pagination: map[string]interface{}{"type": "offset", "parameter": "offset"},
+			err:        "needs the `items` field",
+		},
+		"items which aren't an array": {
+			path:       "/pets",
+			pagination: map[string]interface{}{"type": "cursor", "parameter": "cursor", "next": "meta.nextCursor", "items": "meta"},
+			err:        "the `items` field must be an array",
+		},
+		"no JSON body": {
+			path:       "/owners",
+			pagination: map[string]interface{}{"type": "link"},
+			err:        "successful response with a JSON body",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			swagger, err := openapi3.NewLoader().LoadFromData([]byte(paginationOpenAPIDefinition))
+			require.NoError(t, err)
+			op := swagger.Paths.Value(tt.path).Get
+			if op.Extensions == nil {
+				op.Extensions = map[string]interface{}{}
+			}
+			op.Extensions[extOapiCodegenPagination] = tt.pagination
+
+			_, err = Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate: GenerateOptions{
+					Models: true,
+					Client: true,
+				},
+			})
+			assert.ErrorContains(t, err, tt.err)
+			assert.ErrorContains(t, err, extOapiCodegenPagination)
+		})
+	}
+}
@@ -22,16 +22,21 @@ type RefWrapper struct {
 }
 
 func walkSwagger(swagger *openapi3.T, doFn func(RefWrapper) (bool, error)) error {
-	if swagger == nil || swagger.Paths == nil {
+	if swagger == nil {
 		return nil
 	}
 
-	for _, p := range swagger.Paths.Map() {
-		for _, param := range p.Parameters {
-			_ = walkParameterRef(param, doFn)
+	if swagger.Paths != nil {
+		for _, p := range swagger.Paths.Map() {
+			_ = walkPathItem(p, doFn)
 		}
-		for _, op := range p.Operations() {
-			_ = walkOperation(op, doFn)
+	}
+
+	// Webhooks are only available as an untyped extension, so any that can't
+	// be decoded are skipped, as they will fail generation later on
+	if webhooks, err := decodeWebhooks(swagger); err == nil {
+		for _, p := range webhooks {
+			_ = walkPathItem(p, doFn)
 		}
 	}
 
@@ -40,6 +45,22 @@ func walkSwagger(swagger *openapi3.T, doFn func(RefWrapper) (bool, error)) error
 	return nil
 }
 
+func walkPathItem(pathItem *openapi3.PathItem, doFn func(RefWrapper) (bool, error)) error {
+	// Not a valid ref, ignore it and continue
+	if pathItem == nil {
+		return nil
+	}
+
+	for _, param := range pathItem.Parameters {
+		_ = walkParameterRef(param, doFn)
+	}
+	for _, op := range pathItem.Operations() {
+		_ = walkOperation(op, doFn)
+	}
+
+	return nil
+}
+
 func walkOperation(op *openapi3.Operation, doFn func(RefWrapper) (bool, error)) error {
 	// Not a valid ref, ignore it and continue
 	if op == nil {
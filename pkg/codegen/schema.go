@@ -90,9 +90,14 @@ type Property struct {
 	NeedsFormTag  bool
 	Extensions    map[string]interface{}
 	Deprecated    bool
+
+	// state is the state of the generation which the property was generated
+	// in
+	state *generatorState
 }
 
 func (p Property) GoFieldName() string {
+	gs := p.state.orDefault()
 	goFieldName := p.JsonFieldName
 	if extension, ok := p.Extensions[extGoName]; ok {
 		if extGoFieldName, err := extParseGoFieldName(extension); err == nil {
@@ -100,7 +105,7 @@ func (p Property) GoFieldName() string {
 		}
 	}
 
-	if globalState.options.Compatibility.AllowUnexportedStructFieldNames {
+	if gs.options.Compatibility.AllowUnexportedStructFieldNames {
 		if extension, ok := p.Extensions[extOapiCodegenOnlyHonourGoName]; ok {
 			if extOapiCodegenOnlyHonourGoName, err := extParseOapiCodegenOnlyHonourGoName(extension); err == nil {
 				if extOapiCodegenOnlyHonourGoName {
@@ -110,17 +115,18 @@ func (p Property) GoFieldName() string {
 		}
 	}
 
-	return SchemaNameToTypeName(goFieldName)
+	return gs.schemaNameToTypeName(goFieldName)
 }
 
 func (p Property) GoTypeDef() string {
+	gs := p.state.orDefault()
 	typeDef := p.Schema.TypeDecl()
-	if globalState.options.OutputOptions.NullableType && p.Nullable {
+	if gs.options.OutputOptions.NullableType && p.Nullable {
 		return "nullable.Nullable[" + typeDef + "]"
 	}
 	if !p.Schema.SkipOptionalPointer &&
 		(!p.Required || p.Nullable ||
-			(p.ReadOnly && (!p.Required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
+			(p.ReadOnly && (!p.Required || !gs.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
 			p.WriteOnly) {
 
 		typeDef = "*" + typeDef
@@ -166,6 +172,12 @@ func (e *EnumDefinition) GetValues() map[string]string {
 	return newValues
 }
 
+// GetValueNames returns the names of the enum's values, as returned by
+// GetValues, in order.
+func (e *EnumDefinition) GetValueNames() []string {
+	return SortedMapKeys(e.GetValues())
+}
+
 type Constants struct {
 	// SecuritySchemeProviderNames holds all provider names for security schemes.
 	SecuritySchemeProviderNames []string
@@ -209,8 +221,14 @@ type ResponseTypeDefinition struct {
 	AdditionalTypeDefinitions []TypeDefinition
 }
 
+// IsEventStream returns whether the response is a stream of Server-Sent
+// Events, whose data is described by the schema.
+func (t ResponseTypeDefinition) IsEventStream() bool {
+	return t.ContentTypeName == contentTypeEventStream
+}
+
 func (t *TypeDefinition) IsAlias() bool {
-	return !globalState.options.Compatibility.OldAliasing && t.Schema.DefineViaAlias
+	return t.Schema.DefineViaAlias
 }
 
 type Discriminator struct {
@@ -219,6 +237,10 @@ type Discriminator struct {
 
 	// JSON property name that holds the discriminator
 	Property string
+
+	// state is the state of the generation which the discriminator was
+	// generated in
+	state *generatorState
 }
 
 func (d *Discriminator) JSONTag() string {
@@ -226,7 +248,7 @@ func (d *Discriminator) JSONTag() string {
 }
 
 func (d *Discriminator) PropertyName() string {
-	return SchemaNameToTypeName(d.Property)
+	return d.state.orDefault().schemaNameToTypeName(d.Property)
 }
 
 // UnionElement describe union element, based on prefix externalRef\d+ and real ref name from external schema.
@@ -251,6 +273,19 @@ func PropertiesEqual(a, b Property) bool {
 }
 
 func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
+	return packageGeneratorState().generateGoSchema(sref, path)
+}
+
+func (gs *generatorState) generateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
+	schema, err := gs.goSchema(sref, path)
+	// with the `old-aliasing`, no types are defined as aliases
+	if gs.options.Compatibility.OldAliasing {
+		schema.DefineViaAlias = false
+	}
+	return schema, err
+}
+
+func (gs *generatorState) goSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// Add a fallback value in case the sref is nil.
 	// i.e. the parent schema defines a type:array, but the array has
 	// no items defined. Therefore, we have at least valid Go-Code.
@@ -264,7 +299,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// another type. We're not de-referencing, so simply use the referenced type.
 	if IsGoTypeReference(sref.Ref) {
 		// Convert the reference path to Go type
-		refType, err := RefPathToGoType(sref.Ref)
+		refType, err := gs.refPathToGoType(sref.Ref, true)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error turning reference (%s) into a Go type: %s",
 				sref.Ref, err)
@@ -274,7 +309,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			Description:         schema.Description,
 			DefineViaAlias:      true,
 			OAPISchema:          schema,
-			SkipOptionalPointer: globalState.options.OutputOptions.PreferSkipOptionalPointer,
+			SkipOptionalPointer: gs.options.OutputOptions.PreferSkipOptionalPointer,
 		}, nil
 	}
 
@@ -282,7 +317,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 		Description: schema.Description,
 		OAPISchema:  schema,
 		// NOTE that SkipOptionalPointer will be defaulted to the global value, but can be overridden on a per-type/-field basis
-		SkipOptionalPointer: globalState.options.OutputOptions.PreferSkipOptionalPointer,
+		SkipOptionalPointer: gs.options.OutputOptions.PreferSkipOptionalPointer,
 	}
 
 	// AllOf is interesting, and useful. It's the union of a number of other
@@ -290,7 +325,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// so that in a RESTful paradigm, the Create operation can return
 	// (object, id), so that other operations can refer to (id)
 	if schema.AllOf != nil {
-		mergedSchema, err := MergeSchemas(schema.AllOf, path)
+		mergedSchema, err := gs.mergeSchemas(schema.AllOf, path)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error merging schemas: %w", err)
 		}
@@ -334,11 +369,12 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				// We have an object with no properties. This is a generic object
 				// expressed as a map.
 				outType = "map[string]interface{}"
-				setSkipOptionalPointerForContainerType(&outSchema)
+				gs.setSkipOptionalPointerForContainerType(&outSchema)
 			} else { // t == ""
 				// If we don't even have the object designator, we're a completely
 				// generic type.
 				outType = "interface{}"
+				gs.addDiagnostic(DiagnosticUntypedSchema, gs.pointerOf(schema), "the schema has no `type`, so it's generated as `interface{}`")
 				// this should never have an "optional pointer", as it doesn't make sense to be a `*interface{}`
 				outSchema.SkipOptionalPointer = true
 			}
@@ -362,7 +398,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			// If additional properties are defined, we will override the default
 			// above with the specific definition.
 			if schema.AdditionalProperties.Schema != nil {
-				additionalSchema, err := GenerateGoSchema(schema.AdditionalProperties.Schema, path)
+				additionalSchema, err := gs.generateGoSchema(schema.AdditionalProperties.Schema, path)
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating type for additional properties: %w", err)
 				}
@@ -371,7 +407,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
 					// to get to the type.
-					typeName := PathToTypeName(append(path, "AdditionalProperties"))
+					typeName := gs.pathToTypeName(append(path, "AdditionalProperties"))
 
 					typeDef := TypeDefinition{
 						TypeName: typeName,
@@ -389,7 +425,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			// early-out here and generate a map[string]<schema> instead of an object
 			// that contains this map. We skip over anyOf/oneOf here because they can
 			// introduce properties. allOf was handled above.
-			if !globalState.options.Compatibility.DisableFlattenAdditionalProperties &&
+			if !gs.options.Compatibility.DisableFlattenAdditionalProperties &&
 				len(schema.Properties) == 0 && schema.AnyOf == nil && schema.OneOf == nil {
 				// We have a dictionary here. Returns the goType to be just a map from
 				// string to the property type. HasAdditionalProperties=false means
@@ -397,7 +433,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				// since we don't need them for a simple map.
 				outSchema.HasAdditionalProperties = false
 				outSchema.GoType = fmt.Sprintf("map[string]%s", additionalPropertiesType(outSchema))
-				setSkipOptionalPointerForContainerType(&outSchema)
+				gs.setSkipOptionalPointerForContainerType(&outSchema)
 				return outSchema, nil
 			}
 
@@ -405,7 +441,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			for _, pName := range SortedSchemaKeys(schema.Properties) {
 				p := schema.Properties[pName]
 				propertyPath := append(path, pName)
-				pSchema, err := GenerateGoSchema(p, propertyPath)
+				pSchema, err := gs.generateGoSchema(p, propertyPath)
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating Go schema for property '%s': %w", pName, err)
 				}
@@ -417,7 +453,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
 					// to get to the type.
-					typeName := PathToTypeName(propertyPath)
+					typeName := gs.pathToTypeName(propertyPath)
 
 					typeDef := TypeDefinition{
 						TypeName: typeName,
@@ -442,6 +478,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					WriteOnly:     p.Value.WriteOnly,
 					Extensions:    p.Value.Extensions,
 					Deprecated:    p.Value.Deprecated,
+					state:         gs,
 				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 				if len(pSchema.AdditionalTypes) > 0 {
@@ -450,17 +487,17 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			}
 
 			if schema.AnyOf != nil {
-				if err := generateUnion(&outSchema, schema.AnyOf, schema.Discriminator, path); err != nil {
+				if err := gs.generateUnion(&outSchema, schema.AnyOf, schema.Discriminator, path); err != nil {
 					return Schema{}, fmt.Errorf("error generating type for anyOf: %w", err)
 				}
 			}
 			if schema.OneOf != nil {
-				if err := generateUnion(&outSchema, schema.OneOf, schema.Discriminator, path); err != nil {
+				if err := gs.generateUnion(&outSchema, schema.OneOf, schema.Discriminator, path); err != nil {
 					return Schema{}, fmt.Errorf("error generating type for oneOf: %w", err)
 				}
 			}
 
-			outSchema.GoType = GenStructFromSchema(outSchema)
+			outSchema.GoType = gs.genStructFromSchema(outSchema)
 		}
 
 		// Check for x-go-type-name. It behaves much like x-go-type, however, it will
@@ -486,7 +523,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 
 		return outSchema, nil
 	} else if len(schema.Enum) > 0 {
-		err := oapiSchemaToGoType(schema, path, &outSchema)
+		err := gs.oapiSchemaToGoType(schema, path, &outSchema)
 		// Enums need to be typed, so that the values aren't interchangeable,
 		// so no matter what schema conversion thinks, we need to define a
 		// new type.
@@ -510,7 +547,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			}
 		}
 
-		sanitizedValues := SanitizeEnumNames(enumNames, enumValues)
+		sanitizedValues := gs.sanitizeEnumNames(enumNames, enumValues)
 		outSchema.EnumValues = make(map[string]string, len(sanitizedValues))
 
 		for k, v := range sanitizedValues {
@@ -520,10 +557,10 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			} else {
 				enumName = k
 			}
-			if globalState.options.Compatibility.OldEnumConflicts {
-				outSchema.EnumValues[SchemaNameToTypeName(PathToTypeName(append(path, enumName)))] = v
+			if gs.options.Compatibility.OldEnumConflicts {
+				outSchema.EnumValues[gs.schemaNameToTypeName(gs.pathToTypeName(append(path, enumName)))] = v
 			} else {
-				outSchema.EnumValues[SchemaNameToTypeName(k)] = v
+				outSchema.EnumValues[gs.schemaNameToTypeName(k)] = v
 			}
 		}
 		if len(path) > 1 { // handle additional type only on non-toplevel types
@@ -536,7 +573,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 					return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
 				}
 			} else {
-				typeName = SchemaNameToTypeName(PathToTypeName(path))
+				typeName = gs.schemaNameToTypeName(gs.pathToTypeName(path))
 			}
 
 			typeDef := TypeDefinition{
@@ -548,7 +585,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			outSchema.RefType = typeName
 		}
 	} else {
-		err := oapiSchemaToGoType(schema, path, &outSchema)
+		err := gs.oapiSchemaToGoType(schema, path, &outSchema)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error resolving primitive type: %w", err)
 		}
@@ -558,14 +595,17 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 
 // oapiSchemaToGoType converts an OpenApi schema into a Go type definition for
 // all non-object types.
-func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schema) error {
+func (gs *generatorState) oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schema) error {
 	f := schema.Format
 	t := schema.Type
 
 	if t.Is("array") {
+		if schema.Items == nil {
+			gs.addDiagnostic(DiagnosticArrayWithoutItems, gs.pointerOf(schema), "the array has no `items`, so its items are generated as `interface{}`")
+		}
 		// For arrays, we'll get the type of the Items and throw a
 		// [] in front of it.
-		arrayType, err := GenerateGoSchema(schema.Items, path)
+		arrayType, err := gs.generateGoSchema(schema.Items, path)
 		if err != nil {
 			return fmt.Errorf("error generating type for array: %w", err)
 		}
@@ -574,7 +614,7 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 			// but are not a pre-defined type, we need to define a type
 			// for them, which will be based on the field names we followed
 			// to get to the type.
-			typeName := PathToTypeName(append(path, "Item"))
+			typeName := gs.pathToTypeName(append(path, "Item"))
 
 			typeDef := TypeDefinition{
 				TypeName: typeName,
@@ -590,10 +630,10 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 		outSchema.AdditionalTypes = arrayType.AdditionalTypes
 		outSchema.Properties = arrayType.Properties
 		outSchema.DefineViaAlias = true
-		if sliceContains(globalState.options.OutputOptions.DisableTypeAliasesForType, "array") {
+		if sliceContains(gs.options.OutputOptions.DisableTypeAliasesForType, "array") {
 			outSchema.DefineViaAlias = false
 		}
-		setSkipOptionalPointerForContainerType(outSchema)
+		gs.setSkipOptionalPointerForContainerType(outSchema)
 
 	} else if t.Is("integer") {
 		// We default to int if format doesn't ask for something else.
@@ -635,7 +675,7 @@ func oapiSchemaToGoType(schema *openapi3.Schema, path []string, outSchema *Schem
 		switch f {
 		case "byte":
 			outSchema.GoType = "[]byte"
-			setSkipOptionalPointerForContainerType(outSchema)
+			gs.setSkipOptionalPointerForContainerType(outSchema)
 		case "email":
 			outSchema.GoType = "openapi_types.Email"
 		case "date":
@@ -685,6 +725,10 @@ func stringOrEmpty(b bool, s string) string {
 // GenFieldsFromProperties produce corresponding field names with JSON annotations,
 // given a list of schema descriptors
 func GenFieldsFromProperties(props []Property) []string {
+	return packageGeneratorState().genFieldsFromProperties(props)
+}
+
+func (gs *generatorState) genFieldsFromProperties(props []Property) []string {
 	var fields []string
 	for i, p := range props {
 		field := ""
@@ -724,18 +768,18 @@ func GenFieldsFromProperties(props []Property) []string {
 		field += fmt.Sprintf("    %s %s", goFieldName, p.GoTypeDef())
 
 		shouldOmitEmpty := (!p.Required || p.ReadOnly || p.WriteOnly) &&
-			(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
+			(!p.Required || !p.ReadOnly || !gs.options.Compatibility.DisableRequiredReadOnlyAsPointer)
 
 		omitEmpty := !p.Nullable && shouldOmitEmpty
 
-		if p.Nullable && globalState.options.OutputOptions.NullableType {
+		if p.Nullable && gs.options.OutputOptions.NullableType {
 			omitEmpty = shouldOmitEmpty
 		}
 
 		omitZero := false
 
 		// default, but allow turning of
-		if shouldOmitEmpty && p.Schema.SkipOptionalPointer && globalState.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
+		if shouldOmitEmpty && p.Schema.SkipOptionalPointer && gs.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
 			omitZero = true
 		}
 
@@ -758,9 +802,12 @@ func GenFieldsFromProperties(props []Property) []string {
 			stringOrEmpty(omitEmpty, ",omitempty") +
 			stringOrEmpty(omitZero, ",omitzero")
 
-		if globalState.options.OutputOptions.EnableYamlTags {
+		if gs.options.OutputOptions.EnableYamlTags {
 			fieldTags["yaml"] = p.JsonFieldName + stringOrEmpty(omitEmpty, ",omitempty")
 		}
+		if gs.xmlTagsEnabled() {
+			fieldTags["xml"] = xmlFieldTag(p, omitEmpty)
+		}
 		if p.NeedsFormTag {
 			fieldTags["form"] = p.JsonFieldName + stringOrEmpty(omitEmpty, ",omitempty")
 		}
@@ -805,15 +852,23 @@ func additionalPropertiesType(schema Schema) string {
 }
 
 func GenStructFromSchema(schema Schema) string {
+	return packageGeneratorState().genStructFromSchema(schema)
+}
+
+func (gs *generatorState) genStructFromSchema(schema Schema) string {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
+	if xmlName := gs.genXMLNameField(schema); xmlName != "" {
+		objectParts = append(objectParts, xmlName)
+	}
 	// Append all the field definitions
-	objectParts = append(objectParts, GenFieldsFromProperties(schema.Properties)...)
+	objectParts = append(objectParts, gs.genFieldsFromProperties(schema.Properties)...)
 	// Close the struct
 	if schema.HasAdditionalProperties {
+		// encoding/xml can't encode maps
 		objectParts = append(objectParts,
-			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`",
-				additionalPropertiesType(schema)))
+			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"%s`",
+				additionalPropertiesType(schema), stringOrEmpty(gs.xmlTagsEnabled(), ` xml:"-"`)))
 	}
 	if len(schema.UnionElements) != 0 {
 		objectParts = append(objectParts, "union json.RawMessage")
@@ -824,14 +879,14 @@ func GenStructFromSchema(schema Schema) string {
 
 // This constructs a Go type for a parameter, looking at either the schema or
 // the content, whichever is available
-func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
+func (gs *generatorState) paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	if param.Content == nil && param.Schema == nil {
 		return Schema{}, fmt.Errorf("parameter '%s' has no schema or content", param.Name)
 	}
 
 	// We can process the schema through the generic schema processor
 	if param.Schema != nil {
-		return GenerateGoSchema(param.Schema, path)
+		return gs.generateGoSchema(param.Schema, path)
 	}
 
 	// At this point, we have a content type. We know how to deal with
@@ -855,27 +910,28 @@ func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	}
 
 	// For json, we go through the standard schema mechanism
-	return GenerateGoSchema(mt.Schema, path)
+	return gs.generateGoSchema(mt.Schema, path)
 }
 
-func generateUnion(outSchema *Schema, elements openapi3.SchemaRefs, discriminator *openapi3.Discriminator, path []string) error {
+func (gs *generatorState) generateUnion(outSchema *Schema, elements openapi3.SchemaRefs, discriminator *openapi3.Discriminator, path []string) error {
 	if discriminator != nil {
 		outSchema.Discriminator = &Discriminator{
 			Property: discriminator.PropertyName,
 			Mapping:  make(map[string]string),
+			state:    gs,
 		}
 	}
 
 	refToGoTypeMap := make(map[string]string)
 	for i, element := range elements {
 		elementPath := append(path, fmt.Sprint(i))
-		elementSchema, err := GenerateGoSchema(element, elementPath)
+		elementSchema, err := gs.generateGoSchema(element, elementPath)
 		if err != nil {
 			return err
 		}
 
 		if element.Ref == "" {
-			elementName := SchemaNameToTypeName(PathToTypeName(elementPath))
+			elementName := gs.schemaNameToTypeName(gs.pathToTypeName(elementPath))
 			if elementSchema.TypeDecl() == elementName {
 				elementSchema.GoType = elementName
 			} else {
@@ -893,6 +949,11 @@ func generateUnion(outSchema *Schema, elements openapi3.SchemaRefs, discriminato
 				return errors.New("ambiguous discriminator.mapping: please replace inlined object with $ref")
 			}
 
+			if element.Value != nil && !schemaHasProperty(element.Value, discriminator.PropertyName) {
+				gs.addDiagnostic(DiagnosticDiscriminatorPropertyMissing, gs.pointerOf(element.Value),
+					"the schema doesn't have the %q property of the discriminator of its union, so its value can't be told apart from the others", discriminator.PropertyName)
+			}
+
 			// Explicit mapping.
 			var mapped bool
 			for k, v := range discriminator.Mapping {
@@ -914,14 +975,42 @@ func generateUnion(outSchema *Schema, elements openapi3.SchemaRefs, discriminato
 		return errors.New("discriminator: not all schemas were mapped")
 	}
 
+	if discriminator != nil {
+		for _, value := range SortedMapKeys(discriminator.Mapping) {
+			if _, ok := refToGoTypeMap[discriminator.Mapping[value]]; ok {
+				continue
+			}
+			var pointer string
+			if unionPointer := gs.pointerOf(outSchema.OAPISchema); unionPointer != "" {
+				pointer = appendPointer(unionPointer, "discriminator", "mapping", value)
+			}
+			gs.addDiagnostic(DiagnosticDiscriminatorMappingUnmatched, pointer,
+				"the discriminator maps %q to %s, which isn't one of the schemas of its union, so the mapping is ignored", value, discriminator.Mapping[value])
+		}
+	}
+
 	return nil
 }
 
+// schemaHasProperty returns whether the schema, or any of those it's composed
+// of with `allOf`, has the property.
+func schemaHasProperty(schema *openapi3.Schema, name string) bool {
+	if _, ok := schema.Properties[name]; ok {
+		return true
+	}
+	for _, element := range schema.AllOf {
+		if element != nil && element.Value != nil && schemaHasProperty(element.Value, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // setSkipOptionalPointerForContainerType ensures that the "optional pointer" is skipped on container types (such as a slice or a map).
 // This is controlled using the `prefer-skip-optional-pointer-on-container-types` Output Option
 // NOTE that it is still possible to override this on a per-field basis with `x-go-type-skip-optional-pointer`
-func setSkipOptionalPointerForContainerType(outSchema *Schema) {
-	if !globalState.options.OutputOptions.PreferSkipOptionalPointerOnContainerTypes {
+func (gs *generatorState) setSkipOptionalPointerForContainerType(outSchema *Schema) {
+	if !gs.options.OutputOptions.PreferSkipOptionalPointerOnContainerTypes {
 		return
 	}
 
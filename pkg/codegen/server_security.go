@@ -0,0 +1,127 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecuritySchemeDefinition describes a security scheme, whose credentials
+// the server's SecurityHandler authenticates, when using the
+// `server-security` output option.
+type SecuritySchemeDefinition struct {
+	// ProviderName is the name of the security scheme
+	ProviderName string
+	// GoName is the name of the SecurityHandler method which authenticates
+	// its credentials, without the `Handle` prefix
+	GoName string
+	// Type is the security scheme's `type`, such as `http` or `apiKey`
+	Type string
+	// AuthorizationScheme is the scheme of the `Authorization` header, such
+	// as `Bearer`, for the `http`, `oauth2` and `openIdConnect` types
+	AuthorizationScheme string
+	// In is where the credentials of an `apiKey` security scheme are, which
+	// is `header`, `query` or `cookie`
+	In string
+	// Name is the name of the header, query parameter or cookie, which has
+	// the credentials of an `apiKey` security scheme
+	Name string
+}
+
+// IsBasic returns whether the credentials are a username and password, in
+// an `Authorization` header with the `Basic` scheme.
+func (s SecuritySchemeDefinition) IsBasic() bool {
+	return strings.EqualFold(s.AuthorizationScheme, "basic")
+}
+
+// IsAPIKey returns whether the credentials are an API key, in a header,
+// query parameter or cookie.
+func (s SecuritySchemeDefinition) IsAPIKey() bool {
+	return s.Type == "apiKey"
+}
+
+// CredentialsName is the name of the SecurityHandler method's parameter for
+// the credentials.
+func (s SecuritySchemeDefinition) CredentialsName() string {
+	switch {
+	case s.IsAPIKey():
+		return "apiKey"
+	case strings.EqualFold(s.AuthorizationScheme, "bearer"):
+		return "token"
+	default:
+		return "credentials"
+	}
+}
+
+// DescribeSecuritySchemes describes the security schemes which the
+// operations' security requirements use, sorted by their names.
+func DescribeSecuritySchemes(spec *openapi3.T, ops []OperationDefinition) ([]SecuritySchemeDefinition, error) {
+	used := map[string]string{}
+	for _, op := range ops {
+		for _, requirement := range op.SecurityRequirements {
+			for _, def := range requirement {
+				if _, ok := used[def.ProviderName]; !ok {
+					used[def.ProviderName] = op.OperationId
+				}
+			}
+		}
+	}
+
+	var definitions []SecuritySchemeDefinition
+	for _, name := range SortedMapKeys(used) {
+		var ref *openapi3.SecuritySchemeRef
+		if spec.Components != nil {
+			ref = spec.Components.SecuritySchemes[name]
+		}
+		if ref == nil || ref.Value == nil {
+			return nil, fmt.Errorf("the security scheme %q, which the operation %s needs, isn't defined", name, used[name])
+		}
+		scheme := ref.Value
+
+		definition := SecuritySchemeDefinition{
+			ProviderName: name,
+			GoName:       UppercaseFirstCharacter(SanitizeGoIdentity(name)),
+			Type:         scheme.Type,
+		}
+		switch scheme.Type {
+		case "http":
+			if scheme.Scheme == "" {
+				return nil, fmt.Errorf("the http security scheme %q has no scheme", name)
+			}
+			definition.AuthorizationScheme = UppercaseFirstCharacter(strings.ToLower(scheme.Scheme))
+		case "oauth2", "openIdConnect":
+			definition.AuthorizationScheme = "Bearer"
+		case "apiKey":
+			switch scheme.In {
+			case "header", "query", "cookie":
+			default:
+				return nil, fmt.Errorf("the apiKey security scheme %q is in %q, rather than a header, query or cookie", name, scheme.In)
+			}
+			definition.In = scheme.In
+			definition.Name = scheme.Name
+		default:
+			return nil, fmt.Errorf("the security scheme %q is of the type %q, whose credentials can't be authenticated by the server", name, scheme.Type)
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GenerateServerSecurity generates the SecurityHandler interface, and the
+// evaluation of the operations' security requirements, which the server's
+// wrappers use when using the `server-security` output option.
+func GenerateServerSecurity(t *template.Template, spec *openapi3.T, ops []OperationDefinition) (string, error) {
+	schemes, err := DescribeSecuritySchemes(spec, ops)
+	if err != nil {
+		return "", err
+	}
+	return GenerateTemplates([]string{"server-security.tmpl"}, t, struct {
+		Schemes    []SecuritySchemeDefinition
+		Operations []OperationDefinition
+	}{
+		Schemes:    schemes,
+		Operations: ops,
+	})
+}
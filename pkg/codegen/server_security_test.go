@@ -0,0 +1,191 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serverSecurityOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Server security
+security:
+  - bearerAuth: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+    post:
+      operationId: addPet
+      security:
+        - oauth2: [pets:write]
+        - apiKey: []
+          basicAuth: []
+      responses:
+        '204':
+          description: The pet was added
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        '204':
+          description: The API is healthy
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    basicAuth:
+      type: http
+      scheme: Basic
+    apiKey:
+      type: apiKey
+      in: cookie
+      name: api_key
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            pets:write: Change pets
+    unused:
+      type: http
+      scheme: digest
+`
+
+func TestDescribeSecuritySchemes(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(serverSecurityOpenAPIDefinition))
+	require.NoError(t, err)
+	ops, err := OperationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	schemes, err := DescribeSecuritySchemes(spec, ops)
+	require.NoError(t, err)
+	assert.Equal(t, []SecuritySchemeDefinition{
+		{ProviderName: "apiKey", GoName: "ApiKey", Type: "apiKey", In: "cookie", Name: "api_key"},
+		{ProviderName: "basicAuth", GoName: "BasicAuth", Type: "http", AuthorizationScheme: "Basic"},
+		{ProviderName: "bearerAuth", GoName: "BearerAuth", Type: "http", AuthorizationScheme: "Bearer"},
+		{ProviderName: "oauth2", GoName: "Oauth2", Type: "oauth2", AuthorizationScheme: "Bearer"},
+	}, schemes)
+}
+
+func TestGenerateServerSecurity(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(serverSecurityOpenAPIDefinition))
+	require.NoError(t, err)
+
+	for name, generate := range map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"chi":      {ChiServer: true},
+		"gorilla":  {GorillaServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			code, err := Generate(spec, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+				OutputOptions: OutputOptions{
+					ServerSecurity: true,
+				},
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			require.NoError(t, err)
+
+			assert.Contains(t, code, "type SecurityHandler interface {")
+			assert.Contains(t, code, "HandleBearerAuth(ctx context.Context, token string, scopes []string) (context.Context, error)")
+			assert.Contains(t, code, "HandleBasicAuth(ctx context.Context, username, password string, scopes []string) (context.Context, error)")
+			assert.Contains(t, code, "HandleApiKey(ctx context.Context, apiKey string, scopes []string) (context.Context, error)")
+			assert.Contains(t, code, "HandleOauth2(ctx context.Context, token string, scopes []string) (context.Context, error)")
+			assert.NotContains(t, code, "HandleUnused")
+			assert.Contains(t, code, `{{scheme: "oauth2", scopes: []string{"pets:write"}}},
+		{{scheme: "apiKey", scopes: []string{}}, {scheme: "basicAuth", scopes: []string{}}},`)
+			assert.NotContains(t, code, `"Health": {`)
+			assert.Contains(t, code, `authenticate(`)
+			assert.Contains(t, code, "SecurityHandler SecurityHandler")
+		})
+	}
+}
+
+func TestServerSecurityIsOptional(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(serverSecurityOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			StdHTTPServer: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "SecurityHandler")
+	assert.NotContains(t, code, "authenticate(")
+}
+
+func TestServerSecurityWithoutPaths(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Server security without paths
+paths: {}
+`))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			EchoServer: true,
+		},
+		OutputOptions: OutputOptions{
+			ServerSecurity: true,
+		},
+	})
+	require.NoError(t, err)
+
+	// the base URL is only declared when there are routes to prefix with it,
+	// as it would otherwise be unused
+	assert.Contains(t, code, "func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {")
+	assert.NotContains(t, code, "baseURL := options.BaseURL")
+}
+
+func TestServerSecurityUnsupportedSchemes(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Server security
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      security:
+        - missing: []
+      responses:
+        '200':
+          description: The pets
+`))
+	require.NoError(t, err)
+	ops, err := OperationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	_, err = DescribeSecuritySchemes(spec, ops)
+	assert.EqualError(t, err, `the security scheme "missing", which the operation ListPets needs, isn't defined`)
+}
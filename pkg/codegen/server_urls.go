@@ -21,15 +21,21 @@ type ServerObjectDefinition struct {
 }
 
 func GenerateServerURLs(t *template.Template, spec *openapi3.T) (string, error) {
+	gs := packageGeneratorState()
+	gs.spec = spec
+	return gs.generateServerURLs(t, spec)
+}
+
+func (gs *generatorState) generateServerURLs(t *template.Template, spec *openapi3.T) (string, error) {
 	names := make(map[string]*openapi3.Server)
 
 	for _, server := range spec.Servers {
 		suffix := server.Description
 		if suffix == "" {
-			suffix = nameNormalizer(server.URL)
+			suffix = gs.nameNormalizer(server.URL)
 		}
 		name := serverURLPrefix + UppercaseFirstCharacter(suffix)
-		name = nameNormalizer(name)
+		name = gs.nameNormalizer(name)
 
 		// if this is the only type with this name, store it
 		if _, conflict := names[name]; !conflict {
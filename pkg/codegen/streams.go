@@ -0,0 +1,151 @@
+package codegen
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+const (
+	// streamFormatJSON streams the elements of a JSON array
+	streamFormatJSON = "json"
+	// streamFormatNDJSON streams newline delimited JSON values
+	streamFormatNDJSON = "ndjson"
+	// streamFormatJSONSeq streams a JSON text sequence, as in RFC 7464
+	streamFormatJSONSeq = "json-seq"
+)
+
+// StreamDefinition describes the items of a request or response body which
+// are streamed, rather than buffered in memory, when its media type has the
+// `x-oapi-codegen-stream` extension.
+type StreamDefinition struct {
+	// Format is how the items are delimited, which is `json` for the
+	// elements of a JSON array, `ndjson` for newline delimited JSON, and
+	// `json-seq` for a JSON text sequence
+	Format string
+	// Item is the schema of each of the items
+	Item Schema
+}
+
+// TypeDecl returns the Go type of the stream, which is an iterator over its
+// items, and the error of reading or writing them.
+func (s StreamDefinition) TypeDecl() string {
+	return fmt.Sprintf("iter.Seq2[%s, error]", s.Item.TypeDecl())
+}
+
+// streamFormat returns the format of a media type's stream, and the tag of
+// the Go names for its body, when it isn't JSON, or an empty format when the
+// media type can't be streamed.
+func streamFormat(contentType string) (format string, tag string) {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ""
+	}
+	switch {
+	case parsed == "application/x-ndjson" || parsed == "application/ndjson" || parsed == "application/jsonl":
+		return streamFormatNDJSON, "NDJSON"
+	case parsed == "application/json-seq" || strings.HasSuffix(parsed, "+json-seq"):
+		return streamFormatJSONSeq, "JSONSeq"
+	case util.IsMediaTypeJson(contentType):
+		return streamFormatJSON, ""
+	}
+	return "", ""
+}
+
+// isStreamed returns whether a media type has the `x-oapi-codegen-stream`
+// extension.
+func isStreamed(content *openapi3.MediaType) (bool, error) {
+	extension, ok := content.Extensions[extOapiCodegenStream]
+	if !ok {
+		return false, nil
+	}
+	stream, err := extParseOapiCodegenStream(extension)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %q: %w", extOapiCodegenStream, err)
+	}
+	return stream, nil
+}
+
+// errNotStreamable is the error of the `x-oapi-codegen-stream` extension on
+// a media type which can't be streamed.
+func errNotStreamable(contentType string) error {
+	return fmt.Errorf("the %s media type can't be streamed, as only JSON arrays, NDJSON and JSON text sequences can", contentType)
+}
+
+// describeStream describes the items of a streamed media type, given the
+// Go schema of its content. The items of an array are its elements, and
+// otherwise NDJSON and JSON text sequences are a stream of the schema.
+func (gs *generatorState) describeStream(contentType string, content *openapi3.MediaType, schema Schema, path []string) (*StreamDefinition, error) {
+	format, _ := streamFormat(contentType)
+	if format == "" {
+		return nil, errNotStreamable(contentType)
+	}
+
+	if schema.ArrayType != nil {
+		return &StreamDefinition{Format: format, Item: *schema.ArrayType}, nil
+	}
+	if content.Schema != nil && content.Schema.Value != nil && content.Schema.Value.Type.Is("array") {
+		// the array is a reference to another type, so its elements are
+		// described again
+		item, err := gs.generateGoSchema(content.Schema.Value.Items, append(path, "Item"))
+		if err != nil {
+			return nil, fmt.Errorf("error generating the items of the %s stream: %w", contentType, err)
+		}
+		return &StreamDefinition{Format: format, Item: item}, nil
+	}
+	if format == streamFormatJSON {
+		return nil, fmt.Errorf("the %s media type can't be streamed, as its schema isn't an array", contentType)
+	}
+	return &StreamDefinition{Format: format, Item: schema}, nil
+}
+
+// hasStreams returns whether any of the operations' request or response
+// bodies are streamed.
+func hasStreams(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		for _, body := range op.Bodies {
+			if body.IsStream() {
+				return true
+			}
+		}
+		for _, response := range op.Responses {
+			for _, content := range response.Contents {
+				if content.IsStream() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// GenerateStreams generates the functions which encode and decode the items
+// of streamed request and response bodies, and the events of Server-Sent
+// Events, which the client and strict server use.
+func GenerateStreams(t *template.Template, ops []OperationDefinition) (string, error) {
+	var templates []string
+	if hasStreams(ops) {
+		templates = append(templates, "streams.tmpl")
+	}
+	if hasEventStreams(ops) {
+		templates = append(templates, "event-streams.tmpl")
+	}
+	if len(templates) == 0 {
+		return "", nil
+	}
+	return GenerateTemplates(templates, t, ops)
+}
+
+// GenerateClientStreams generates the functions which decode the items of
+// streamed responses for the client.
+func GenerateClientStreams(t *template.Template, ops []OperationDefinition) (string, error) {
+	if !hasStreams(ops) {
+		return "", nil
+	}
+	return GenerateTemplates([]string{"client-streams.tmpl"}, t, ops)
+}
@@ -0,0 +1,274 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const streamsOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Streams
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              x-oapi-codegen-stream: true
+              schema:
+                $ref: '#/components/schemas/Pets'
+    post:
+      operationId: importPets
+      requestBody:
+        content:
+          application/x-ndjson:
+            x-oapi-codegen-stream: true
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pets'
+      responses:
+        '204':
+          description: The pets were imported
+  /pets/export:
+    get:
+      operationId: exportPets
+      responses:
+        '200':
+          $ref: '#/components/responses/PetStream'
+        default:
+          description: The changes to the pets
+          content:
+            application/json-seq:
+              x-oapi-codegen-stream: true
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+  responses:
+    PetStream:
+      description: The pets
+      content:
+        application/x-ndjson:
+          x-oapi-codegen-stream: true
+          schema:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestDescribeStreams(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(streamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	ops, err := OperationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	operations := map[string]OperationDefinition{}
+	for _, op := range ops {
+		operations[op.OperationId] = op
+	}
+
+	listPets := operations["ListPets"]
+	stream := listPets.Responses[0].Contents[0].Stream
+	require.NotNil(t, stream)
+	assert.Equal(t, "json", stream.Format)
+	assert.Equal(t, "iter.Seq2[Pet, error]", stream.TypeDecl())
+
+	importPets := operations["ImportPets"]
+	require.Len(t, importPets.Bodies, 2)
+	assert.False(t, importPets.Bodies[0].IsStream())
+	assert.Equal(t, "NDJSON", importPets.Bodies[1].NameTag)
+	require.True(t, importPets.Bodies[1].IsStream())
+	assert.Equal(t, "ndjson", importPets.Bodies[1].Stream.Format)
+	assert.Equal(t, "iter.Seq2[Pet, error]", importPets.Bodies[1].Stream.TypeDecl())
+
+	exportPets := operations["ExportPets"]
+	assert.Equal(t, "ndjson", exportPets.Responses[0].Contents[0].Stream.Format)
+	assert.Equal(t, "JSONSeq", exportPets.Responses[1].Contents[0].NameTag)
+	assert.Equal(t, "json-seq", exportPets.Responses[1].Contents[0].Stream.Format)
+}
+
+func TestGenerateStreams(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(streamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	for name, generate := range map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			generate.Client = true
+			generate.Strict = true
+			code, err := Generate(spec, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			require.NoError(t, err)
+
+			// the client and strict server share the encoding of streams
+			assert.Equal(t, 1, strings.Count(code, "func encodeJSONStream[T any]("))
+			assert.Equal(t, 1, strings.Count(code, "func decodeJSONStream[T any]("))
+
+			assert.Contains(t, code, "ImportPetsWithNDJSONStream(ctx context.Context, body iter.Seq2[Pet, error], reqEditors ...RequestEditorFn) (*http.Response, error)")
+			assert.Contains(t, code, "func DecodeListPets200JSONStream(rsp *http.Response) (iter.Seq2[Pet, error], error) {")
+			assert.Contains(t, code, "func DecodeExportPets200NDJSONStream(rsp *http.Response) (iter.Seq2[Pet, error], error) {")
+			assert.Contains(t, code, "func DecodeExportPetsdefaultJSONSeqStream(rsp *http.Response) (iter.Seq2[struct {")
+
+			assert.Contains(t, code, `NDJSONBody iter.Seq2[Pet, error]`)
+			assert.Contains(t, code, `decodeJSONStream[Pet](`)
+			assert.Contains(t, code, "type PetStreamNDJSONResponse iter.Seq2[Pet, error]")
+			assert.Contains(t, code, "type ExportPets200NDJSONResponse PetStreamNDJSONResponse")
+			assert.Contains(t, code, `Body iter.Seq2[struct {`)
+			assert.Regexp(t, `encodeJSONStream\([^,]+, "json-seq", `, code)
+		})
+	}
+}
+
+func TestStreamsAreOptional(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(strings.ReplaceAll(streamsOpenAPIDefinition, "x-oapi-codegen-stream: true", "x-oapi-codegen-stream: false")))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+			Strict:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "iter.Seq2")
+	assert.NotContains(t, code, "JSONStream")
+	assert.Contains(t, code, "Body io.Reader")
+}
+
+func TestStreamErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		content string
+		err     string
+	}{
+		"not a JSON array": {
+			content: `
+            application/json:
+              x-oapi-codegen-stream: true
+              schema:
+                type: object`,
+			err: "the application/json media type can't be streamed, as its schema isn't an array",
+		},
+		"not JSON": {
+			content: `
+            text/csv:
+              x-oapi-codegen-stream: true
+              schema:
+                type: string`,
+			err: "the text/csv media type can't be streamed, as only JSON arrays, NDJSON and JSON text sequences can",
+		},
+		"not a boolean": {
+			content: `
+            application/x-ndjson:
+              x-oapi-codegen-stream: yes please
+              schema:
+                type: object`,
+			err: `invalid value for "x-oapi-codegen-stream": failed to convert type: string`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Streams
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:` + test.content + `
+`))
+			require.NoError(t, err)
+
+			_, err = OperationDefinitions(spec, false)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestGenerateStreamsWithLayout(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(streamsOpenAPIDefinition))
+	require.NoError(t, err)
+
+	layouts := map[string]struct {
+		layout OutputLayoutOptions
+		counts []int
+	}{
+		"separate files": {
+			layout: OutputLayoutOptions{
+				Client:       &OutputFileOptions{Filename: "client.gen.go"},
+				StrictServer: &OutputFileOptions{Filename: "server.gen.go"},
+			},
+			counts: []int{0, 1, 0},
+		},
+		"separate packages": {
+			layout: OutputLayoutOptions{
+				Models:       &OutputFileOptions{Filename: "models/models.gen.go", PackageName: "models", ImportPath: "example.com/api/models"},
+				Client:       &OutputFileOptions{Filename: "client/client.gen.go", PackageName: "client"},
+				StrictServer: &OutputFileOptions{Filename: "server.gen.go"},
+			},
+			counts: []int{0, 0, 1, 1},
+		},
+	}
+	for name, test := range layouts {
+		t.Run(name, func(t *testing.T) {
+			files, err := GenerateFiles(spec, Configuration{
+				PackageName: "api",
+				Generate: GenerateOptions{
+					Models:        true,
+					Client:        true,
+					StdHTTPServer: true,
+					Strict:        true,
+				},
+				OutputOptions: OutputOptions{Layout: test.layout},
+			})
+			require.NoError(t, err)
+			require.Len(t, files, len(test.counts))
+
+			// the encoding of streams is generated in each package which uses it
+			for i, file := range files {
+				assert.Equal(t, test.counts[i], strings.Count(file.Code, "func encodeJSONStream[T any]("), file.Filename)
+			}
+		})
+	}
+}
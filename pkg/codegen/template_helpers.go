@@ -40,8 +40,6 @@ var (
 	contentTypesYAML    = []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"}
 	contentTypesXML     = []string{"application/xml", "text/xml", "application/problems+xml"}
 
-	responseTypeSuffix = "Response"
-
 	titleCaser = cases.Title(language.English)
 )
 
@@ -91,11 +89,11 @@ func genParamNames(params []ParameterDefinition) string {
 }
 
 // genResponsePayload generates the payload returned at the end of each client request function
-func genResponsePayload(operationID string) string {
+func (gs *generatorState) genResponsePayload(operationID string) string {
 	var buffer = bytes.NewBufferString("")
 
 	// Here is where we build up a response:
-	fmt.Fprintf(buffer, "&%s{\n", genResponseTypeName(operationID))
+	fmt.Fprintf(buffer, "&%s{\n", gs.genResponseTypeName(operationID))
 	fmt.Fprintf(buffer, "Body: bodyBytes,\n")
 	fmt.Fprintf(buffer, "HTTPResponse: rsp,\n")
 	fmt.Fprintf(buffer, "}")
@@ -104,12 +102,12 @@ func genResponsePayload(operationID string) string {
 }
 
 // genResponseUnmarshal generates unmarshaling steps for structured response payloads
-func genResponseUnmarshal(op *OperationDefinition) string {
+func (gs *generatorState) genResponseUnmarshal(op *OperationDefinition) string {
 	var handledCaseClauses = make(map[string]string)
 	var unhandledCaseClauses = make(map[string]string)
 
 	// Get the type definitions from the operation:
-	typeDefinitions, err := op.GetResponseTypeDefinitions()
+	typeDefinitions, err := gs.responseTypeDefinitions(op)
 	if err != nil {
 		panic(err)
 	}
@@ -146,10 +144,14 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 		// If we made it this far then we need to handle unmarshaling for each content-type:
 		SortedMapKeys := SortedMapKeys(responseRef.Value.Content)
 		jsonCount := 0
+		xmlCount := 0
 		for _, contentTypeName := range SortedMapKeys {
 			if StringInArray(contentTypeName, contentTypesJSON) || util.IsMediaTypeJson(contentTypeName) {
 				jsonCount++
 			}
+			if gs.isXMLResponse(contentTypeName) {
+				xmlCount++
+			}
 		}
 
 		for _, contentTypeName := range SortedMapKeys {
@@ -198,7 +200,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 				}
 
 			// XML:
-			case StringInArray(contentTypeName, contentTypesXML):
+			case gs.isXMLResponse(contentTypeName):
 				if typeDefinition.ContentTypeName == contentTypeName {
 					caseAction := fmt.Sprintf("var dest %s\n"+
 						"if err := xml.Unmarshal(bodyBytes, &dest); err != nil { \n"+
@@ -207,10 +209,18 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
 						typeDefinition.TypeName)
-					caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "xml")
-					handledCaseClauses[caseKey] = caseClause
+					if xmlCount > 1 && gs.options.OutputOptions.XMLBodies {
+						caseKey, caseClause := buildUnmarshalCaseStrict(typeDefinition, caseAction, contentTypeName)
+						handledCaseClauses[caseKey] = caseClause
+					} else {
+						caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "xml")
+						handledCaseClauses[caseKey] = caseClause
+					}
 				}
 
+			// Server-Sent Events are read before the body:
+			case contentTypeName == contentTypeEventStream && typeDefinition.IsEventStream():
+
 			// Everything else:
 			default:
 				caseAction := fmt.Sprintf("// Content-type (%s) unsupported", contentTypeName)
@@ -242,6 +252,13 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 	return buffer.String()
 }
 
+// isXMLResponse returns whether the client decodes a response's content type
+// as XML, which is any XML media type when `xml-bodies` are generated.
+func (gs *generatorState) isXMLResponse(contentTypeName string) bool {
+	return StringInArray(contentTypeName, contentTypesXML) ||
+		gs.options.OutputOptions.XMLBodies && util.IsMediaTypeXml(contentTypeName)
+}
+
 // buildUnmarshalCase builds an unmarshaling case clause for different content-types:
 func buildUnmarshalCase(typeDefinition ResponseTypeDefinition, caseAction string, contentType string) (caseKey string, caseClause string) {
 	caseKey = fmt.Sprintf("%s.%s.%s", prefixLeastSpecific, contentType, typeDefinition.ResponseName)
@@ -258,12 +275,12 @@ func buildUnmarshalCaseStrict(typeDefinition ResponseTypeDefinition, caseAction
 }
 
 // genResponseTypeName creates the name of generated response types (given the operationID):
-func genResponseTypeName(operationID string) string {
-	return fmt.Sprintf("%s%s", UppercaseFirstCharacter(operationID), responseTypeSuffix)
+func (gs *generatorState) genResponseTypeName(operationID string) string {
+	return fmt.Sprintf("%s%s", UppercaseFirstCharacter(operationID), gs.responseTypeSuffix)
 }
 
-func getResponseTypeDefinitions(op *OperationDefinition) []ResponseTypeDefinition {
-	td, err := op.GetResponseTypeDefinitions()
+func (gs *generatorState) getResponseTypeDefinitions(op *OperationDefinition) []ResponseTypeDefinition {
+	td, err := gs.responseTypeDefinitions(op)
 	if err != nil {
 		panic(err)
 	}
@@ -318,32 +335,44 @@ func genServerURLWithVariablesFunctionParams(goTypePrefix string, variables map[
 
 // TemplateFunctions is passed to the template engine, and we can call each
 // function here by keyName from the template code.
-var TemplateFunctions = template.FuncMap{
-	"genParamArgs":               genParamArgs,
-	"genParamTypes":              genParamTypes,
-	"genParamNames":              genParamNames,
-	"genParamFmtString":          ReplacePathParamsWithStr,
-	"swaggerUriToIrisUri":        SwaggerUriToIrisUri,
-	"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
-	"swaggerUriToFiberUri":       SwaggerUriToFiberUri,
-	"swaggerUriToChiUri":         SwaggerUriToChiUri,
-	"swaggerUriToGinUri":         SwaggerUriToGinUri,
-	"swaggerUriToGorillaUri":     SwaggerUriToGorillaUri,
-	"swaggerUriToStdHttpUri":     SwaggerUriToStdHttpUri,
-	"lcFirst":                    LowercaseFirstCharacter,
-	"ucFirst":                    UppercaseFirstCharacter,
-	"ucFirstWithPkgName":         UppercaseFirstCharacterWithPkgName,
-	"camelCase":                  ToCamelCase,
-	"genResponsePayload":         genResponsePayload,
-	"genResponseTypeName":        genResponseTypeName,
-	"genResponseUnmarshal":       genResponseUnmarshal,
-	"getResponseTypeDefinitions": getResponseTypeDefinitions,
-	"toStringArray":              toStringArray,
-	"lower":                      strings.ToLower,
-	"title":                      titleCaser.String,
-	"stripNewLines":              stripNewLines,
-	"sanitizeGoIdentity":         SanitizeGoIdentity,
-	"toGoComment":                StringWithTypeNameToGoComment,
-
-	"genServerURLWithVariablesFunctionParams": genServerURLWithVariablesFunctionParams,
+//
+// As each generation uses its own functions, these are those of a generation
+// with the default options, which aren't replaced by a generation, so `opts`
+// returns the zero Configuration rather than the options of the latest one.
+var TemplateFunctions = newGeneratorState().templateFunctions()
+
+// templateFunctions returns the TemplateFunctions of the generation.
+func (gs *generatorState) templateFunctions() template.FuncMap {
+	return template.FuncMap{
+		"genParamArgs":               genParamArgs,
+		"genParamTypes":              genParamTypes,
+		"genParamNames":              genParamNames,
+		"genParamFmtString":          ReplacePathParamsWithStr,
+		"swaggerUriToIrisUri":        SwaggerUriToIrisUri,
+		"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
+		"swaggerUriToFiberUri":       SwaggerUriToFiberUri,
+		"swaggerUriToChiUri":         SwaggerUriToChiUri,
+		"swaggerUriToGinUri":         SwaggerUriToGinUri,
+		"swaggerUriToGorillaUri":     SwaggerUriToGorillaUri,
+		"swaggerUriToStdHttpUri":     SwaggerUriToStdHttpUri,
+		"lcFirst":                    LowercaseFirstCharacter,
+		"ucFirst":                    UppercaseFirstCharacter,
+		"ucFirstWithPkgName":         UppercaseFirstCharacterWithPkgName,
+		"camelCase":                  ToCamelCase,
+		"genResponsePayload":         gs.genResponsePayload,
+		"genResponseTypeName":        gs.genResponseTypeName,
+		"genResponseUnmarshal":       gs.genResponseUnmarshal,
+		"getResponseTypeDefinitions": gs.getResponseTypeDefinitions,
+		"genResponseEventStreams":    gs.genResponseEventStreams,
+		"hasNegotiatedResponses":     hasNegotiatedResponses,
+		"toStringArray":              toStringArray,
+		"lower":                      strings.ToLower,
+		"title":                      titleCaser.String,
+		"stripNewLines":              stripNewLines,
+		"sanitizeGoIdentity":         SanitizeGoIdentity,
+		"toGoComment":                StringWithTypeNameToGoComment,
+		"opts":                       func() Configuration { return gs.options },
+
+		"genServerURLWithVariablesFunctionParams": genServerURLWithVariablesFunctionParams,
+	}
 }
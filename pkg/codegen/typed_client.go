@@ -0,0 +1,200 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+// TypedClientOperationDefinition describes the `<Op>Typed` methods of the
+// ClientWithResponses for an operation, which return the body of a
+// successful response, or an error for any other response, when using the
+// `client-typed-methods` output option.
+type TypedClientOperationDefinition struct {
+	OperationDefinition
+	// Methods are the `<Op>Typed` methods, one for each of the operation's
+	// `<Op>WithResponse` methods
+	Methods []TypedClientMethodDefinition
+	// Successes are the 2xx responses with a JSON body, which all have the
+	// SuccessType
+	Successes []TypedResponseDefinition
+	// SuccessType is the Go type of the body of a successful response, which
+	// is empty when no successful response has a JSON body
+	SuccessType string
+	// Errors are the other responses, each of which has an error type
+	Errors []TypedResponseDefinition
+}
+
+// HasDefaultError returns whether the operation has a `default` response, so
+// that every unsuccessful response has an error type of the operation.
+func (o TypedClientOperationDefinition) HasDefaultError() bool {
+	for _, e := range o.Errors {
+		if e.ResponseName == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+// TypedClientMethodDefinition describes one of the `<Op>Typed` methods of
+// the ClientWithResponses.
+type TypedClientMethodDefinition struct {
+	// Name is the name of the method, such as `AddPetWithBodyTyped`
+	Name string
+	// WithResponseName is the name of the method that it calls, such as
+	// `AddPetWithBodyWithResponse`
+	WithResponseName string
+	// GenericBody is whether the method takes the body as an `io.Reader`,
+	// with its content type
+	GenericBody bool
+	// Body is the request body that the method takes, when it takes a typed
+	// body
+	Body *RequestBodyDefinition
+}
+
+// TypedResponseDefinition describes one of the responses of an operation, for
+// its `<Op>Typed` methods.
+type TypedResponseDefinition struct {
+	OperationId string
+	// ResponseName is the status code of the response, such as `404`, `4XX`
+	// or `default`
+	ResponseName string
+	// Field is the field of the `<Op>Response` which has the JSON body of the
+	// response, which is empty when it doesn't have one
+	Field string
+	// Type is the Go type of the JSON body of the response
+	Type string
+
+	// state is the state of the generation which the response was described
+	// in
+	state *generatorState
+}
+
+// ErrorTypeName is the name of the error type for the response, such as
+// `GetPet404Error`.
+func (r TypedResponseDefinition) ErrorTypeName() string {
+	return r.OperationId + r.state.orDefault().nameNormalizer(r.ResponseName) + "Error"
+}
+
+// Condition is the condition on the `response` of an `<Op>WithResponse`
+// method for the response to be this one, which is always true for the
+// `default` response.
+func (r TypedResponseDefinition) Condition() string {
+	return getConditionOfResponseName("response.StatusCode()", r.ResponseName)
+}
+
+// Description describes the status codes of the response, for the doc
+// comment of its error type.
+func (r TypedResponseDefinition) Description() string {
+	switch r.ResponseName {
+	case "default":
+		return "a status code which isn't otherwise described"
+	case "1XX", "2XX", "3XX", "4XX", "5XX":
+		return fmt.Sprintf("a %sxx status code", r.ResponseName[:1])
+	default:
+		return fmt.Sprintf("the %s status code", r.ResponseName)
+	}
+}
+
+// describeTypedClientOperation describes the `<Op>Typed` methods for the
+// operation, or returns nil, and adds a Diagnostic, when its successful
+// responses have bodies of different types, so that there's no single type
+// for them to return.
+func (gs *generatorState) describeTypedClientOperation(op OperationDefinition) (*TypedClientOperationDefinition, error) {
+	typed := &TypedClientOperationDefinition{
+		OperationDefinition: op,
+	}
+
+	tds, err := gs.responseTypeDefinitions(&op)
+	if err != nil {
+		return nil, err
+	}
+	if op.Spec != nil && op.Spec.Responses != nil {
+		for _, responseName := range SortedMapKeys(op.Spec.Responses.Map()) {
+			response := TypedResponseDefinition{
+				OperationId:  op.OperationId,
+				ResponseName: responseName,
+				state:        gs,
+			}
+			// the JSON body is preferably the `application/json` one, when
+			// there are several
+			for _, td := range tds {
+				if td.ResponseName != responseName || !util.IsMediaTypeJson(td.ContentTypeName) {
+					continue
+				}
+				if response.Field == "" || td.ContentTypeName == "application/json" {
+					response.Field = td.TypeName
+					response.Type = td.Schema.TypeDecl()
+				}
+			}
+
+			if !strings.HasPrefix(responseName, "2") {
+				typed.Errors = append(typed.Errors, response)
+				continue
+			}
+			if response.Field == "" {
+				continue
+			}
+			if typed.SuccessType != "" && typed.SuccessType != response.Type {
+				var pointer string
+				if ref := op.Spec.Responses.Value(responseName); ref != nil {
+					pointer = gs.pointerOf(ref.Value)
+				}
+				gs.addDiagnostic(DiagnosticTypedClientSkipped, pointer,
+					"the successful responses of %s have bodies of the different types %s and %s, so the client has no %sTyped methods",
+					op.OperationId, typed.SuccessType, response.Type, op.OperationId)
+				return nil, nil
+			}
+			typed.SuccessType = response.Type
+			typed.Successes = append(typed.Successes, response)
+		}
+	}
+
+	if !op.HasBody() {
+		typed.Methods = append(typed.Methods, TypedClientMethodDefinition{
+			Name:             op.OperationId + "Typed",
+			WithResponseName: op.OperationId + "WithResponse",
+		})
+		return typed, nil
+	}
+	typed.Methods = append(typed.Methods, TypedClientMethodDefinition{
+		Name:             op.OperationId + "WithBodyTyped",
+		WithResponseName: op.OperationId + "WithBodyWithResponse",
+		GenericBody:      true,
+	})
+	for i := range op.Bodies {
+		if !op.Bodies[i].IsSupportedByClient() {
+			continue
+		}
+		typed.Methods = append(typed.Methods, TypedClientMethodDefinition{
+			Name:             op.OperationId + op.Bodies[i].Suffix() + "Typed",
+			WithResponseName: op.OperationId + op.Bodies[i].Suffix() + "WithResponse",
+			Body:             &op.Bodies[i],
+		})
+	}
+	return typed, nil
+}
+
+// GenerateTypedClient generates the `<Op>Typed` methods of the
+// ClientWithResponses, and the error types for the unsuccessful responses of
+// each operation.
+func GenerateTypedClient(t *template.Template, ops []OperationDefinition) (string, error) {
+	return packageGeneratorState().generateTypedClient(t, ops)
+}
+
+func (gs *generatorState) generateTypedClient(t *template.Template, ops []OperationDefinition) (string, error) {
+	var typed []TypedClientOperationDefinition
+	for _, op := range ops {
+		def, err := gs.describeTypedClientOperation(op)
+		if err != nil {
+			return "", fmt.Errorf("error describing the typed methods of %s: %w", op.OperationId, err)
+		}
+		if def != nil {
+			typed = append(typed, *def)
+		}
+	}
+
+	return GenerateTemplates([]string{"client-typed.tmpl"}, t, typed)
+}
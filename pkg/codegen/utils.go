@@ -32,7 +32,6 @@ var (
 	pathParamRE    *regexp.Regexp
 	predeclaredSet map[string]struct{}
 	separatorSet   map[rune]struct{}
-	nameNormalizer NameNormalizer = ToCamelCase
 )
 
 type NameNormalizerFunction string
@@ -281,9 +280,13 @@ func ToCamelCaseWithDigits(s string) string {
 // ToCamelCaseWithInitialisms function will convert query-arg style strings to CamelCase with initialisms in uppercase.
 // So, httpOperationId would be converted to HTTPOperationID
 func ToCamelCaseWithInitialisms(s string) string {
+	return packageGeneratorState().toCamelCaseWithInitialisms(s)
+}
+
+func (gs *generatorState) toCamelCaseWithInitialisms(s string) string {
 	parts := camelCaseMatchParts.FindAllString(ToCamelCaseWithDigits(s), -1)
 	for i := range parts {
-		if v, ok := globalState.initialismsMap[strings.ToLower(parts[i])]; ok {
+		if v, ok := gs.initialismsMap[strings.ToLower(parts[i])]; ok {
 			parts[i] = v
 		}
 	}
@@ -292,16 +295,19 @@ func ToCamelCaseWithInitialisms(s string) string {
 
 var camelCaseMatchParts = regexp.MustCompile(`[\p{Lu}\d]+([\p{Ll}\d]+|$)`)
 
+// defaultInitialismsMap and defaultInitialismsRegexp are the initialisms of a
+// generation without any `additional-initialisms`.
+var defaultInitialismsMap, defaultInitialismsRegexp = makeInitialismsMap(nil)
+
 var initialismsList = []string{
 	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON",
 	"QPS", "RAM", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "GID", "UID", "UUID",
 	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS", "SIP", "RTP", "AMQP", "DB", "TS",
 }
 
-// targetWordRegex is a regex that matches all initialisms.
-var targetWordRegex *regexp.Regexp
-
-func makeInitialismsMap(additionalInitialisms []string) map[string]string {
+// makeInitialismsMap returns the map of the initialisms, with any additional
+// ones, and a regex that matches all of them.
+func makeInitialismsMap(additionalInitialisms []string) (map[string]string, *regexp.Regexp) {
 	l := append(initialismsList, additionalInitialisms...)
 
 	m := make(map[string]string, len(l))
@@ -310,19 +316,21 @@ func makeInitialismsMap(additionalInitialisms []string) map[string]string {
 	}
 
 	// Create a regex to match the initialisms
-	targetWordRegex = regexp.MustCompile(`(?i)(` + strings.Join(l, "|") + `)`)
-
-	return m
+	return m, regexp.MustCompile(`(?i)(` + strings.Join(l, "|") + `)`)
 }
 
 func ToCamelCaseWithInitialism(str string) string {
-	return replaceInitialism(ToCamelCase(str))
+	return packageGeneratorState().toCamelCaseWithInitialism(str)
+}
+
+func (gs *generatorState) toCamelCaseWithInitialism(str string) string {
+	return gs.replaceInitialism(ToCamelCase(str))
 }
 
-func replaceInitialism(s string) string {
+func (gs *generatorState) replaceInitialism(s string) string {
 	// These strings do not apply CamelCase
 	// Do not do CamelCase when these characters match when the preceding character is lowercase
-	return targetWordRegex.ReplaceAllStringFunc(s, func(s string) string {
+	return gs.initialismsRegexp.ReplaceAllStringFunc(s, func(s string) string {
 		// If the preceding character is lowercase, do not do CamelCase
 		if unicode.IsLower(rune(s[0])) {
 			return s
@@ -332,14 +340,14 @@ func replaceInitialism(s string) string {
 }
 
 // mediaTypeToCamelCase converts a media type to a PascalCase representation
-func mediaTypeToCamelCase(s string) string {
+func (gs *generatorState) mediaTypeToCamelCase(s string) string {
 	// ToCamelCase doesn't - and won't - add `/` to the characters it'll allow word boundary
 	s = strings.Replace(s, "/", "_", 1)
 	// including a _ to make sure that these are treated as word boundaries by `ToCamelCase`
 	s = strings.Replace(s, "*", "Wildcard_", 1)
 	s = strings.Replace(s, "+", "Plus_", 1)
 
-	return ToCamelCaseWithInitialism(s)
+	return gs.toCamelCaseWithInitialism(s)
 }
 
 // SortedMapKeys takes a map with keys of type string and returns a slice of those
@@ -440,34 +448,34 @@ func RefPathToObjName(refPath string) string {
 // URL components (http://deepmap.com/schemas/document.json#/Foo) are supported if they present in --import-mapping
 // Remote and URL also support standard local paths even though the spec doesn't mention them.
 func RefPathToGoType(refPath string) (string, error) {
-	return refPathToGoType(refPath, true)
+	return packageGeneratorState().refPathToGoType(refPath, true)
 }
 
 // refPathToGoType returns the Go typename for refPath given its
-func refPathToGoType(refPath string, local bool) (string, error) {
+func (gs *generatorState) refPathToGoType(refPath string, local bool) (string, error) {
 	if refPath[0] == '#' {
-		return refPathToGoTypeSelf(refPath, local)
+		return gs.refPathToGoTypeSelf(refPath, local)
 	}
 	pathParts := strings.Split(refPath, "#")
 	if len(pathParts) != 2 {
 		return "", fmt.Errorf("unsupported reference: %s", refPath)
 	}
 	remoteComponent, flatComponent := pathParts[0], pathParts[1]
-	goPkg, ok := globalState.importMapping[remoteComponent]
+	goPkg, ok := gs.importMapping[remoteComponent]
 
 	if !ok {
 		return "", fmt.Errorf("unrecognized external reference '%s'; please provide the known import for this reference using option --import-mapping", remoteComponent)
 	}
 
 	if goPkg.Path == importMappingCurrentPackage {
-		return refPathToGoTypeSelf(fmt.Sprintf("#%s", pathParts[1]), local)
+		return gs.refPathToGoTypeSelf(fmt.Sprintf("#%s", pathParts[1]), local)
 	}
 
-	return refPathToGoTypeRemote(flatComponent, goPkg)
+	return gs.refPathToGoTypeRemote(flatComponent, goPkg)
 
 }
 
-func refPathToGoTypeSelf(refPath string, local bool) (string, error) {
+func (gs *generatorState) refPathToGoTypeSelf(refPath string, local bool) (string, error) {
 	pathParts := strings.Split(refPath, "/")
 	depth := len(pathParts)
 	if local {
@@ -480,7 +488,7 @@ func refPathToGoTypeSelf(refPath string, local bool) (string, error) {
 
 	// Schemas may have been renamed locally, so look up the actual name in
 	// the spec.
-	name, err := findSchemaNameByRefPath(refPath, globalState.spec)
+	name, err := gs.findSchemaNameByRefPath(refPath, gs.spec)
 	if err != nil {
 		return "", fmt.Errorf("error finding ref: %s in spec: %v", refPath, err)
 	}
@@ -490,11 +498,11 @@ func refPathToGoTypeSelf(refPath string, local bool) (string, error) {
 	// lastPart now stores the final element of the type path. This is what
 	// we use as the base for a type name.
 	lastPart := pathParts[len(pathParts)-1]
-	return SchemaNameToTypeName(lastPart), nil
+	return gs.schemaNameToTypeName(lastPart), nil
 }
 
-func refPathToGoTypeRemote(flatComponent string, goPkg goImport) (string, error) {
-	goType, err := refPathToGoType("#"+flatComponent, false)
+func (gs *generatorState) refPathToGoTypeRemote(flatComponent string, goPkg goImport) (string, error) {
+	goType, err := gs.refPathToGoType("#"+flatComponent, false)
 	if err != nil {
 		return "", err
 	}
@@ -505,7 +513,7 @@ func refPathToGoTypeRemote(flatComponent string, goPkg goImport) (string, error)
 // #/components/schemas/Foo                     -> true
 // ./local/file.yml#/components/parameters/Bar  -> true
 // ./local/file.yml                             -> false
-// IsGoTypeReference can be used to check whether RefPathToGoType($ref) is possible.
+// IsGoTypeReference can be used to check whether gs.refPathToGoType($ref, true) is possible.
 func IsGoTypeReference(ref string) bool {
 	return ref != "" && !IsWholeDocumentReference(ref)
 }
@@ -748,6 +756,10 @@ func SanitizeGoIdentity(str string) string {
 // SanitizeEnumNames fixes illegal chars in the enum names
 // and removes duplicates
 func SanitizeEnumNames(enumNames, enumValues []string) map[string]string {
+	return packageGeneratorState().sanitizeEnumNames(enumNames, enumValues)
+}
+
+func (gs *generatorState) sanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 	dupCheck := make(map[string]int, len(enumValues))
 	deDup := make([][]string, 0, len(enumValues))
 
@@ -767,7 +779,7 @@ func SanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 
 	for _, p := range deDup {
 		n, v := p[0], p[1]
-		sanitized := SanitizeGoIdentity(SchemaNameToTypeName(n))
+		sanitized := SanitizeGoIdentity(gs.schemaNameToTypeName(n))
 
 		if _, dup := dupCheck[sanitized]; !dup {
 			sanitizedDeDup[sanitized] = v
@@ -835,7 +847,11 @@ func typeNamePrefix(name string) (prefix string) {
 // SchemaNameToTypeName converts a Schema name to a valid Go type name. It converts to camel case, and makes sure the name is
 // valid in Go
 func SchemaNameToTypeName(name string) string {
-	return typeNamePrefix(name) + nameNormalizer(name)
+	return packageGeneratorState().schemaNameToTypeName(name)
+}
+
+func (gs *generatorState) schemaNameToTypeName(name string) string {
+	return typeNamePrefix(name) + gs.nameNormalizer(name)
 }
 
 // According to the spec, additionalProperties may be true, false, or a
@@ -858,8 +874,12 @@ func SchemaHasAdditionalProperties(schema *openapi3.Schema) bool {
 // PathToTypeName converts a path, like Object/field1/nestedField into a go
 // type name.
 func PathToTypeName(path []string) string {
+	return packageGeneratorState().pathToTypeName(path)
+}
+
+func (gs *generatorState) pathToTypeName(path []string) string {
 	for i, p := range path {
-		path[i] = nameNormalizer(p)
+		path[i] = gs.nameNormalizer(p)
 	}
 	return strings.Join(path, "_")
 }
@@ -932,10 +952,10 @@ func EscapePathElements(path string) string {
 // and the definition of the schema. If the schema overrides the name via
 // x-go-name, the new name is returned, otherwise, the original name is
 // returned.
-func renameSchema(schemaName string, schemaRef *openapi3.SchemaRef) (string, error) {
+func (gs *generatorState) renameSchema(schemaName string, schemaRef *openapi3.SchemaRef) (string, error) {
 	// References will not change type names.
 	if schemaRef.Ref != "" {
-		return SchemaNameToTypeName(schemaName), nil
+		return gs.schemaNameToTypeName(schemaName), nil
 	}
 	schema := schemaRef.Value
 
@@ -946,14 +966,14 @@ func renameSchema(schemaName string, schemaRef *openapi3.SchemaRef) (string, err
 		}
 		return typeName, nil
 	}
-	return SchemaNameToTypeName(schemaName), nil
+	return gs.schemaNameToTypeName(schemaName), nil
 }
 
 // renameParameter generates the name for a parameter, taking x-go-name into
 // account
-func renameParameter(parameterName string, parameterRef *openapi3.ParameterRef) (string, error) {
+func (gs *generatorState) renameParameter(parameterName string, parameterRef *openapi3.ParameterRef) (string, error) {
 	if parameterRef.Ref != "" {
-		return SchemaNameToTypeName(parameterName), nil
+		return gs.schemaNameToTypeName(parameterName), nil
 	}
 	parameter := parameterRef.Value
 
@@ -964,14 +984,14 @@ func renameParameter(parameterName string, parameterRef *openapi3.ParameterRef)
 		}
 		return typeName, nil
 	}
-	return SchemaNameToTypeName(parameterName), nil
+	return gs.schemaNameToTypeName(parameterName), nil
 }
 
 // renameResponse generates the name for a parameter, taking x-go-name into
 // account
-func renameResponse(responseName string, responseRef *openapi3.ResponseRef) (string, error) {
+func (gs *generatorState) renameResponse(responseName string, responseRef *openapi3.ResponseRef) (string, error) {
 	if responseRef.Ref != "" {
-		return SchemaNameToTypeName(responseName), nil
+		return gs.schemaNameToTypeName(responseName), nil
 	}
 	response := responseRef.Value
 
@@ -982,14 +1002,14 @@ func renameResponse(responseName string, responseRef *openapi3.ResponseRef) (str
 		}
 		return typeName, nil
 	}
-	return SchemaNameToTypeName(responseName), nil
+	return gs.schemaNameToTypeName(responseName), nil
 }
 
 // renameRequestBody generates the name for a parameter, taking x-go-name into
 // account
-func renameRequestBody(requestBodyName string, requestBodyRef *openapi3.RequestBodyRef) (string, error) {
+func (gs *generatorState) renameRequestBody(requestBodyName string, requestBodyRef *openapi3.RequestBodyRef) (string, error) {
 	if requestBodyRef.Ref != "" {
-		return SchemaNameToTypeName(requestBodyName), nil
+		return gs.schemaNameToTypeName(requestBodyName), nil
 	}
 	requestBody := requestBodyRef.Value
 
@@ -1000,14 +1020,14 @@ func renameRequestBody(requestBodyName string, requestBodyRef *openapi3.RequestB
 		}
 		return typeName, nil
 	}
-	return SchemaNameToTypeName(requestBodyName), nil
+	return gs.schemaNameToTypeName(requestBodyName), nil
 }
 
 // findSchemaByRefPath turns a $ref path into a schema. This will return ""
 // if the schema wasn't found, and it'll only work successfully for schemas
 // defined within the spec that we parsed.
-func findSchemaNameByRefPath(refPath string, spec *openapi3.T) (string, error) {
-	if spec.Components == nil {
+func (gs *generatorState) findSchemaNameByRefPath(refPath string, spec *openapi3.T) (string, error) {
+	if spec == nil || spec.Components == nil {
 		return "", nil
 	}
 	pathElements := strings.Split(refPath, "/")
@@ -1028,19 +1048,19 @@ func findSchemaNameByRefPath(refPath string, spec *openapi3.T) (string, error) {
 	switch pathElements[2] {
 	case "schemas":
 		if schema, found := spec.Components.Schemas[propertyName]; found {
-			return renameSchema(propertyName, schema)
+			return gs.renameSchema(propertyName, schema)
 		}
 	case "parameters":
 		if parameter, found := spec.Components.Parameters[propertyName]; found {
-			return renameParameter(propertyName, parameter)
+			return gs.renameParameter(propertyName, parameter)
 		}
 	case "responses":
 		if response, found := spec.Components.Responses[propertyName]; found {
-			return renameResponse(propertyName, response)
+			return gs.renameResponse(propertyName, response)
 		}
 	case "requestBodies":
 		if requestBody, found := spec.Components.RequestBodies[propertyName]; found {
-			return renameRequestBody(propertyName, requestBody)
+			return gs.renameRequestBody(propertyName, requestBody)
 		}
 	}
 	return "", nil
@@ -232,8 +232,8 @@ components:
 }
 
 func TestRefPathToGoType(t *testing.T) {
-	old := globalState.importMapping
-	globalState.importMapping = constructImportMapping(
+	gs := newGeneratorState()
+	gs.importMapping = constructImportMapping(
 		map[string]string{
 			"doc.json":                    "externalref0",
 			"http://deepmap.com/doc.json": "externalref1",
@@ -241,7 +241,6 @@ func TestRefPathToGoType(t *testing.T) {
 			"dj-current-package.yml": "-",
 		},
 	)
-	defer func() { globalState.importMapping = old }()
 
 	tests := []struct {
 		name   string
@@ -304,7 +303,7 @@ func TestRefPathToGoType(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			goType, err := RefPathToGoType(tc.path)
+			goType, err := gs.refPathToGoType(tc.path, true)
 			if tc.goType == "" {
 				assert.Error(t, err)
 				return
@@ -715,7 +714,7 @@ func Test_replaceInitialism(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, replaceInitialism(tt.args.s), "replaceInitialism(%v)", tt.args.s)
+			assert.Equalf(t, tt.want, newGeneratorState().replaceInitialism(tt.args.s), "replaceInitialism(%v)", tt.args.s)
 		})
 	}
 }
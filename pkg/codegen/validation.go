@@ -0,0 +1,630 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ValidationDefinition describes the `Validate` method that is generated for a
+// type, when using the `generate.validation` option.
+type ValidationDefinition struct {
+	// TypeName is the name of the type that the method is generated for
+	TypeName string
+	// Statements are the Go statements which check each of the constraints of
+	// the type's schema, appending any that aren't satisfied to `errs`
+	Statements string
+}
+
+// GenerateValidation generates a `Validate() error` method for each of the
+// types which aren't an alias of another type, as well as the ValidationError
+// type that they return.
+func GenerateValidation(t *template.Template, types []TypeDefinition) (string, error) {
+	definedTypes := make(map[string]TypeDefinition)
+	for _, typ := range types {
+		if _, found := definedTypes[typ.TypeName]; !found {
+			definedTypes[typ.TypeName] = typ
+		}
+	}
+
+	var validations []ValidationDefinition
+	generated := make(map[string]bool)
+	for _, typ := range types {
+		if generated[typ.TypeName] || typ.IsAlias() {
+			continue
+		}
+		generated[typ.TypeName] = true
+
+		w := &validationWriter{
+			types: definedTypes,
+			buf:   &strings.Builder{},
+		}
+		if err := w.typeDefinition(typ); err != nil {
+			return "", fmt.Errorf("error generating validation for %s: %w", typ.TypeName, err)
+		}
+		validations = append(validations, ValidationDefinition{
+			TypeName:   typ.TypeName,
+			Statements: w.String(),
+		})
+	}
+
+	if len(validations) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []ValidationDefinition
+	}{
+		Types: validations,
+	}
+
+	return GenerateTemplates([]string{"validation.tmpl"}, t, context)
+}
+
+// validationOperationTypes returns the types that are defined for the
+// parameters and request bodies of each of the operations.
+func validationOperationTypes(ops []OperationDefinition) []TypeDefinition {
+	var types []TypeDefinition
+	for _, op := range ops {
+		types = append(types, op.TypeDefinitions...)
+		for _, body := range op.Bodies {
+			if body.IsSupported() {
+				types = append(types, *body.TypeDef(op.OperationId))
+			}
+		}
+	}
+	return types
+}
+
+// RequestValidationDefinition describes the validation of the request object
+// of a strict server's operation, when using the
+// `output-options.strict-server-request-validation` option.
+type RequestValidationDefinition struct {
+	OperationDefinition
+	// Statements are the Go statements which check each of the constraints of
+	// the operation's parameters and bodies, when using the generated
+	// validation, appending any that aren't satisfied to `errs`
+	Statements string
+}
+
+// GenerateStrictRequestValidation generates the validation of each of the
+// strict server's request objects, which is either performed by the generated
+// `Validate` methods, given the types that they're generated for, or against
+// the embedded specification.
+func GenerateStrictRequestValidation(t *template.Template, types []TypeDefinition, ops []OperationDefinition, opts Configuration) (string, error) {
+	definedTypes := make(map[string]TypeDefinition)
+	for _, typ := range types {
+		if _, found := definedTypes[typ.TypeName]; !found {
+			definedTypes[typ.TypeName] = typ
+		}
+	}
+
+	validations := make([]RequestValidationDefinition, len(ops))
+	for i, op := range ops {
+		validations[i].OperationDefinition = op
+		if opts.OutputOptions.StrictServerRequestValidation != StrictServerRequestValidationGenerated {
+			continue
+		}
+
+		w := &validationWriter{
+			types: definedTypes,
+			buf:   &strings.Builder{},
+		}
+		if err := w.request(op); err != nil {
+			return "", fmt.Errorf("error generating request validation for %s: %w", op.OperationId, err)
+		}
+		validations[i].Statements = w.String()
+	}
+
+	return GenerateTemplates([]string{"strict/strict-validation.tmpl"}, t, validations)
+}
+
+// validationWriter writes the statements of a `Validate` method, which check
+// the constraints of a type's schema.
+type validationWriter struct {
+	// types are the types that are generated, by their name, so references to
+	// other types can be followed
+	types map[string]TypeDefinition
+	buf   *strings.Builder
+	// vars is the number of variables that have been declared so far, to keep
+	// the name of each unique
+	vars int
+}
+
+func (w *validationWriter) String() string {
+	return w.buf.String()
+}
+
+func (w *validationWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(w.buf, format, args...)
+}
+
+// capture returns the statements written by f, rather than writing them, so
+// that they can be wrapped in a conditional or a loop.
+func (w *validationWriter) capture(f func() error) (string, error) {
+	saved := w.buf
+	w.buf = &strings.Builder{}
+	defer func() { w.buf = saved }()
+	err := f()
+	return w.buf.String(), err
+}
+
+func (w *validationWriter) newVar(prefix string) string {
+	w.vars++
+	return fmt.Sprintf("%s%d", prefix, w.vars)
+}
+
+// addError writes a statement which appends a ValidationError for field.
+func (w *validationWriter) addError(field string, message string) {
+	if field == `""` {
+		w.printf("errs = append(errs, ValidationError{Message: %q})\n", message)
+		return
+	}
+	w.printf("errs = append(errs, ValidationError{Field: %s, Message: %q})\n", field, message)
+}
+
+// typeDefinition writes the checks for the receiver, `v`, of a type.
+func (w *validationWriter) typeDefinition(typ TypeDefinition) error {
+	if name, ok := validationNamedType(typ.Schema); ok {
+		// a type which is defined from another type, such as `type Foo Bar`,
+		// has the constraints of Bar, but not its methods
+		return w.value(fmt.Sprintf("%s(v)", name), `""`, typ.Schema)
+	}
+
+	if err := w.value("v", `""`, typ.Schema); err != nil {
+		return err
+	}
+
+	w.union(typ.Schema)
+	return nil
+}
+
+// request writes the checks for the receiver, `request`, of the request object
+// of an operation.
+func (w *validationWriter) request(op OperationDefinition) error {
+	for _, param := range op.PathParams {
+		if err := w.value("request."+param.GoName(), strconv.Quote(param.ParamName), param.Schema); err != nil {
+			return fmt.Errorf("error generating validation for path parameter '%s': %w", param.ParamName, err)
+		}
+	}
+
+	if op.RequiresParamObject() {
+		w.printf("errs = appendValidationErrors(errs, \"\", request.Params.Validate())\n")
+	}
+
+	multipleBodies := len(op.Bodies) > 1
+	for _, body := range op.Bodies {
+		// the items of a stream are only read once, by the handler
+		if body.IsStream() || !body.IsJSON() && !body.IsXML() && body.NameTag != "Formdata" {
+			continue
+		}
+		bodyExpr := "request.Body"
+		if multipleBodies {
+			bodyExpr = "request." + body.NameTag + "Body"
+		}
+		checks, err := w.capture(func() error {
+			return w.value("*"+bodyExpr, `"body"`, Schema{GoType: body.TypeDef(op.OperationId).TypeName})
+		})
+		if err != nil {
+			return fmt.Errorf("error generating validation for %s body: %w", body.ContentType, err)
+		}
+		if checks != "" {
+			w.printf("if %s != nil {\n%s}\n", bodyExpr, checks)
+		}
+	}
+	return nil
+}
+
+// value writes the checks for expr, an expression of the Go type of schema,
+// which is found at field, a Go expression of the path to the value.
+func (w *validationWriter) value(expr string, field string, schema Schema) error {
+	if name, ok := validationNamedType(schema); ok {
+		typ, found := w.types[name]
+		switch {
+		case found && typ.IsAlias():
+			// aliases don't have methods of their own, so their constraints
+			// need to be checked in place
+			return w.value(expr, field, typ.Schema)
+		case found:
+			w.printf("errs = appendValidationErrors(errs, %s, %s.Validate())\n", field, validationOperand(expr))
+		default:
+			// types from other packages, or provided by `x-go-type`, may
+			// implement Validate, too
+			w.printf("errs = appendValidationErrors(errs, %s, validateValue(%s))\n", field, expr)
+		}
+		return nil
+	}
+
+	oapiSchema := schema.OAPISchema
+	if oapiSchema == nil {
+		oapiSchema = &openapi3.Schema{}
+	}
+
+	w.enum(expr, field, schema.GoType, oapiSchema)
+
+	switch {
+	case schema.GoType == "string":
+		w.string(expr, field, oapiSchema)
+	case validationIsNumber(schema.GoType):
+		w.number(expr, field, schema.GoType, oapiSchema)
+	case schema.ArrayType != nil:
+		if err := w.array(expr, field, *schema.ArrayType, oapiSchema); err != nil {
+			return err
+		}
+	case strings.HasPrefix(schema.GoType, "map[") && schema.AdditionalPropertiesType != nil:
+		if err := w.mapValues(expr, field, *schema.AdditionalPropertiesType); err != nil {
+			return err
+		}
+	case strings.HasPrefix(schema.GoType, "struct"):
+		if err := w.properties(expr, field, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *validationWriter) enum(expr string, field string, goType string, schema *openapi3.Schema) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var values []string
+	var descriptions []string
+	for _, value := range schema.Enum {
+		var literal string
+		switch v := value.(type) {
+		case nil:
+			// `null` is handled by the (optional) pointer
+			continue
+		case string:
+			if goType != "string" {
+				return
+			}
+			literal = strconv.Quote(v)
+			descriptions = append(descriptions, v)
+		case float64:
+			if !validationIsNumber(goType) || (validationIsInteger(goType) && v != math.Trunc(v)) {
+				return
+			}
+			literal = strconv.FormatFloat(v, 'f', -1, 64)
+			descriptions = append(descriptions, literal)
+		case bool:
+			if goType != "bool" {
+				return
+			}
+			literal = strconv.FormatBool(v)
+			descriptions = append(descriptions, literal)
+		default:
+			return
+		}
+		if seen[literal] {
+			continue
+		}
+		seen[literal] = true
+		values = append(values, literal)
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	w.printf("switch %s {\n", expr)
+	w.printf("case %s:\n", strings.Join(values, ", "))
+	w.printf("default:\n")
+	w.addError(field, "must be one of: "+strings.Join(descriptions, ", "))
+	w.printf("}\n")
+}
+
+func (w *validationWriter) string(expr string, field string, schema *openapi3.Schema) {
+	if expr == "v" {
+		// the receiver is a defined type, such as an enum
+		expr = "string(v)"
+	}
+
+	if schema.MinLength > 0 {
+		w.printf("if utf8.RuneCountInString(%s) < %d {\n", expr, schema.MinLength)
+		w.addError(field, fmt.Sprintf("length must be at least %d", schema.MinLength))
+		w.printf("}\n")
+	}
+	if schema.MaxLength != nil {
+		w.printf("if utf8.RuneCountInString(%s) > %d {\n", expr, *schema.MaxLength)
+		w.addError(field, fmt.Sprintf("length must be at most %d", *schema.MaxLength))
+		w.printf("}\n")
+	}
+	if schema.Pattern != "" {
+		// patterns which use features that Go's regexp package doesn't
+		// support, such as lookarounds, can't be validated
+		if _, err := regexp.Compile(schema.Pattern); err == nil {
+			w.printf("if !validatePattern(%q, %s) {\n", schema.Pattern, expr)
+			w.addError(field, fmt.Sprintf("must match the pattern %s", schema.Pattern))
+			w.printf("}\n")
+		}
+	}
+}
+
+func (w *validationWriter) number(expr string, field string, goType string, schema *openapi3.Schema) {
+	if schema.Min != nil && !(strings.HasPrefix(goType, "uint") && *schema.Min <= 0 && !schema.ExclusiveMin) {
+		operator, message := "<", "must be greater than or equal to %s"
+		if schema.ExclusiveMin {
+			operator, message = "<=", "must be greater than %s"
+		}
+		bound := strconv.FormatFloat(*schema.Min, 'f', -1, 64)
+		w.printf("if %s %s %s {\n", validationNumberExpr(expr, goType, *schema.Min), operator, bound)
+		w.addError(field, fmt.Sprintf(message, bound))
+		w.printf("}\n")
+	}
+	if schema.Max != nil {
+		operator, message := ">", "must be less than or equal to %s"
+		if schema.ExclusiveMax {
+			operator, message = ">=", "must be less than %s"
+		}
+		bound := strconv.FormatFloat(*schema.Max, 'f', -1, 64)
+		w.printf("if %s %s %s {\n", validationNumberExpr(expr, goType, *schema.Max), operator, bound)
+		w.addError(field, fmt.Sprintf(message, bound))
+		w.printf("}\n")
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		multipleOf := *schema.MultipleOf
+		bound := strconv.FormatFloat(multipleOf, 'f', -1, 64)
+		if validationIntegerRepresents(goType, multipleOf) {
+			w.printf("if %s%%%s != 0 {\n", expr, bound)
+		} else {
+			w.printf("if !validateMultipleOf(float64(%s), %s) {\n", expr, bound)
+		}
+		w.addError(field, fmt.Sprintf("must be a multiple of %s", bound))
+		w.printf("}\n")
+	}
+}
+
+func (w *validationWriter) array(expr string, field string, itemSchema Schema, schema *openapi3.Schema) error {
+	if schema.MinItems > 0 {
+		w.printf("if len(%s) < %d {\n", expr, schema.MinItems)
+		w.addError(field, fmt.Sprintf("must have at least %d items", schema.MinItems))
+		w.printf("}\n")
+	}
+	if schema.MaxItems != nil {
+		w.printf("if len(%s) > %d {\n", expr, *schema.MaxItems)
+		w.addError(field, fmt.Sprintf("must have at most %d items", *schema.MaxItems))
+		w.printf("}\n")
+	}
+	if schema.UniqueItems {
+		w.printf("if !validateUniqueItems(%s) {\n", expr)
+		w.addError(field, "items must be unique")
+		w.printf("}\n")
+	}
+
+	index, item := w.newVar("i"), w.newVar("v")
+	itemChecks, err := w.capture(func() error {
+		return w.value(item, validationIndexField(field, fmt.Sprintf("strconv.Itoa(%s)", index)), itemSchema)
+	})
+	if err != nil {
+		return fmt.Errorf("error generating validation for array items: %w", err)
+	}
+	if itemChecks != "" {
+		w.printf("for %s, %s := range %s {\n%s}\n", index, item, expr, itemChecks)
+	}
+	return nil
+}
+
+func (w *validationWriter) mapValues(expr string, field string, valueSchema Schema) error {
+	key, value := w.newVar("k"), w.newVar("v")
+	valueChecks, err := w.capture(func() error {
+		return w.value(value, validationIndexField(field, fmt.Sprintf("strconv.Quote(%s)", key)), valueSchema)
+	})
+	if err != nil {
+		return fmt.Errorf("error generating validation for additional properties: %w", err)
+	}
+	if valueChecks != "" {
+		w.printf("for %s, %s := range %s {\n%s}\n", key, value, expr, valueChecks)
+	}
+	return nil
+}
+
+func (w *validationWriter) properties(expr string, field string, schema Schema) error {
+	for _, p := range schema.Properties {
+		propertyExpr := validationOperand(expr) + "." + p.GoFieldName()
+		propertyField := validationPropertyField(field, p.JsonFieldName)
+		typeDef := p.GoTypeDef()
+
+		switch {
+		case strings.HasPrefix(typeDef, "nullable.Nullable["):
+			if p.Required {
+				w.printf("if !%s.IsSpecified() {\n", propertyExpr)
+				w.addError(propertyField, "is required")
+				w.printf("}\n")
+			}
+			value := w.newVar("v")
+			checks, err := w.capture(func() error {
+				return w.value(value, propertyField, p.Schema)
+			})
+			if err != nil {
+				return fmt.Errorf("error generating validation for property '%s': %w", p.JsonFieldName, err)
+			}
+			if checks != "" {
+				w.printf("if %s, err := %s.Get(); err == nil {\n%s}\n", value, propertyExpr, checks)
+			}
+		case strings.HasPrefix(typeDef, "*"):
+			checks, err := w.capture(func() error {
+				return w.value("*"+propertyExpr, propertyField, p.Schema)
+			})
+			if err != nil {
+				return fmt.Errorf("error generating validation for property '%s': %w", p.JsonFieldName, err)
+			}
+			if checks != "" {
+				w.printf("if %s != nil {\n%s}\n", propertyExpr, checks)
+			}
+		default:
+			if p.Required && w.isNilable(p.Schema) {
+				// a required array or object that is absent is left as nil
+				w.printf("if %s == nil {\n", propertyExpr)
+				w.addError(propertyField, "is required")
+				w.printf("}\n")
+			}
+			if err := w.value(propertyExpr, propertyField, p.Schema); err != nil {
+				return fmt.Errorf("error generating validation for property '%s': %w", p.JsonFieldName, err)
+			}
+		}
+	}
+
+	if schema.HasAdditionalProperties && schema.AdditionalPropertiesType != nil {
+		if err := w.mapValues(expr+".AdditionalProperties", field, *schema.AdditionalPropertiesType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// union writes the checks for the `oneOf` or `anyOf` of the receiver, which
+// is satisfied when the union holds one of its elements, and that element is
+// itself valid.
+func (w *validationWriter) union(schema Schema) {
+	if len(schema.UnionElements) == 0 {
+		return
+	}
+
+	if schema.Discriminator != nil && len(schema.Discriminator.Mapping) != 0 {
+		w.printf("if value, err := v.ValueByDiscriminator(); err != nil {\n")
+		w.printf("errs = append(errs, ValidationError{Message: err.Error()})\n")
+		w.printf("} else {\n")
+		w.printf("errs = appendValidationErrors(errs, \"\", validateValue(value))\n")
+		w.printf("}\n")
+		return
+	}
+
+	keyword := "anyOf"
+	if schema.OAPISchema != nil && schema.OAPISchema.OneOf != nil {
+		keyword = "oneOf"
+	}
+
+	elements := make([]string, len(schema.UnionElements))
+	for i, element := range schema.UnionElements {
+		elements[i] = fmt.Sprintf("validateUnionElement(v.As%s())", element.Method())
+	}
+	w.printf("if !(%s) {\n", strings.Join(elements, " ||\n"))
+	w.addError(`""`, fmt.Sprintf("must match one of the schemas in %s", keyword))
+	w.printf("}\n")
+}
+
+// isNilable returns whether the Go type of schema, once any aliases are
+// followed, is a slice or a map.
+func (w *validationWriter) isNilable(schema Schema) bool {
+	for {
+		name, ok := validationNamedType(schema)
+		if !ok {
+			break
+		}
+		typ, found := w.types[name]
+		if !found || !typ.IsAlias() {
+			return false
+		}
+		schema = typ.Schema
+	}
+	return strings.HasPrefix(schema.GoType, "[]") || strings.HasPrefix(schema.GoType, "map[")
+}
+
+// validationOperand returns expr in a form that a selector or method call can
+// be applied to, which a dereference needs to be parenthesized for.
+func validationOperand(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+var validationNamedTypeRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// validationNamedType returns the name of the type that schema refers to,
+// if it isn't one of Go's predeclared types, or one of the types that are used
+// for the string formats.
+func validationNamedType(schema Schema) (string, bool) {
+	if schema.RefType != "" {
+		return schema.RefType, true
+	}
+
+	goType := schema.GoType
+	if !validationNamedTypeRe.MatchString(goType) {
+		return "", false
+	}
+	switch goType {
+	case "string", "bool", "byte", "rune", "any", "time.Time", "json.RawMessage":
+		return "", false
+	}
+	if validationIsNumber(goType) || strings.HasPrefix(goType, "openapi_types.") {
+		return "", false
+	}
+	return goType, true
+}
+
+func validationIsInteger(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func validationIsNumber(goType string) bool {
+	return validationIsInteger(goType) || goType == "float32" || goType == "float64"
+}
+
+// validationNumberExpr returns expr in a form that can be compared to bound,
+// which is converted to a float64 if bound isn't representable by goType.
+func validationNumberExpr(expr string, goType string, bound float64) string {
+	if validationIsInteger(goType) && !validationIntegerRepresents(goType, bound) {
+		return fmt.Sprintf("float64(%s)", expr)
+	}
+	return expr
+}
+
+// validationIntegerRepresents returns whether the integer goType can represent
+// value, so that it can be used as a constant of that type.
+func validationIntegerRepresents(goType string, value float64) bool {
+	if value != math.Trunc(value) {
+		return false
+	}
+	var minimum, maximum float64
+	switch goType {
+	case "int8":
+		minimum, maximum = math.MinInt8, math.MaxInt8
+	case "int16":
+		minimum, maximum = math.MinInt16, math.MaxInt16
+	case "uint8":
+		maximum = math.MaxUint8
+	case "uint16":
+		maximum = math.MaxUint16
+	case "uint", "uint32", "uint64":
+		maximum = math.MaxUint32
+	default:
+		minimum, maximum = math.MinInt32, math.MaxInt32
+	}
+	return value >= minimum && value <= maximum
+}
+
+// validationPropertyField returns the Go expression for the path of the
+// property name, within the value at field.
+func validationPropertyField(field string, name string) string {
+	if field == `""` {
+		return strconv.Quote(name)
+	}
+	return validationConcatField(field, strconv.Quote("."+name))
+}
+
+// validationIndexField returns the Go expression for the path of an item,
+// identified by the Go expression index, within the value at field.
+func validationIndexField(field string, index string) string {
+	return validationConcatField(validationConcatField(field, `"["`)+" + "+index, `"]"`)
+}
+
+// validationConcatField concatenates the Go string literal suffix onto the Go
+// expression field, merging them if field ends with a string literal.
+func validationConcatField(field string, suffix string) string {
+	if strings.HasSuffix(field, `"`) {
+		return field[:len(field)-1] + suffix[1:]
+	}
+	return field + " + " + suffix
+}
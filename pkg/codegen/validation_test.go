@@ -0,0 +1,263 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestGenerateValidation(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Validation: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/validation.yaml")
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "type ValidationErrors []ValidationError")
+
+	// Check that each of the defined types can be validated
+	assert.Contains(t, code, "func (v Pet) Validate() error {")
+	assert.Contains(t, code, "func (v Status) Validate() error {")
+	assert.Contains(t, code, "func (v Toy) Validate() error {")
+	assert.Contains(t, code, "func (v FindPetsParams) Validate() error {")
+	assert.Contains(t, code, "func (v AddPetJSONBody) Validate() error {")
+	// but not the aliases, which can't have methods
+	assert.NotContains(t, code, "func (v Name) Validate() error {")
+	assert.NotContains(t, code, "func (v Scores) Validate() error {")
+
+	// Check the constraints of an alias are checked where it's used
+	assert.Contains(t, code, `if utf8.RuneCountInString(v.Name) > 20 {`)
+	assert.Contains(t, code, `if !validatePattern("^[A-Za-z ]+$", v.Name) {`)
+	// but a pattern that Go doesn't support isn't
+	assert.NotContains(t, code, `validatePattern("^(?!foo)"`)
+
+	// Check that nested values are validated, with the path to them
+	assert.Contains(t, code, `errs = appendValidationErrors(errs, "status", v.Status.Validate())`)
+	assert.Contains(t, code, `errs = appendValidationErrors(errs, "toys["+strconv.Itoa(i5)+"]", v6.Validate())`)
+	assert.Contains(t, code, `errs = append(errs, ValidationError{Field: "owner.nickname", Message: "length must be at least 2"})`)
+
+	// Check the numeric constraints
+	assert.Contains(t, code, `if *v.Age >= 50 {`)
+	assert.Contains(t, code, `if *v.Legs%2 != 0 {`)
+	assert.Contains(t, code, `if !validateMultipleOf(float64(*v.Weight), 0.5) {`)
+
+	// Check the array constraints
+	assert.Contains(t, code, `if v.Tags == nil {`)
+	assert.Contains(t, code, `if !validateUniqueItems(v.Tags) {`)
+
+	// Check the unions
+	assert.Contains(t, code, `validateUnionElement(v.AsBall())`)
+	assert.Contains(t, code, `if value, err := v.ValueByDiscriminator(); err != nil {`)
+}
+
+func TestGenerateValidationRequiresModels(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client:     true,
+			Validation: true,
+		},
+	}
+
+	assert.Error(t, opts.Validate())
+}
+
+func TestGenerateStrictRequestValidation(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/validation.yaml")
+	require.NoError(t, err)
+
+	servers := map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"chi":      {ChiServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	}
+
+	for name, generate := range servers {
+		t.Run(name+"/generated", func(t *testing.T) {
+			generate.Models = true
+			generate.Strict = true
+			generate.Validation = true
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    generate,
+				OutputOptions: OutputOptions{
+					StrictServerRequestValidation: StrictServerRequestValidationGenerated,
+				},
+			}
+			require.NoError(t, opts.Validate())
+
+			code, err := Generate(swagger, opts)
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			assert.NoError(t, err)
+
+			assert.Contains(t, code, "type RequestValidationError struct {")
+			assert.Contains(t, code, "if errs := request.validate(); len(errs) != 0 {")
+			assert.Contains(t, code, "func (request FindPetsRequestObject) validate() ValidationErrors {")
+			assert.Contains(t, code, `errs = appendValidationErrors(errs, "", request.Params.Validate())`)
+			assert.Contains(t, code, `errs = appendValidationErrors(errs, "body", (*request.Body).Validate())`)
+			assert.Contains(t, code, `errs = append(errs, ValidationError{Field: "id", Message: "must be greater than or equal to 1"})`)
+			assert.NotContains(t, code, "func validateRequestWithSpec(")
+		})
+
+		t.Run(name+"/embedded-spec", func(t *testing.T) {
+			generate.Models = true
+			generate.Strict = true
+			generate.EmbeddedSpec = true
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    generate,
+				OutputOptions: OutputOptions{
+					StrictServerRequestValidation: StrictServerRequestValidationEmbeddedSpec,
+				},
+			}
+			require.NoError(t, opts.Validate())
+
+			code, err := Generate(swagger, opts)
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			assert.NoError(t, err)
+
+			assert.Contains(t, code, "type ValidationErrors []ValidationError")
+			assert.Contains(t, code, "if errs := request.validate(); len(errs) != 0 {")
+			assert.Contains(t, code, `return validateRequestWithSpec("DELETE", "/pets/{id}", map[string]interface{}{`)
+			assert.Contains(t, code, `"query.limit": request.Params.Limit,`)
+			assert.Contains(t, code, `"application/json": request.Body,`)
+			assert.Contains(t, code, "func validateRequestWithSpec(")
+		})
+	}
+}
+
+func TestGenerateStrictRequestValidationWithoutSchemas(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: No schemas
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '204':
+          description: The pet exists
+`))
+	require.NoError(t, err)
+
+	validations := map[StrictServerRequestValidation]GenerateOptions{
+		StrictServerRequestValidationGenerated:    {Validation: true},
+		StrictServerRequestValidationEmbeddedSpec: {Validation: true, EmbeddedSpec: true},
+	}
+
+	for validation, generate := range validations {
+		t.Run(string(validation), func(t *testing.T) {
+			generate.Models = true
+			generate.StdHTTPServer = true
+			generate.Strict = true
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    generate,
+				OutputOptions: OutputOptions{
+					StrictServerRequestValidation: validation,
+				},
+			}
+			require.NoError(t, opts.Validate())
+
+			code, err := Generate(swagger, opts)
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			assert.NoError(t, err)
+
+			// the errors are generated once, even though none of the types
+			// have constraints to validate
+			assert.Equal(t, 1, strings.Count(code, "type ValidationErrors []ValidationError"))
+			assert.Equal(t, 1, strings.Count(code, "func appendValidationErrors("))
+		})
+	}
+}
+
+func TestStrictRequestValidationOptions(t *testing.T) {
+	tests := map[string]struct {
+		generate   GenerateOptions
+		validation StrictServerRequestValidation
+		valid      bool
+	}{
+		"generated": {
+			generate:   GenerateOptions{StdHTTPServer: true, Strict: true, Models: true, Validation: true},
+			validation: StrictServerRequestValidationGenerated,
+			valid:      true,
+		},
+		"generated without validation": {
+			generate:   GenerateOptions{StdHTTPServer: true, Strict: true, Models: true},
+			validation: StrictServerRequestValidationGenerated,
+		},
+		"without strict-server": {
+			generate:   GenerateOptions{StdHTTPServer: true, Models: true, Validation: true},
+			validation: StrictServerRequestValidationGenerated,
+		},
+		"embedded-spec": {
+			generate:   GenerateOptions{StdHTTPServer: true, Strict: true, Models: true, EmbeddedSpec: true},
+			validation: StrictServerRequestValidationEmbeddedSpec,
+			valid:      true,
+		},
+		"embedded-spec without embedded-spec": {
+			generate:   GenerateOptions{StdHTTPServer: true, Strict: true, Models: true},
+			validation: StrictServerRequestValidationEmbeddedSpec,
+		},
+		"unknown": {
+			generate:   GenerateOptions{StdHTTPServer: true, Strict: true, Models: true, Validation: true},
+			validation: "openapi3filter",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := Configuration{
+				PackageName: "api",
+				Generate:    test.generate,
+				OutputOptions: OutputOptions{
+					StrictServerRequestValidation: test.validation,
+				},
+			}
+			if test.valid {
+				assert.NoError(t, opts.Validate())
+			} else {
+				assert.Error(t, opts.Validate())
+			}
+		})
+	}
+}
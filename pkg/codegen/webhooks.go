@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// specWebhooksKey is the top-level key that OpenAPI 3.1 uses for the Webhooks
+// Object (https://spec.openapis.org/oas/v3.1.0#oasWebhooks).
+//
+// NOTE that kin-openapi doesn't (yet) model OpenAPI 3.1, so the `webhooks` are
+// retained as an untyped entry in the document's Extensions.
+const specWebhooksKey = "webhooks"
+
+// decodeWebhooks decodes the `webhooks` of a specification into PathItems,
+// keyed by the name of the webhook. Any references within them are left
+// unresolved.
+func decodeWebhooks(swagger *openapi3.T) (map[string]*openapi3.PathItem, error) {
+	if swagger == nil {
+		return nil, nil
+	}
+
+	raw, ok := swagger.Extensions[specWebhooksKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling webhooks: %w", err)
+	}
+
+	var webhooks map[string]*openapi3.PathItem
+	if err := json.Unmarshal(encoded, &webhooks); err != nil {
+		return nil, fmt.Errorf("error unmarshaling webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// specWebhooks decodes the `webhooks` of a specification into PathItems, keyed
+// by the name of the webhook, and resolves any references within them against
+// the specification's components.
+func (gs *generatorState) specWebhooks(swagger *openapi3.T) (map[string]*openapi3.PathItem, error) {
+	webhooks, err := decodeWebhooks(swagger)
+	if err != nil || len(webhooks) == 0 {
+		return nil, err
+	}
+
+	// Resolve the references by handing the webhooks to the loader as if they
+	// were paths of a document that shares our components.
+	paths := openapi3.NewPaths()
+	for name, pathItem := range webhooks {
+		if pathItem == nil {
+			return nil, fmt.Errorf("webhook %s has no value", name)
+		}
+		paths.Set(name, pathItem)
+	}
+	doc := &openapi3.T{
+		OpenAPI:    swagger.OpenAPI,
+		Info:       swagger.Info,
+		Components: swagger.Components,
+		Paths:      paths,
+	}
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	if err := loader.ResolveRefsIn(doc, nil); err != nil {
+		return nil, fmt.Errorf("error resolving references in webhooks: %w", err)
+	}
+	if gs.diagnostics != nil {
+		gs.diagnostics.index().webhooks(webhooks)
+	}
+
+	return webhooks, nil
+}
+
+// WebhookDefinitions returns an OperationDefinition for each operation of the
+// `webhooks` defined in an OpenAPI 3.1 specification.
+//
+// The Path of each operation is the name of the webhook, as the URL that a
+// webhook is delivered to is decided by each of its subscribers.
+func WebhookDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]OperationDefinition, error) {
+	gs := packageGeneratorState()
+	gs.spec = swagger
+	return gs.webhookDefinitions(swagger, initialismOverrides)
+}
+
+func (gs *generatorState) webhookDefinitions(swagger *openapi3.T, initialismOverrides bool) ([]OperationDefinition, error) {
+	var operations []OperationDefinition
+
+	var toCamelCaseFunc func(string) string
+	if initialismOverrides {
+		toCamelCaseFunc = gs.toCamelCaseWithInitialism
+	} else {
+		toCamelCaseFunc = ToCamelCase
+	}
+
+	webhooks, err := gs.specWebhooks(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range SortedMapKeys(webhooks) {
+		webhookOperations, err := gs.describePathItemOperations(swagger, name, webhooks[name], toCamelCaseFunc)
+		if err != nil {
+			return nil, fmt.Errorf("error describing webhook %s: %w", name, err)
+		}
+		for i := range webhookOperations {
+			webhookOperations[i].IsWebhook = true
+		}
+		operations = append(operations, webhookOperations...)
+	}
+	return operations, nil
+}
+
+// GenerateWebhooks generates the receiver-side WebhookServerInterface, along
+// with its net/http wrappers, and the sender-side WebhookClient for each of the
+// webhook operations.
+func GenerateWebhooks(t *template.Template, ops []OperationDefinition, opts Configuration) (string, error) {
+	return generateWebhookTemplates(t, webhookTemplateContext{Prefix: "Webhook", Operations: ops}, opts)
+}
+
+// webhookTemplateContext is passed to the webhooks/*.tmpl templates, which
+// render both the webhooks and the callbacks.
+type webhookTemplateContext struct {
+	// Prefix is prepended to the names of the generated interfaces and types,
+	// such as WebhookServerInterface or CallbackClient.
+	Prefix string
+	// Callbacks is set when the operations are callbacks, whose clients resolve
+	// their URLs from the runtime expressions of the callbacks.
+	Callbacks  bool
+	Operations []OperationDefinition
+}
+
+func generateWebhookTemplates(t *template.Template, data webhookTemplateContext, opts Configuration) (string, error) {
+	templates := []string{
+		"webhooks/webhook-interface.tmpl",
+		"webhooks/webhook-handler.tmpl",
+	}
+	if opts.Generate.Strict {
+		templates = append(templates, "webhooks/webhook-strict.tmpl")
+	}
+	templates = append(templates, "webhooks/webhook-client.tmpl")
+
+	return GenerateTemplates(templates, t, data)
+}
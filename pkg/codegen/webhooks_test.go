@@ -0,0 +1,122 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+)
+
+func TestWebhookDefinitions(t *testing.T) {
+	swagger, err := util.LoadSwagger("test_specs/webhooks.yaml")
+	require.NoError(t, err)
+
+	ops, err := WebhookDefinitions(swagger, false)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+
+	assert.Equal(t, "NewPet", ops[0].OperationId)
+	assert.Equal(t, "newPet", ops[0].Path)
+	assert.Equal(t, "POST", ops[0].Method)
+	assert.True(t, ops[0].IsWebhook)
+	assert.Len(t, ops[0].HeaderParams, 1)
+	assert.Len(t, ops[0].QueryParams, 1)
+	require.Len(t, ops[0].Bodies, 1)
+	// the reference to the component has been resolved
+	assert.Equal(t, "Pet", ops[0].Bodies[0].Schema.GoType)
+
+	assert.Equal(t, "PetRemoved", ops[1].OperationId)
+	assert.Equal(t, "petRemoved", ops[1].Path)
+	assert.Equal(t, "DELETE", ops[1].Method)
+	assert.True(t, ops[1].IsWebhook)
+}
+
+func TestGenerateWebhooks(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Webhooks: true,
+			Strict:   true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/webhooks.yaml")
+	require.NoError(t, err)
+
+	// Run our code generation:
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	// Check that we have valid (formattable) code:
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that the models only referenced by webhooks aren't pruned
+	assert.Contains(t, code, "type Pet struct {")
+	assert.Contains(t, code, "type PetRemoval struct {")
+	assert.Contains(t, code, "type NewPetParams struct {")
+	assert.Contains(t, code, "type NewPetJSONRequestBody = Pet")
+
+	// Check the receiver
+	assert.Contains(t, code, "type WebhookServerInterface interface {")
+	assert.Contains(t, code, "NewPet(w http.ResponseWriter, r *http.Request, params NewPetParams)")
+	assert.Contains(t, code, "PetRemoved(w http.ResponseWriter, r *http.Request)")
+	assert.Contains(t, code, "func NewPetWebhookHandler(si WebhookServerInterface, options WebhookHandlerOptions) http.Handler {")
+
+	// Check the strict receiver
+	assert.Contains(t, code, "type WebhookStrictServerInterface interface {")
+	assert.Contains(t, code, "NewPet(ctx context.Context, request NewPetRequestObject) (NewPetResponseObject, error)")
+	assert.Contains(t, code, "func (response NewPet410Response) VisitNewPetResponse(w http.ResponseWriter) error {")
+	assert.Contains(t, code, "func NewWebhookStrictHandler(ssi WebhookStrictServerInterface, middlewares []strictnethttp.StrictHTTPMiddlewareFunc) WebhookServerInterface {")
+
+	// Check the sender
+	assert.Contains(t, code, "type HttpRequestDoer interface {")
+	assert.Contains(t, code, "func (c *WebhookClient) NewPet(ctx context.Context, targetURL string, params *NewPetParams, body NewPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+	assert.Contains(t, code, "func NewPetRemovedWebhookRequestWithBody(targetURL string, contentType string, body io.Reader) (*http.Request, error) {")
+}
+
+func TestGenerateWebhooksWithClient(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Webhooks: true,
+			Client:   true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/webhooks.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Check that the types shared with the client are only defined once
+	assert.Equal(t, 1, strings.Count(code, "type HttpRequestDoer interface {"))
+	assert.Equal(t, 1, strings.Count(code, "type RequestEditorFn func("))
+	assert.NotContains(t, code, "type WebhookStrictServerInterface interface {")
+}
+
+func TestGenerateWithoutWebhooks(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	}
+	swagger, err := util.LoadSwagger("test_specs/webhooks.yaml")
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "WebhookServerInterface")
+	assert.NotContains(t, code, "NewPetParams")
+}
@@ -0,0 +1,107 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// XMLElementDefinition describes the root element of an XML request or
+// response body.
+type XMLElementDefinition struct {
+	// Name is the name of the element, from the `xml` object of the body's
+	// schema, or otherwise the name of the schema it refers to, or the name of
+	// its Go type
+	Name string
+	// Namespace is the namespace of the element, from the `xml` object of the
+	// body's schema
+	Namespace string
+}
+
+// StartElement returns the Go expression of the element's start, which the
+// body is encoded within.
+func (x XMLElementDefinition) StartElement() string {
+	if x.Namespace != "" {
+		return fmt.Sprintf("xml.StartElement{Name: xml.Name{Space: %q, Local: %q}}", x.Namespace, x.Name)
+	}
+	return fmt.Sprintf("xml.StartElement{Name: xml.Name{Local: %q}}", x.Name)
+}
+
+// xmlTagsEnabled returns whether the generated structs have `xml` tags.
+func (gs *generatorState) xmlTagsEnabled() bool {
+	return gs.options.OutputOptions.EnableXmlTags || gs.options.OutputOptions.XMLBodies
+}
+
+// describeXMLElement describes the root element of an XML body, whose schema
+// is sref, and whose Go type is typeName when the schema is defined inline.
+func describeXMLElement(sref *openapi3.SchemaRef, typeName string) *XMLElementDefinition {
+	element := &XMLElementDefinition{Name: typeName}
+	if sref == nil {
+		return element
+	}
+	if strings.HasPrefix(sref.Ref, "#/components/schemas/") {
+		element.Name = strings.TrimPrefix(sref.Ref, "#/components/schemas/")
+	}
+	if sref.Value != nil && sref.Value.XML != nil {
+		if sref.Value.XML.Name != "" {
+			element.Name = sref.Value.XML.Name
+		}
+		element.Namespace = sref.Value.XML.Namespace
+	}
+	return element
+}
+
+// genXMLNameField generates the `XMLName` field of a struct, when its schema's
+// `xml` object names its element, so that it's the element's name wherever
+// the struct is encoded.
+func (gs *generatorState) genXMLNameField(schema Schema) string {
+	if !gs.xmlTagsEnabled() || schema.OAPISchema == nil || schema.OAPISchema.XML == nil || schema.OAPISchema.XML.Name == "" {
+		return ""
+	}
+	name := schema.OAPISchema.XML.Name
+	if namespace := schema.OAPISchema.XML.Namespace; namespace != "" {
+		name = namespace + " " + name
+	}
+	tags := fmt.Sprintf(`json:"-" xml:"%s"`, name)
+	if gs.options.OutputOptions.EnableYamlTags {
+		tags += ` yaml:"-"`
+	}
+	return fmt.Sprintf("XMLName xml.Name `%s`", tags)
+}
+
+// xmlFieldTag returns the `xml` tag of a property, whose element or attribute
+// is named by the `xml` object of its schema. An array's items are each an
+// element, which are within an element of their own when it's `wrapped`.
+func xmlFieldTag(p Property, omitEmpty bool) string {
+	name := p.JsonFieldName
+	var namespace string
+	var attribute bool
+	if oapiSchema := p.Schema.OAPISchema; oapiSchema != nil && oapiSchema.XML != nil {
+		if oapiSchema.XML.Name != "" {
+			name = oapiSchema.XML.Name
+		}
+		namespace = oapiSchema.XML.Namespace
+		attribute = oapiSchema.XML.Attribute
+	}
+
+	if p.Schema.ArrayType != nil {
+		// the items are named after the property, rather than the array,
+		// unless they have a name of their own
+		itemName := p.JsonFieldName
+		if items := p.Schema.ArrayType.OAPISchema; items != nil && items.XML != nil && items.XML.Name != "" {
+			itemName = items.XML.Name
+		}
+		if oapiSchema := p.Schema.OAPISchema; oapiSchema != nil && oapiSchema.XML != nil && oapiSchema.XML.Wrapped {
+			name += ">" + itemName
+		} else {
+			name = itemName
+		}
+		attribute = false
+	}
+
+	if namespace != "" {
+		name = namespace + " " + name
+	}
+	return name + stringOrEmpty(attribute, ",attr") + stringOrEmpty(omitEmpty, ",omitempty")
+}
@@ -0,0 +1,246 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xmlOpenAPIDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: XML
+paths:
+  /books:
+    post:
+      operationId: addBook
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Book'
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Book'
+      responses:
+        '201':
+          description: The book
+          headers:
+            Location:
+              schema:
+                type: string
+          content:
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Book'
+        '400':
+          description: The book is invalid
+          content:
+            application/problem+xml:
+              schema:
+                type: object
+                xml:
+                  name: problem
+                  namespace: urn:ietf:rfc:7807
+                properties:
+                  detail:
+                    type: string
+    get:
+      operationId: listBooks
+      responses:
+        '200':
+          description: The books
+          content:
+            text/xml:
+              schema:
+                $ref: '#/components/schemas/Library'
+components:
+  schemas:
+    Book:
+      type: object
+      xml:
+        name: book
+      required:
+        - id
+        - title
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        title:
+          type: string
+        authors:
+          type: array
+          xml:
+            wrapped: true
+          items:
+            type: string
+            xml:
+              name: author
+        tags:
+          type: array
+          items:
+            type: string
+            xml:
+              name: tag
+        publisher:
+          $ref: '#/components/schemas/Publisher'
+        extras:
+          type: object
+          additionalProperties:
+            type: string
+    Publisher:
+      type: object
+      xml:
+        name: publisher
+      properties:
+        name:
+          type: string
+          xml:
+            name: publisherName
+    Library:
+      type: object
+      properties:
+        books:
+          type: array
+          items:
+            $ref: '#/components/schemas/Book'
+`
+
+func TestXMLTags(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(xmlOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			EnableXmlTags: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Regexp(t, "XMLName\\s+xml.Name\\s+`json:\"-\" xml:\"book\"`", code)
+	assert.Regexp(t, "Id\\s+int\\s+`json:\"id\" xml:\"id,attr\"`", code)
+	assert.Regexp(t, "Title\\s+string\\s+`json:\"title\" xml:\"title\"`", code)
+	assert.Regexp(t, "Authors\\s+\\*\\[\\]string\\s+`json:\"authors,omitempty\" xml:\"authors>author,omitempty\"`", code)
+	assert.Regexp(t, "Tags\\s+\\*\\[\\]string\\s+`json:\"tags,omitempty\" xml:\"tag,omitempty\"`", code)
+	// the element of a property that refers to a schema is named by its schema
+	assert.Regexp(t, "Publisher\\s+\\*Publisher\\s+`json:\"publisher,omitempty\" xml:\"publisher,omitempty\"`", code)
+	assert.Regexp(t, "Name\\s+\\*string\\s+`json:\"name,omitempty\" xml:\"publisherName,omitempty\"`", code)
+	assert.Regexp(t, "Books\\s+\\*\\[\\]Book\\s+`json:\"books,omitempty\" xml:\"book,omitempty\"`", code)
+	assert.Regexp(t, "Extras\\s+\\*map\\[string\\]string\\s+`json:\"extras,omitempty\" xml:\"extras,omitempty\"`", code)
+
+	// XML bodies aren't generated
+	assert.NotContains(t, code, "AddBookXMLRequestBody")
+}
+
+func TestXMLTagsAreOptional(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(xmlOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(spec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+			Strict:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, code, "XMLName")
+	assert.NotContains(t, code, `xml:"`)
+	assert.NotContains(t, code, "AddBookXMLRequestBody")
+	assert.Contains(t, code, "type AddBook201ApplicationxmlResponse struct {")
+}
+
+func TestDescribeXMLBodies(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(xmlOpenAPIDefinition))
+	require.NoError(t, err)
+	gs := newGeneratorState()
+	gs.spec = spec
+	gs.options = Configuration{OutputOptions: OutputOptions{XMLBodies: true}}
+
+	ops, err := gs.operationDefinitions(spec, false)
+	require.NoError(t, err)
+
+	operations := map[string]OperationDefinition{}
+	for _, op := range ops {
+		operations[op.OperationId] = op
+	}
+
+	addBook := operations["AddBook"]
+	require.Len(t, addBook.Bodies, 2)
+	assert.False(t, addBook.Bodies[0].IsXML())
+	body := addBook.Bodies[1]
+	assert.True(t, body.IsXML())
+	assert.True(t, body.IsSupportedByClient())
+	assert.Equal(t, "XML", body.NameTag)
+	assert.Equal(t, XMLElementDefinition{Name: "book"}, *body.XML)
+
+	problem := addBook.Responses[1].Contents[0]
+	assert.True(t, problem.IsXML())
+	assert.Equal(t, XMLElementDefinition{Name: "problem", Namespace: "urn:ietf:rfc:7807"}, *problem.XML)
+	assert.Equal(t, `xml.StartElement{Name: xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}}`, problem.XML.StartElement())
+
+	// without an `xml` object, the element is named after the schema
+	library := operations["ListBooks"].Responses[0].Contents[0]
+	assert.True(t, library.IsXML())
+	assert.Equal(t, XMLElementDefinition{Name: "Library"}, *library.XML)
+}
+
+func TestGenerateXMLBodies(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(xmlOpenAPIDefinition))
+	require.NoError(t, err)
+
+	for name, generate := range map[string]GenerateOptions{
+		"std-http": {StdHTTPServer: true},
+		"echo":     {EchoServer: true},
+		"gin":      {GinServer: true},
+		"fiber":    {FiberServer: true},
+		"iris":     {IrisServer: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			generate.Models = true
+			generate.Client = true
+			generate.Strict = true
+			code, err := Generate(spec, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+				OutputOptions: OutputOptions{
+					XMLBodies: true,
+				},
+			})
+			require.NoError(t, err)
+
+			_, err = format.Source([]byte(code))
+			require.NoError(t, err)
+
+			// the bodies have xml tags, as they're encoded as XML
+			assert.Contains(t, code, `xml:"id,attr"`)
+
+			assert.Contains(t, code, "type AddBookXMLRequestBody = Book")
+			assert.Contains(t, code, `xml.NewEncoder(&buf).EncodeElement(body, xml.StartElement{Name: xml.Name{Local: "book"}})`)
+			assert.Contains(t, code, "func (c *ClientWithResponses) AddBookWithXMLBodyWithResponse(")
+
+			assert.Regexp(t, `XML201\s+\*Book`, code)
+			assert.Regexp(t, `ApplicationproblemXML400\s+\*struct \{`, code)
+			assert.Regexp(t, `XML200\s+\*Library`, code)
+			assert.Contains(t, code, `case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:`)
+
+			assert.Regexp(t, `XMLBody\s+\*AddBookXMLRequestBody`, code)
+			assert.Contains(t, code, "EncodeElement(response.Body, xml.StartElement{Name: xml.Name{Local: \"book\"}})")
+			assert.Contains(t, code, "EncodeElement(response, xml.StartElement{Name: xml.Name{Space: \"urn:ietf:rfc:7807\", Local: \"problem\"}})")
+		})
+	}
+}
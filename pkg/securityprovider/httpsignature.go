@@ -0,0 +1,376 @@
+package securityprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/ecdsafile"
+)
+
+const (
+	// ErrSecurityProviderHTTPSignatureUnsupportedCurve indicates an ECDSA key
+	// whose curve has no HTTP message signature algorithm, which are P-256
+	// and P-384.
+	ErrSecurityProviderHTTPSignatureUnsupportedCurve = SecurityProviderError("unsupported curve for an HTTP message signature, which needs P-256 or P-384")
+	// ErrSecurityProviderHTTPSignatureInvalidKeyID indicates a key ID which
+	// can't be sent in the `Signature-Input` header, as it isn't printable
+	// ASCII.
+	ErrSecurityProviderHTTPSignatureInvalidKeyID = SecurityProviderError("invalid key ID for an HTTP message signature, which needs to be printable ASCII")
+)
+
+// DefaultHTTPSignatureComponents are the components of a request which are
+// signed, and need to be signed, when no others are chosen: its method,
+// authority, path and query, and the `Content-Digest` and `Date` headers.
+var DefaultHTTPSignatureComponents = []string{"@method", "@authority", "@path", "@query", "content-digest", "date"}
+
+// NewSecurityProviderHTTPSignature provides a SecurityProvider, which signs
+// requests with an HTTP message signature (RFC 9421), using an ECDSA private
+// key with the P-256 or P-384 curve.
+//
+// The signature covers the components, which are the names of derived
+// components, such as `@method` or `@path`, or of headers, such as `date`,
+// or DefaultHTTPSignatureComponents when there are none. The `Content-Digest`
+// and `Date` headers are added to the request when they're covered, and it
+// doesn't have them.
+//
+// The keyID needs to be printable ASCII, as it's sent as a structured field
+// string (RFC 8941).
+func NewSecurityProviderHTTPSignature(keyID string, privateKey *ecdsa.PrivateKey, components ...string) (*SecurityProviderHTTPSignature, error) {
+	for i := 0; i < len(keyID); i++ {
+		if keyID[i] < 0x20 || keyID[i] > 0x7e {
+			return nil, ErrSecurityProviderHTTPSignatureInvalidKeyID
+		}
+	}
+	alg, err := httpSignatureAlgorithm(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	components, err = httpSignatureComponents(components)
+	if err != nil {
+		return nil, err
+	}
+	return &SecurityProviderHTTPSignature{
+		keyID:      keyID,
+		privateKey: privateKey,
+		alg:        alg,
+		components: components,
+		label:      "sig1",
+	}, nil
+}
+
+// NewSecurityProviderHTTPSignatureFromPEM provides a
+// SecurityProviderHTTPSignature, with an ECDSA private key from a PEM
+// encoding, as loaded by ecdsafile.LoadEcdsaPrivateKey.
+func NewSecurityProviderHTTPSignatureFromPEM(keyID string, privateKeyPEM []byte, components ...string) (*SecurityProviderHTTPSignature, error) {
+	privateKey, err := ecdsafile.LoadEcdsaPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecurityProviderHTTPSignature(keyID, privateKey, components...)
+}
+
+// SecurityProviderHTTPSignature signs a request with an HTTP message
+// signature (RFC 9421), in its `Signature-Input` and `Signature` headers.
+type SecurityProviderHTTPSignature struct {
+	keyID      string
+	privateKey *ecdsa.PrivateKey
+	alg        string
+	components []string
+	label      string
+	// now is the current time, which can be replaced in tests
+	now func() time.Time
+}
+
+// Intercept will attach the `Signature-Input` and `Signature` headers to
+// the request, with a signature of its components. As the signature covers
+// the request as it is, this needs to be the last of the request's editors.
+func (s *SecurityProviderHTTPSignature) Intercept(ctx context.Context, req *http.Request) error {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+
+	for _, component := range s.components {
+		switch component {
+		case "content-digest":
+			if req.Header.Get("Content-Digest") != "" {
+				continue
+			}
+			body, err := readRequestBody(req)
+			if err != nil {
+				return fmt.Errorf("can't read the body of the request to sign it: %w", err)
+			}
+			req.Header.Set("Content-Digest", contentDigest(body))
+		case "date":
+			if req.Header.Get("Date") == "" {
+				req.Header.Set("Date", now.UTC().Format(http.TimeFormat))
+			}
+		}
+	}
+
+	params := fmt.Sprintf("%s;created=%d;keyid=%s;alg=%s", serializeInnerList(s.components), now.Unix(), strconv.Quote(s.keyID), strconv.Quote(s.alg))
+	base, err := httpSignatureBase(clientRequestComponents(req), s.components, params)
+	if err != nil {
+		return err
+	}
+	signature, err := signECDSA(s.privateKey, base)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Signature-Input", fmt.Sprintf("%s=%s", s.label, params))
+	req.Header.Set("Signature", fmt.Sprintf("%s=:%s:", s.label, base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+// httpSignatureDerivedComponents are the derived components which can be
+// signed.
+var httpSignatureDerivedComponents = map[string]bool{
+	"@method":         true,
+	"@target-uri":     true,
+	"@authority":      true,
+	"@scheme":         true,
+	"@request-target": true,
+	"@path":           true,
+	"@query":          true,
+}
+
+// httpSignatureComponents returns the lowercased components, or
+// DefaultHTTPSignatureComponents when there are none.
+func httpSignatureComponents(components []string) ([]string, error) {
+	if len(components) == 0 {
+		components = DefaultHTTPSignatureComponents
+	}
+	lowered := make([]string, len(components))
+	for i, component := range components {
+		lowered[i] = strings.ToLower(component)
+		if strings.HasPrefix(lowered[i], "@") && !httpSignatureDerivedComponents[lowered[i]] {
+			return nil, fmt.Errorf("unsupported derived component for an HTTP message signature: %s", component)
+		}
+	}
+	return lowered, nil
+}
+
+// requestComponents are the values of a request's derived components, and
+// its headers, as seen by the client or the server.
+type requestComponents struct {
+	method        string
+	targetURI     string
+	authority     string
+	scheme        string
+	requestTarget string
+	path          string
+	query         string
+	header        http.Header
+}
+
+func clientRequestComponents(req *http.Request) requestComponents {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	return requestComponents{
+		method:        req.Method,
+		targetURI:     req.URL.String(),
+		authority:     normalizeAuthority(host, req.URL.Scheme),
+		scheme:        strings.ToLower(req.URL.Scheme),
+		requestTarget: req.URL.RequestURI(),
+		path:          req.URL.EscapedPath(),
+		query:         req.URL.RawQuery,
+		header:        req.Header,
+	}
+}
+
+func serverRequestComponents(req *http.Request) requestComponents {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	requestTarget := req.RequestURI
+	if requestTarget == "" {
+		requestTarget = req.URL.RequestURI()
+	}
+	return requestComponents{
+		method:        req.Method,
+		targetURI:     scheme + "://" + req.Host + req.URL.RequestURI(),
+		authority:     normalizeAuthority(req.Host, scheme),
+		scheme:        scheme,
+		requestTarget: requestTarget,
+		path:          req.URL.EscapedPath(),
+		query:         req.URL.RawQuery,
+		header:        req.Header,
+	}
+}
+
+// normalizeAuthority lowercases the host, and removes the scheme's default
+// port.
+func normalizeAuthority(host, scheme string) string {
+	host = strings.ToLower(host)
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if (port == "80" && scheme == "http") || (port == "443" && scheme == "https") {
+			if strings.Contains(h, ":") {
+				return "[" + h + "]"
+			}
+			return h
+		}
+	}
+	return host
+}
+
+func (c requestComponents) value(component string) (string, error) {
+	switch component {
+	case "@method":
+		return c.method, nil
+	case "@target-uri":
+		return c.targetURI, nil
+	case "@authority":
+		return c.authority, nil
+	case "@scheme":
+		return c.scheme, nil
+	case "@request-target":
+		return c.requestTarget, nil
+	case "@path":
+		if c.path == "" {
+			return "/", nil
+		}
+		return c.path, nil
+	case "@query":
+		return "?" + c.query, nil
+	}
+	if strings.HasPrefix(component, "@") {
+		return "", fmt.Errorf("unsupported derived component %s", component)
+	}
+
+	values := c.header.Values(component)
+	if len(values) == 0 {
+		return "", fmt.Errorf("the request has no %s header", component)
+	}
+	trimmed := make([]string, len(values))
+	for i, value := range values {
+		trimmed[i] = strings.TrimSpace(value)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
+
+// httpSignatureBase returns the signature base of the components of a
+// request, with the serialized signature parameters (RFC 9421, Section 2.5).
+func httpSignatureBase(c requestComponents, components []string, params string) ([]byte, error) {
+	var base bytes.Buffer
+	for _, component := range components {
+		value, err := c.value(component)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&base, "%s: %s\n", strconv.Quote(component), value)
+	}
+	fmt.Fprintf(&base, "%s: %s", strconv.Quote("@signature-params"), params)
+	return base.Bytes(), nil
+}
+
+func serializeInnerList(components []string) string {
+	quoted := make([]string, len(components))
+	for i, component := range components {
+		quoted[i] = strconv.Quote(component)
+	}
+	return "(" + strings.Join(quoted, " ") + ")"
+}
+
+// httpSignatureAlgorithm returns the name of the HTTP message signature
+// algorithm for the curve of the ECDSA key.
+func httpSignatureAlgorithm(publicKey *ecdsa.PublicKey) (string, error) {
+	switch publicKey.Curve {
+	case elliptic.P256():
+		return "ecdsa-p256-sha256", nil
+	case elliptic.P384():
+		return "ecdsa-p384-sha384", nil
+	default:
+		return "", ErrSecurityProviderHTTPSignatureUnsupportedCurve
+	}
+}
+
+func httpSignatureHash(publicKey *ecdsa.PublicKey, base []byte) []byte {
+	if publicKey.Curve == elliptic.P384() {
+		sum := sha512.Sum384(base)
+		return sum[:]
+	}
+	sum := sha256.Sum256(base)
+	return sum[:]
+}
+
+// signECDSA signs the base, with the signature encoded as the concatenation
+// of r and s, each padded to the size of the curve (RFC 9421, Section
+// 3.3.4).
+func signECDSA(privateKey *ecdsa.PrivateKey, base []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, httpSignatureHash(&privateKey.PublicKey, base))
+	if err != nil {
+		return nil, fmt.Errorf("can't sign the request: %w", err)
+	}
+	size := (privateKey.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signature, nil
+}
+
+func verifyECDSA(publicKey *ecdsa.PublicKey, base, signature []byte) bool {
+	size := (publicKey.Curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	return ecdsa.Verify(publicKey, httpSignatureHash(publicKey, base), r, s)
+}
+
+// contentDigest returns the `Content-Digest` header (RFC 9530) for a body.
+func contentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(sum[:]))
+}
+
+// readRequestBody reads the body of a request, and replaces it, so that it
+// can be read again.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = body.Close() }()
+		return io.ReadAll(body)
+	}
+	content, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	return content, nil
+}
+
+// hashes are the `Content-Digest` algorithms which can be verified.
+var contentDigestHashes = map[string]crypto.Hash{
+	"sha-256": crypto.SHA256,
+	"sha-512": crypto.SHA512,
+}
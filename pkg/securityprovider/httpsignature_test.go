@@ -0,0 +1,267 @@
+package securityprovider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/ecdsafile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHTTPSignatureKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// signedRequest signs a request with the provider, and returns it as the
+// server receives it.
+func signedRequest(t *testing.T, provider *SecurityProviderHTTPSignature, method, url, body string) *http.Request {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	require.NoError(t, err)
+	require.NoError(t, provider.Intercept(context.Background(), req))
+
+	received := httptest.NewRequest(method, url, strings.NewReader(body))
+	received.Header = req.Header.Clone()
+	return received
+}
+
+func TestHTTPSignature(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			key := newHTTPSignatureKey(t, curve)
+			provider, err := NewSecurityProviderHTTPSignature("client", key)
+			require.NoError(t, err)
+			verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+			require.NoError(t, err)
+
+			req := signedRequest(t, provider, http.MethodPost, "https://api.example.com:443/pets?limit=10", `{"name":"Fido"}`)
+			assert.Equal(t, "sha-256=:01h/oyLrCMgkhxW+4Zjf2m64Gdc4wkNzWv9oBoYNWvc=:", req.Header.Get("Content-Digest"))
+			assert.NotEmpty(t, req.Header.Get("Date"))
+			assert.Regexp(t, `^sig1=\("@method" "@authority" "@path" "@query" "content-digest" "date"\);created=\d+;keyid="client";alg="ecdsa-p\d+-sha\d+"$`, req.Header.Get("Signature-Input"))
+
+			keyID, err := verifier.Verify(req)
+			require.NoError(t, err)
+			assert.Equal(t, "client", keyID)
+
+			// the body can still be read by the handler
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, `{"name":"Fido"}`, string(body))
+		})
+	}
+}
+
+func TestHTTPSignatureWithoutABody(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P256())
+	provider, err := NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+
+	req := signedRequest(t, provider, http.MethodGet, "https://api.example.com/pets", "")
+	_, err = verifier.Verify(req)
+	assert.NoError(t, err)
+}
+
+func TestHTTPSignatureWithPEMKeys(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P256())
+	privatePEM, err := ecdsafile.StoreEcdsaPrivateKey(key)
+	require.NoError(t, err)
+	publicPEM, err := ecdsafile.StoreEcdsaPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	provider, err := NewSecurityProviderHTTPSignatureFromPEM("client", privatePEM, "@method", "@path")
+	require.NoError(t, err)
+	publicKey, err := ecdsafile.LoadEcdsaPublicKey(publicPEM)
+	require.NoError(t, err)
+	verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": publicKey}, "@method", "@path")
+	require.NoError(t, err)
+
+	req := signedRequest(t, provider, http.MethodDelete, "https://api.example.com/pets/1", "")
+	assert.Empty(t, req.Header.Get("Content-Digest"))
+	_, err = verifier.Verify(req)
+	assert.NoError(t, err)
+}
+
+func TestHTTPSignatureVerifierRejects(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P256())
+	provider, err := NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		modify func(req *http.Request)
+		err    string
+	}{
+		{
+			name:   "a changed body",
+			modify: func(req *http.Request) { req.Body = io.NopCloser(strings.NewReader(`{"name":"Rex"}`)) },
+			err:    "the sha-256 Content-Digest doesn't match the body",
+		},
+		{
+			name:   "a changed path",
+			modify: func(req *http.Request) { req.URL.Path = "/owners" },
+			err:    "the signature sig1 doesn't match the request",
+		},
+		{
+			name:   "a changed header",
+			modify: func(req *http.Request) { req.Header.Set("Date", "Mon, 01 Jan 2024 00:00:00 GMT") },
+			err:    "the signature sig1 doesn't match the request",
+		},
+		{
+			name:   "an unknown key",
+			modify: func(req *http.Request) { verifier.keys = map[string]*ecdsa.PublicKey{} },
+			err:    `the key "client" of sig1 isn't known`,
+		},
+		{
+			name:   "an old signature",
+			modify: func(req *http.Request) { now = now.Add(10 * time.Minute) },
+			err:    "which isn't within 5m0s",
+		},
+		{
+			name: "a missing component",
+			modify: func(req *http.Request) {
+				req.Header.Set("Signature-Input", strings.Replace(req.Header.Get("Signature-Input"), ` "date"`, "", 1))
+			},
+			err: "sig1 doesn't cover date",
+		},
+		{
+			name:   "a missing signature",
+			modify: func(req *http.Request) { req.Header.Del("Signature") },
+			err:    "there's no signature for sig1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := signedRequest(t, provider, http.MethodPost, "https://api.example.com/pets", `{"name":"Fido"}`)
+			now = time.Now()
+			verifier.keys = map[string]*ecdsa.PublicKey{"client": &key.PublicKey}
+			test.modify(req)
+
+			_, err := verifier.Verify(req)
+			assert.ErrorIs(t, err, ErrHTTPSignatureInvalid)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+
+	_, err = verifier.Verify(httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.ErrorIs(t, err, ErrHTTPSignatureMissing)
+}
+
+func TestHTTPSignatureVerifierMiddleware(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P256())
+	provider, err := NewSecurityProviderHTTPSignature("client", key)
+	require.NoError(t, err)
+	verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{"client": &key.PublicKey})
+	require.NoError(t, err)
+
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, ok := HTTPSignatureKeyIDFromContext(r.Context())
+		assert.True(t, ok)
+		_, _ = w.Write([]byte(keyID))
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/pets", strings.NewReader(`{"name":"Fido"}`))
+	require.NoError(t, err)
+	require.NoError(t, provider.Intercept(context.Background(), req))
+	rsp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer func() { _ = rsp.Body.Close() }()
+	body, err := io.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, "client", string(body))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Unauthorized\n", rec.Body.String())
+	assert.Equal(t, `sig1=("@method" "@authority" "@path" "@query" "content-digest" "date")`, rec.Header().Get("Accept-Signature"))
+}
+
+func TestHTTPSignatureUnsupportedCurve(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P224())
+	_, err := NewSecurityProviderHTTPSignature("client", key)
+	assert.ErrorIs(t, err, ErrSecurityProviderHTTPSignatureUnsupportedCurve)
+
+	_, err = NewSecurityProviderHTTPSignature("client", newHTTPSignatureKey(t, elliptic.P256()), "@status")
+	assert.ErrorContains(t, err, "unsupported derived component")
+}
+
+func TestHTTPSignatureInvalidKeyID(t *testing.T) {
+	key := newHTTPSignatureKey(t, elliptic.P256())
+	for _, keyID := range []string{"clé", "client\n", "client\x7f"} {
+		_, err := NewSecurityProviderHTTPSignature(keyID, key)
+		assert.ErrorIs(t, err, ErrSecurityProviderHTTPSignatureInvalidKeyID, keyID)
+	}
+
+	// the quotes and backslashes of a key ID are escaped
+	provider, err := NewSecurityProviderHTTPSignature(`client "a\b"`, key)
+	require.NoError(t, err)
+	verifier, err := NewHTTPSignatureVerifier(map[string]*ecdsa.PublicKey{`client "a\b"`: &key.PublicKey})
+	require.NoError(t, err)
+	keyID, err := verifier.Verify(signedRequest(t, provider, http.MethodGet, "https://api.example.com/pets", ""))
+	require.NoError(t, err)
+	assert.Equal(t, `client "a\b"`, keyID)
+}
+
+func TestVerifyContentDigestAcrossFieldLines(t *testing.T) {
+	body := `{"name":"Fido"}`
+	digest := contentDigest([]byte(body))
+
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+	req.Header.Add("Content-Digest", "unknown=:AAAA:")
+	req.Header.Add("Content-Digest", digest)
+	assert.NoError(t, verifyContentDigest(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+	req.Header.Add("Content-Digest", digest)
+	req.Header.Add("Content-Digest", "sha-512=:"+base64.StdEncoding.EncodeToString(make([]byte, 64))+":")
+	assert.ErrorContains(t, verifyContentDigest(req), "the sha-512 Content-Digest doesn't match the body")
+}
+
+func TestParseHTTPSignatureInputs(t *testing.T) {
+	inputs, err := parseHTTPSignatureInputs(`sig1=("@method" "content-type");created=1618884473;keyid="test-key, \"quoted\"", sig2=();expires=1618884475;alg=ecdsa-p256-sha256;tag`)
+	require.NoError(t, err)
+	require.Len(t, inputs, 2)
+
+	assert.Equal(t, "sig1", inputs[0].label)
+	assert.Equal(t, []string{"@method", "content-type"}, inputs[0].components)
+	assert.Equal(t, `("@method" "content-type");created=1618884473;keyid="test-key, \"quoted\""`, inputs[0].params)
+	assert.Equal(t, int64(1618884473), *inputs[0].created)
+	assert.Equal(t, `test-key, "quoted"`, inputs[0].keyID)
+
+	assert.Equal(t, "sig2", inputs[1].label)
+	assert.Empty(t, inputs[1].components)
+	assert.Nil(t, inputs[1].created)
+	assert.Equal(t, int64(1618884475), *inputs[1].expires)
+	assert.Equal(t, "ecdsa-p256-sha256", inputs[1].alg)
+
+	_, err = parseHTTPSignatureInputs(`sig1=("@method"`)
+	assert.Error(t, err)
+	_, err = parseHTTPSignatureInputs(`sig1=("@query-param";name="id")`)
+	assert.ErrorContains(t, err, "aren't supported")
+}
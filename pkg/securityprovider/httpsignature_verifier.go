@@ -0,0 +1,456 @@
+package securityprovider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ErrHTTPSignatureMissing indicates a request without an HTTP message
+	// signature.
+	ErrHTTPSignatureMissing = SecurityProviderError("the request has no HTTP message signature")
+	// ErrHTTPSignatureInvalid indicates a request whose HTTP message
+	// signature can't be verified.
+	ErrHTTPSignatureInvalid = SecurityProviderError("the request's HTTP message signature isn't valid")
+)
+
+const (
+	// defaultHTTPSignatureMaxAge is how long after it's created that a
+	// signature is accepted, when the verifier doesn't say.
+	defaultHTTPSignatureMaxAge = 5 * time.Minute
+	// httpSignatureClockSkew is how far in the future that a signature can be
+	// created, to allow for the clocks of the client and server differing.
+	httpSignatureClockSkew = time.Minute
+)
+
+// NewHTTPSignatureVerifier returns an HTTPSignatureVerifier, which verifies
+// HTTP message signatures (RFC 9421) made with the ECDSA private keys of the
+// public keys, by their key ID, such as those loaded by
+// ecdsafile.LoadEcdsaPublicKey.
+//
+// A signature needs to cover each of the components, or
+// DefaultHTTPSignatureComponents when there are none.
+func NewHTTPSignatureVerifier(keys map[string]*ecdsa.PublicKey, components ...string) (*HTTPSignatureVerifier, error) {
+	for keyID, key := range keys {
+		if _, err := httpSignatureAlgorithm(key); err != nil {
+			return nil, fmt.Errorf("the key %s: %w", keyID, err)
+		}
+	}
+	components, err := httpSignatureComponents(components)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPSignatureVerifier{
+		keys:       keys,
+		components: components,
+	}, nil
+}
+
+// HTTPSignatureVerifier verifies the HTTP message signatures of requests, in
+// their `Signature-Input` and `Signature` headers, such as those signed by a
+// SecurityProviderHTTPSignature.
+type HTTPSignatureVerifier struct {
+	keys       map[string]*ecdsa.PublicKey
+	components []string
+
+	// MaxAge is how long after it's created that a signature is accepted,
+	// which is 5 minutes when it's zero
+	MaxAge time.Duration
+
+	// now is the current time, which can be replaced in tests
+	now func() time.Time
+}
+
+type httpSignatureKeyIDContextKey struct{}
+
+// ContextWithHTTPSignatureKeyID returns a copy of the context with the ID of
+// the key which signed the request, as the Middleware does for each request
+// it verifies. This is for the middleware of the servers which can't wrap an
+// http.Handler, such as gin.
+func ContextWithHTTPSignatureKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, httpSignatureKeyIDContextKey{}, keyID)
+}
+
+// HTTPSignatureKeyIDFromContext returns the ID of the key which signed the
+// request, from the context of a request which the HTTPSignatureVerifier's
+// Middleware has verified.
+func HTTPSignatureKeyIDFromContext(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(httpSignatureKeyIDContextKey{}).(string)
+	return keyID, ok
+}
+
+// Middleware verifies the signature of each request, before it's passed to
+// the next handler, and responds with 401 Unauthorized when it can't be
+// verified. Why it couldn't be verified isn't sent to the client. This can be
+// used with the std-http and chi servers, and with the echo server through
+// `echo.WrapMiddleware`.
+func (v *HTTPSignatureVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID, err := v.Verify(r)
+		if err != nil {
+			w.Header().Set("Accept-Signature", "sig1="+serializeInnerList(v.components))
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithHTTPSignatureKeyID(r.Context(), keyID)))
+	})
+}
+
+// Verify verifies the signature of a request, and returns the ID of the key
+// which signed it. When the request has several signatures, one of them
+// needs to be verified.
+func (v *HTTPSignatureVerifier) Verify(req *http.Request) (string, error) {
+	inputs, err := parseHTTPSignatureInputs(strings.Join(req.Header.Values("Signature-Input"), ", "))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrHTTPSignatureInvalid, err)
+	}
+	if len(inputs) == 0 {
+		return "", ErrHTTPSignatureMissing
+	}
+	signatures, err := parseHTTPSignatures(strings.Join(req.Header.Values("Signature"), ", "))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrHTTPSignatureInvalid, err)
+	}
+
+	var verifyErr error
+	for _, input := range inputs {
+		if verifyErr = v.verify(req, input, signatures[input.label]); verifyErr == nil {
+			return input.keyID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrHTTPSignatureInvalid, verifyErr)
+}
+
+func (v *HTTPSignatureVerifier) verify(req *http.Request, input httpSignatureInput, signature []byte) error {
+	if signature == nil {
+		return fmt.Errorf("there's no signature for %s", input.label)
+	}
+	key := v.keys[input.keyID]
+	if key == nil {
+		return fmt.Errorf("the key %q of %s isn't known", input.keyID, input.label)
+	}
+	alg, _ := httpSignatureAlgorithm(key)
+	if input.alg != "" && input.alg != alg {
+		return fmt.Errorf("the algorithm %s of %s isn't the key's", input.alg, input.label)
+	}
+	for _, component := range v.components {
+		if !input.covers(component) {
+			return fmt.Errorf("%s doesn't cover %s", input.label, component)
+		}
+	}
+
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	maxAge := v.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultHTTPSignatureMaxAge
+	}
+	if input.created == nil {
+		return fmt.Errorf("%s has no created time", input.label)
+	}
+	created := time.Unix(*input.created, 0)
+	if now.Sub(created) > maxAge || created.Sub(now) > httpSignatureClockSkew {
+		return fmt.Errorf("%s was created at %s, which isn't within %s", input.label, created.UTC().Format(time.RFC3339), maxAge)
+	}
+	if input.expires != nil && now.After(time.Unix(*input.expires, 0)) {
+		return fmt.Errorf("%s has expired", input.label)
+	}
+
+	base, err := httpSignatureBase(serverRequestComponents(req), input.components, input.params)
+	if err != nil {
+		return err
+	}
+	if !verifyECDSA(key, base, signature) {
+		return fmt.Errorf("the signature %s doesn't match the request", input.label)
+	}
+
+	if input.covers("content-digest") {
+		if err := verifyContentDigest(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyContentDigest verifies that each of the digests of the
+// `Content-Digest` header, whose algorithm is known, matches the request's
+// body.
+func verifyContentDigest(req *http.Request) error {
+	// a structured dictionary can be split across several field lines
+	digests, err := parseHTTPSignatures(strings.Join(req.Header.Values("Content-Digest"), ", "))
+	if err != nil {
+		return fmt.Errorf("can't parse the Content-Digest header: %w", err)
+	}
+	body, err := readRequestBody(req)
+	if err != nil {
+		return fmt.Errorf("can't read the body of the request: %w", err)
+	}
+
+	verified := false
+	for alg, digest := range digests {
+		hash, ok := contentDigestHashes[alg]
+		if !ok {
+			continue
+		}
+		h := hash.New()
+		_, _ = h.Write(body)
+		if subtle.ConstantTimeCompare(h.Sum(nil), digest) != 1 {
+			return fmt.Errorf("the %s Content-Digest doesn't match the body", alg)
+		}
+		verified = true
+	}
+	if !verified {
+		return fmt.Errorf("the Content-Digest header has no sha-256 or sha-512 digest")
+	}
+	return nil
+}
+
+// httpSignatureInput is one of the signatures of a `Signature-Input`
+// header.
+type httpSignatureInput struct {
+	label      string
+	components []string
+	// params are the serialized components and parameters, which are the
+	// `@signature-params` of the signature base
+	params  string
+	created *int64
+	expires *int64
+	keyID   string
+	alg     string
+}
+
+func (i httpSignatureInput) covers(component string) bool {
+	for _, c := range i.components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHTTPSignatureInputs parses a `Signature-Input` header, which is a
+// structured field dictionary (RFC 8941) of inner lists of the components,
+// with the signature's parameters.
+func parseHTTPSignatureInputs(header string) ([]httpSignatureInput, error) {
+	members, err := splitStructuredDictionary(header)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]httpSignatureInput, 0, len(members))
+	for _, member := range members {
+		input := httpSignatureInput{label: member.key, params: member.value}
+
+		p := &structuredFieldParser{s: member.value}
+		if input.components, err = p.innerList(); err != nil {
+			return nil, fmt.Errorf("%s: %w", member.key, err)
+		}
+		params, err := p.params()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", member.key, err)
+		}
+		if p.i != len(p.s) {
+			return nil, fmt.Errorf("%s: unexpected %q", member.key, p.s[p.i:])
+		}
+		for name, value := range params {
+			switch name {
+			case "created", "expires":
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s: the %s parameter isn't an integer", member.key, name)
+				}
+				if name == "created" {
+					input.created = &n
+				} else {
+					input.expires = &n
+				}
+			case "keyid":
+				input.keyID = value
+			case "alg":
+				input.alg = value
+			}
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// parseHTTPSignatures parses a structured field dictionary of byte
+// sequences, such as a `Signature` or `Content-Digest` header.
+func parseHTTPSignatures(header string) (map[string][]byte, error) {
+	members, err := splitStructuredDictionary(header)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(members))
+	for _, member := range members {
+		value := member.value
+		if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			return nil, fmt.Errorf("%s isn't a byte sequence", member.key)
+		}
+		if values[member.key], err = base64.StdEncoding.DecodeString(value[1 : len(value)-1]); err != nil {
+			return nil, fmt.Errorf("%s isn't base64 encoded", member.key)
+		}
+	}
+	return values, nil
+}
+
+type structuredDictionaryMember struct {
+	key   string
+	value string
+}
+
+// splitStructuredDictionary splits a structured field dictionary into its
+// members, with their serialized values.
+func splitStructuredDictionary(header string) ([]structuredDictionaryMember, error) {
+	var members []structuredDictionaryMember
+	inString, escaped, depth, start := false, false, 0, 0
+	for i := 0; i <= len(header); i++ {
+		if i < len(header) {
+			c := header[i]
+			switch {
+			case escaped:
+				escaped = false
+				continue
+			case inString:
+				if c == '\\' {
+					escaped = true
+				} else if c == '"' {
+					inString = false
+				}
+				continue
+			case c == '"':
+				inString = true
+				continue
+			case c == '(':
+				depth++
+				continue
+			case c == ')':
+				depth--
+				continue
+			case c != ',' || depth > 0:
+				continue
+			}
+		}
+		if inString || depth != 0 {
+			return nil, fmt.Errorf("unterminated string or inner list")
+		}
+
+		member := strings.TrimSpace(header[start:i])
+		start = i + 1
+		if member == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(member, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("the member %q has no value", member)
+		}
+		members = append(members, structuredDictionaryMember{key: key, value: value})
+	}
+	return members, nil
+}
+
+// structuredFieldParser parses the inner lists of strings, and parameters,
+// of structured fields (RFC 8941).
+type structuredFieldParser struct {
+	s string
+	i int
+}
+
+func (p *structuredFieldParser) innerList() ([]string, error) {
+	if p.i >= len(p.s) || p.s[p.i] != '(' {
+		return nil, fmt.Errorf("expected an inner list")
+	}
+	p.i++
+	var items []string
+	for {
+		for p.i < len(p.s) && p.s[p.i] == ' ' {
+			p.i++
+		}
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("unterminated inner list")
+		}
+		if p.s[p.i] == ')' {
+			p.i++
+			return items, nil
+		}
+		item, err := p.string()
+		if err != nil {
+			return nil, err
+		}
+		if p.i < len(p.s) && p.s[p.i] == ';' {
+			return nil, fmt.Errorf("the component %q has parameters, which aren't supported", item)
+		}
+		items = append(items, item)
+	}
+}
+
+func (p *structuredFieldParser) string() (string, error) {
+	if p.i >= len(p.s) || p.s[p.i] != '"' {
+		return "", fmt.Errorf("expected a string")
+	}
+	var b strings.Builder
+	for p.i++; p.i < len(p.s); p.i++ {
+		switch c := p.s[p.i]; c {
+		case '\\':
+			p.i++
+			if p.i >= len(p.s) || (p.s[p.i] != '"' && p.s[p.i] != '\\') {
+				return "", fmt.Errorf("invalid escape in a string")
+			}
+			b.WriteByte(p.s[p.i])
+		case '"':
+			p.i++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// params parses parameters, returning the value of each as a string, which
+// is `?1` for a parameter without a value.
+func (p *structuredFieldParser) params() (map[string]string, error) {
+	params := map[string]string{}
+	for p.i < len(p.s) && p.s[p.i] == ';' {
+		p.i++
+		for p.i < len(p.s) && p.s[p.i] == ' ' {
+			p.i++
+		}
+		start := p.i
+		for p.i < len(p.s) && strings.IndexByte("abcdefghijklmnopqrstuvwxyz0123456789_-.*", p.s[p.i]) >= 0 {
+			p.i++
+		}
+		name := p.s[start:p.i]
+		if name == "" {
+			return nil, fmt.Errorf("expected a parameter")
+		}
+		if p.i >= len(p.s) || p.s[p.i] != '=' {
+			params[name] = "?1"
+			continue
+		}
+		p.i++
+		if p.i < len(p.s) && p.s[p.i] == '"' {
+			value, err := p.string()
+			if err != nil {
+				return nil, err
+			}
+			params[name] = value
+			continue
+		}
+		start = p.i
+		for p.i < len(p.s) && p.s[p.i] != ';' {
+			p.i++
+		}
+		params[name] = p.s[start:p.i]
+	}
+	return params, nil
+}
@@ -0,0 +1,436 @@
+package securityprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ErrSecurityProviderOAuth2NoTokenURL indicates that an OAuth2Config
+	// doesn't have the TokenURL that its flow needs.
+	ErrSecurityProviderOAuth2NoTokenURL = SecurityProviderError("no token URL specified for OAuth2")
+	// ErrSecurityProviderOAuth2NoDeviceAuthorizationURL indicates that an
+	// OAuth2Config doesn't have the DeviceAuthorizationURL that the device
+	// code flow needs.
+	ErrSecurityProviderOAuth2NoDeviceAuthorizationURL = SecurityProviderError("no device authorization URL specified for OAuth2")
+	// ErrSecurityProviderOAuth2NoRefreshToken indicates that the refresh
+	// token flow was started without a refresh token.
+	ErrSecurityProviderOAuth2NoRefreshToken = SecurityProviderError("no refresh token specified for OAuth2")
+)
+
+const (
+	// defaultOAuth2ExpiryDelta is how long before it expires that a token is
+	// refreshed, when the OAuth2Config doesn't say.
+	defaultOAuth2ExpiryDelta = 10 * time.Second
+	// defaultOAuth2PollInterval is how often the token endpoint is polled in
+	// the device code flow, when the device authorization doesn't say.
+	defaultOAuth2PollInterval = 5 * time.Second
+)
+
+// OAuth2Config configures how an OAuth2 security provider gets its tokens.
+type OAuth2Config struct {
+	// ClientID is the ID of the client, which is sent with each request to
+	// the token endpoint
+	ClientID string
+	// ClientSecret is the secret of a confidential client, which is sent
+	// with the ClientID as HTTP basic authentication, when it's set
+	ClientSecret string
+	// TokenURL is the URL of the token endpoint
+	TokenURL string
+	// RefreshURL is the URL to which refresh tokens are sent, which is the
+	// TokenURL when it's empty
+	RefreshURL string
+	// DeviceAuthorizationURL is the URL of the device authorization
+	// endpoint, for the device code flow
+	DeviceAuthorizationURL string
+	// Scopes are the scopes which are requested
+	Scopes []string
+	// HTTPClient sends the requests to the endpoints, which is
+	// http.DefaultClient when it's nil
+	HTTPClient *http.Client
+	// ExpiryDelta is how long before a token expires that it's refreshed,
+	// which is 10 seconds when it's zero
+	ExpiryDelta time.Duration
+}
+
+// OAuth2Token is a token from an OAuth2 token endpoint.
+type OAuth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	// Expiry is when the token expires, which is zero when it doesn't
+	Expiry time.Time `json:"-"`
+}
+
+// OAuth2Error is the error response of an OAuth2 endpoint.
+type OAuth2Error struct {
+	// StatusCode is the status code of the response
+	StatusCode int
+	// Code is the `error` of the response, such as `invalid_grant`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	URI         string `json:"error_uri,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *OAuth2Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth2: %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("oauth2: %s", e.Code)
+}
+
+// OAuth2DeviceAuthorization is the response of the device authorization
+// endpoint, with the code that the user needs to enter at the verification
+// URI, in the device code flow.
+type OAuth2DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	// Interval is how many seconds to wait between polling the token
+	// endpoint, which is 5 when it's nil, or isn't positive
+	Interval *int64 `json:"interval,omitempty"`
+}
+
+// NewSecurityProviderOAuth2ClientCredentials provides a SecurityProvider,
+// which gets tokens with the OAuth2 client credentials flow.
+func NewSecurityProviderOAuth2ClientCredentials(config OAuth2Config) (*SecurityProviderOAuth2, error) {
+	if config.TokenURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoTokenURL
+	}
+	s := &SecurityProviderOAuth2{config: config}
+	s.fetch = s.clientCredentials
+	return s, nil
+}
+
+// NewSecurityProviderOAuth2RefreshToken provides a SecurityProvider, which
+// gets tokens with an OAuth2 refresh token, such as one from an earlier
+// authorization code flow.
+func NewSecurityProviderOAuth2RefreshToken(config OAuth2Config, refreshToken string) (*SecurityProviderOAuth2, error) {
+	if config.TokenURL == "" && config.RefreshURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoTokenURL
+	}
+	if refreshToken == "" {
+		return nil, ErrSecurityProviderOAuth2NoRefreshToken
+	}
+	s := &SecurityProviderOAuth2{
+		config: config,
+		token:  &OAuth2Token{RefreshToken: refreshToken},
+	}
+	s.fetch = func(ctx context.Context) (*OAuth2Token, error) {
+		return nil, ErrSecurityProviderOAuth2NoRefreshToken
+	}
+	return s, nil
+}
+
+// NewSecurityProviderOAuth2DeviceCode provides a SecurityProvider, which
+// gets tokens with the OAuth2 device code flow (RFC 8628). When it needs a
+// token, it calls prompt with the code that the user needs to enter at the
+// verification URI, and then polls the token endpoint until they have.
+func NewSecurityProviderOAuth2DeviceCode(config OAuth2Config, prompt func(ctx context.Context, authorization OAuth2DeviceAuthorization) error) (*SecurityProviderOAuth2, error) {
+	if config.TokenURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoTokenURL
+	}
+	if config.DeviceAuthorizationURL == "" {
+		return nil, ErrSecurityProviderOAuth2NoDeviceAuthorizationURL
+	}
+	s := &SecurityProviderOAuth2{config: config}
+	s.fetch = func(ctx context.Context) (*OAuth2Token, error) {
+		return s.deviceCode(ctx, prompt)
+	}
+	return s, nil
+}
+
+// SecurityProviderOAuth2 sends an OAuth2 access token as part of an
+// Authorization: Bearer header along with a request. The token is cached
+// until it's about to expire, when it's refreshed with its refresh token,
+// if it has one, or is fetched again.
+//
+// A token is refreshed, or fetched, once for all of the requests which need
+// it at the same time, and isn't cancelled when the context of one of them
+// is, so the HTTPClient's Timeout is what limits how long it can take.
+type SecurityProviderOAuth2 struct {
+	config OAuth2Config
+	fetch  func(ctx context.Context) (*OAuth2Token, error)
+
+	mu    sync.Mutex
+	token *OAuth2Token
+	// pending is the token which is being refreshed, or fetched, when there
+	// is one
+	pending *oauth2PendingToken
+	// now is the current time, which can be replaced in tests
+	now func() time.Time
+	// pollInterval is how often the token endpoint is polled in the device
+	// code flow, when the device authorization doesn't say, which can be
+	// replaced in tests
+	pollInterval time.Duration
+}
+
+// oauth2PendingToken is a token which is being refreshed, or fetched, which
+// each of the requests that need it waits for.
+type oauth2PendingToken struct {
+	// done is closed once the token, or err, is set
+	done  chan struct{}
+	token *OAuth2Token
+	err   error
+}
+
+// Intercept will attach an Authorization header to the request, with a
+// valid access token.
+func (s *SecurityProviderOAuth2) Intercept(ctx context.Context, req *http.Request) error {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	return nil
+}
+
+// Token returns a valid token, from the cache, or by refreshing or fetching
+// one when the cached token is about to expire. It stops waiting for the
+// token when the context is done.
+func (s *SecurityProviderOAuth2) Token(ctx context.Context) (*OAuth2Token, error) {
+	s.mu.Lock()
+	if s.valid(s.token) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	pending := s.pending
+	if pending == nil {
+		pending = &oauth2PendingToken{done: make(chan struct{})}
+		s.pending = pending
+		var refreshToken string
+		if s.token != nil {
+			refreshToken = s.token.RefreshToken
+		}
+		// the token is shared with the other requests which need it, so it
+		// isn't cancelled with this one
+		go s.getToken(context.WithoutCancel(ctx), pending, refreshToken)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-pending.done:
+		return pending.token, pending.err
+	}
+}
+
+// getToken refreshes, or fetches, the pending token, and caches it.
+func (s *SecurityProviderOAuth2) getToken(ctx context.Context, pending *oauth2PendingToken, refreshToken string) {
+	var token *OAuth2Token
+	var err error
+	if refreshToken != "" {
+		token, err = s.refresh(ctx, refreshToken)
+	}
+	if token == nil {
+		var fetchErr error
+		token, fetchErr = s.fetch(ctx)
+		// when there's a refresh token, its error explains why it couldn't be
+		// used
+		if err == nil {
+			err = fetchErr
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token != nil {
+		s.token = token
+		err = nil
+	}
+	pending.token, pending.err = token, err
+	s.pending = nil
+	close(pending.done)
+}
+
+// Invalidate removes the cached access token, such as when a server has
+// rejected it, so that it's refreshed by the next request.
+func (s *SecurityProviderOAuth2) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil {
+		s.token = &OAuth2Token{RefreshToken: s.token.RefreshToken}
+	}
+}
+
+func (s *SecurityProviderOAuth2) valid(token *OAuth2Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+	if token.Expiry.IsZero() {
+		return true
+	}
+	delta := s.config.ExpiryDelta
+	if delta == 0 {
+		delta = defaultOAuth2ExpiryDelta
+	}
+	return s.currentTime().Add(delta).Before(token.Expiry)
+}
+
+func (s *SecurityProviderOAuth2) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func (s *SecurityProviderOAuth2) clientCredentials(ctx context.Context) (*OAuth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	return s.requestToken(ctx, s.config.TokenURL, form)
+}
+
+func (s *SecurityProviderOAuth2) refresh(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	tokenURL := s.config.RefreshURL
+	if tokenURL == "" {
+		tokenURL = s.config.TokenURL
+	}
+	token, err := s.requestToken(ctx, tokenURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the refresh token is kept, when the server doesn't rotate it
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func (s *SecurityProviderOAuth2) deviceCode(ctx context.Context, prompt func(ctx context.Context, authorization OAuth2DeviceAuthorization) error) (*OAuth2Token, error) {
+	form := url.Values{}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	var authorization OAuth2DeviceAuthorization
+	if err := s.post(ctx, s.config.DeviceAuthorizationURL, form, &authorization); err != nil {
+		return nil, err
+	}
+	if err := prompt(ctx, authorization); err != nil {
+		return nil, err
+	}
+
+	interval := s.pollInterval
+	if interval == 0 {
+		interval = defaultOAuth2PollInterval
+	}
+	// an interval of 0 would poll the token endpoint without waiting
+	if authorization.Interval != nil && *authorization.Interval > 0 {
+		interval = time.Duration(*authorization.Interval) * time.Second
+	}
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form = url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {authorization.DeviceCode},
+	}
+	for {
+		token, err := s.requestToken(ctx, s.config.TokenURL, form)
+		if err == nil {
+			return token, nil
+		}
+		oauth2Err, ok := err.(*OAuth2Error)
+		if !ok {
+			return nil, err
+		}
+		switch oauth2Err.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("oauth2: the device code wasn't authorized: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// requestToken posts the form to the token endpoint, and returns its token.
+func (s *SecurityProviderOAuth2) requestToken(ctx context.Context, tokenURL string, form url.Values) (*OAuth2Token, error) {
+	var token OAuth2Token
+	if err := s.post(ctx, tokenURL, form, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2: the token endpoint didn't respond with an access token")
+	}
+	if token.ExpiresIn > 0 {
+		token.Expiry = s.currentTime().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return &token, nil
+}
+
+// post posts the form, with the client's credentials, to an endpoint, and
+// decodes its JSON response into result, or returns its error.
+func (s *SecurityProviderOAuth2) post(ctx context.Context, endpoint string, form url.Values, result interface{}) error {
+	if s.config.ClientSecret == "" {
+		form.Set("client_id", s.config.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+	}
+
+	client := s.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rsp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("oauth2: %w", err)
+	}
+	defer func() { _ = rsp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(rsp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("oauth2: %w", err)
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		oauth2Err := &OAuth2Error{StatusCode: rsp.StatusCode}
+		if err := json.Unmarshal(body, oauth2Err); err != nil || oauth2Err.Code == "" {
+			return fmt.Errorf("oauth2: %s responded with %s", endpoint, rsp.Status)
+		}
+		return oauth2Err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type")); mediaType != "" && mediaType != "application/json" {
+		return fmt.Errorf("oauth2: %s responded with the content type %s, rather than JSON", endpoint, mediaType)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("oauth2: can't decode the response of %s: %w", endpoint, err)
+	}
+	return nil
+}
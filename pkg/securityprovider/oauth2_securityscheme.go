@@ -0,0 +1,130 @@
+package securityprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The flows of an `oauth2` security scheme, which NewOAuth2ConfigFromSpec
+// reads the token URL and scopes of.
+const (
+	OAuth2FlowClientCredentials = "clientCredentials"
+	OAuth2FlowAuthorizationCode = "authorizationCode"
+	OAuth2FlowPassword          = "password"
+)
+
+// NewOAuth2ConfigFromSpec returns an OAuth2Config with the endpoints and
+// scopes of the named security scheme of the spec, to which the client's
+// credentials can be added.
+//
+// For an `oauth2` security scheme, these are the `tokenUrl`, `refreshUrl`
+// and `scopes` of the flow, such as OAuth2FlowClientCredentials. For an
+// `openIdConnect` security scheme, the endpoints are read from its
+// `openIdConnectUrl` discovery document, with httpClient (or
+// http.DefaultClient when it's nil), and the scope is `openid`, so flow
+// isn't needed.
+func NewOAuth2ConfigFromSpec(ctx context.Context, spec *openapi3.T, schemeName string, flow string, httpClient *http.Client) (*OAuth2Config, error) {
+	if spec == nil || spec.Components == nil || spec.Components.SecuritySchemes[schemeName] == nil || spec.Components.SecuritySchemes[schemeName].Value == nil {
+		return nil, fmt.Errorf("the spec has no security scheme %q", schemeName)
+	}
+	scheme := spec.Components.SecuritySchemes[schemeName].Value
+
+	switch scheme.Type {
+	case "oauth2":
+		oauthFlow, err := securitySchemeOAuth2Flow(scheme, flow)
+		if err != nil {
+			return nil, fmt.Errorf("the security scheme %q %w", schemeName, err)
+		}
+		scopes := make([]string, 0, len(oauthFlow.Scopes))
+		for scope := range oauthFlow.Scopes {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		return &OAuth2Config{
+			TokenURL:   oauthFlow.TokenURL,
+			RefreshURL: oauthFlow.RefreshURL,
+			Scopes:     scopes,
+			HTTPClient: httpClient,
+		}, nil
+	case "openIdConnect":
+		discovery, err := discoverOpenIDConnect(ctx, scheme.OpenIdConnectUrl, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("can't discover the endpoints of the security scheme %q: %w", schemeName, err)
+		}
+		return &OAuth2Config{
+			TokenURL:               discovery.TokenEndpoint,
+			DeviceAuthorizationURL: discovery.DeviceAuthorizationEndpoint,
+			Scopes:                 []string{"openid"},
+			HTTPClient:             httpClient,
+		}, nil
+	default:
+		return nil, fmt.Errorf("the security scheme %q is of the type %q, rather than oauth2 or openIdConnect", schemeName, scheme.Type)
+	}
+}
+
+func securitySchemeOAuth2Flow(scheme *openapi3.SecurityScheme, flow string) (*openapi3.OAuthFlow, error) {
+	var oauthFlow *openapi3.OAuthFlow
+	if scheme.Flows != nil {
+		switch flow {
+		case OAuth2FlowClientCredentials:
+			oauthFlow = scheme.Flows.ClientCredentials
+		case OAuth2FlowAuthorizationCode:
+			oauthFlow = scheme.Flows.AuthorizationCode
+		case OAuth2FlowPassword:
+			oauthFlow = scheme.Flows.Password
+		default:
+			return nil, fmt.Errorf("can't use the %q flow, which has no token URL", flow)
+		}
+	}
+	if oauthFlow == nil {
+		return nil, fmt.Errorf("has no %q flow", flow)
+	}
+	if oauthFlow.TokenURL == "" {
+		return nil, fmt.Errorf("has no token URL for the %q flow", flow)
+	}
+	return oauthFlow, nil
+}
+
+// openIDConnectDiscovery is the part of an OpenID Connect discovery document
+// with the endpoints that the providers use.
+type openIDConnectDiscovery struct {
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
+func discoverOpenIDConnect(ctx context.Context, discoveryURL string, httpClient *http.Client) (*openIDConnectDiscovery, error) {
+	if discoveryURL == "" {
+		return nil, fmt.Errorf("it has no openIdConnectUrl")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rsp.Body.Close() }()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with %s", discoveryURL, rsp.Status)
+	}
+
+	var discovery openIDConnectDiscovery
+	if err := json.NewDecoder(rsp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("can't decode the discovery document: %w", err)
+	}
+	if discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("the discovery document has no token_endpoint")
+	}
+	return &discovery, nil
+}
@@ -0,0 +1,337 @@
+package securityprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer is a fake OAuth2 authorization server.
+type tokenServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []http.Request
+	forms    []map[string]string
+	// pending is how many more times the device code is pending
+	pending int
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	t.Helper()
+	s := &tokenServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		form := map[string]string{}
+		for name := range r.PostForm {
+			form[name] = r.PostForm.Get(name)
+		}
+
+		s.mu.Lock()
+		n := len(s.requests)
+		s.requests = append(s.requests, *r)
+		s.forms = append(s.forms, form)
+		pending := s.pending > 0
+		if pending {
+			s.pending--
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case form["grant_type"] == "refresh_token" && form["refresh_token"] == "revoked":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"the refresh token was revoked"}`))
+		case form["grant_type"] == "urn:ietf:params:oauth:grant-type:device_code" && pending:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  fmt.Sprintf("access-%d", n),
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+				"refresh_token": fmt.Sprintf("refresh-%d", n),
+			})
+		}
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-EFGH","verification_uri":"https://example.com/device","expires_in":60,"interval":0}`))
+	})
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        s.URL,
+			"token_endpoint":                s.URL + "/token",
+			"device_authorization_endpoint": s.URL + "/device",
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tokenServer) form(i int) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.forms[i]
+}
+
+func (s *tokenServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func authorization(t *testing.T, provider *SecurityProviderOAuth2) string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	require.NoError(t, err)
+	require.NoError(t, provider.Intercept(context.Background(), req))
+	return req.Header.Get("Authorization")
+}
+
+func TestSecurityProviderOAuth2ClientCredentials(t *testing.T) {
+	server := newTokenServer(t)
+	provider, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL + "/token",
+		Scopes:       []string{"pets:read", "pets:write"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+	// the token is cached
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+	assert.Equal(t, 1, server.count())
+
+	assert.Equal(t, map[string]string{"grant_type": "client_credentials", "scope": "pets:read pets:write"}, server.form(0))
+	username, password, ok := server.requests[0].BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "client", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestSecurityProviderOAuth2RefreshesExpiringTokens(t *testing.T) {
+	server := newTokenServer(t)
+	provider, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     server.URL + "/token",
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+
+	// the token expires in an hour, so is refreshed just before then
+	now = now.Add(time.Hour - 5*time.Second)
+	assert.Equal(t, "Bearer access-1", authorization(t, provider))
+	assert.Equal(t, map[string]string{"grant_type": "refresh_token", "refresh_token": "refresh-0"}, server.form(1))
+}
+
+func TestSecurityProviderOAuth2FetchesATokenWhenTheRefreshTokenIsRevoked(t *testing.T) {
+	server := newTokenServer(t)
+	provider, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Config{
+		ClientID: "client",
+		TokenURL: server.URL + "/token",
+	})
+	require.NoError(t, err)
+	provider.token = &OAuth2Token{RefreshToken: "revoked"}
+
+	assert.Equal(t, "Bearer access-1", authorization(t, provider))
+	// a public client sends its ID in the form
+	assert.Equal(t, map[string]string{"grant_type": "client_credentials", "client_id": "client"}, server.form(1))
+}
+
+func TestSecurityProviderOAuth2RefreshToken(t *testing.T) {
+	server := newTokenServer(t)
+	provider, err := NewSecurityProviderOAuth2RefreshToken(OAuth2Config{
+		ClientID: "client",
+		TokenURL: server.URL + "/token",
+	}, "refresh")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+	assert.Equal(t, map[string]string{"grant_type": "refresh_token", "refresh_token": "refresh", "client_id": "client"}, server.form(0))
+
+	// a rejected access token is refreshed with the rotated refresh token
+	provider.Invalidate()
+	assert.Equal(t, "Bearer access-1", authorization(t, provider))
+	assert.Equal(t, "refresh-0", server.form(1)["refresh_token"])
+}
+
+func TestSecurityProviderOAuth2RefreshTokenErrors(t *testing.T) {
+	server := newTokenServer(t)
+	provider, err := NewSecurityProviderOAuth2RefreshToken(OAuth2Config{
+		TokenURL: server.URL + "/token",
+	}, "revoked")
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	require.NoError(t, err)
+	err = provider.Intercept(context.Background(), req)
+
+	var oauth2Err *OAuth2Error
+	require.ErrorAs(t, err, &oauth2Err)
+	assert.Equal(t, "invalid_grant", oauth2Err.Code)
+	assert.Equal(t, http.StatusBadRequest, oauth2Err.StatusCode)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestSecurityProviderOAuth2DeviceCode(t *testing.T) {
+	server := newTokenServer(t)
+	server.pending = 2
+
+	var prompted OAuth2DeviceAuthorization
+	provider, err := NewSecurityProviderOAuth2DeviceCode(OAuth2Config{
+		ClientID:               "client",
+		TokenURL:               server.URL + "/token",
+		DeviceAuthorizationURL: server.URL + "/device",
+	}, func(ctx context.Context, authorization OAuth2DeviceAuthorization) error {
+		prompted = authorization
+		return nil
+	})
+	require.NoError(t, err)
+	provider.pollInterval = 50 * time.Millisecond
+
+	start := time.Now()
+	assert.Equal(t, "Bearer access-2", authorization(t, provider))
+	// the server's interval of 0 doesn't poll without waiting
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, "ABCD-EFGH", prompted.UserCode)
+	assert.Equal(t, "https://example.com/device", prompted.VerificationURI)
+	assert.Equal(t, 3, server.count())
+	assert.Equal(t, map[string]string{"grant_type": "urn:ietf:params:oauth:grant-type:device_code", "device_code": "device", "client_id": "client"}, server.form(2))
+}
+
+func TestSecurityProviderOAuth2SharesTheTokenBeingFetched(t *testing.T) {
+	server := newTokenServer(t)
+
+	prompted := make(chan struct{})
+	authorized := make(chan struct{})
+	provider, err := NewSecurityProviderOAuth2DeviceCode(OAuth2Config{
+		ClientID:               "client",
+		TokenURL:               server.URL + "/token",
+		DeviceAuthorizationURL: server.URL + "/device",
+	}, func(ctx context.Context, authorization OAuth2DeviceAuthorization) error {
+		close(prompted)
+		<-authorized
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the first request is cancelled while the user is being prompted
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := provider.Token(ctx)
+		first <- err
+	}()
+	<-prompted
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	// the other requests give up when their own context is done, and the
+	// token can be invalidated, without waiting for the user
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = provider.Token(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	provider.Invalidate()
+
+	// the token which was being fetched is shared with the next request
+	close(authorized)
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+	assert.Equal(t, 1, server.count())
+}
+
+func TestSecurityProviderOAuth2NeedsURLs(t *testing.T) {
+	_, err := NewSecurityProviderOAuth2ClientCredentials(OAuth2Config{})
+	assert.ErrorIs(t, err, ErrSecurityProviderOAuth2NoTokenURL)
+
+	_, err = NewSecurityProviderOAuth2RefreshToken(OAuth2Config{TokenURL: "https://example.com/token"}, "")
+	assert.ErrorIs(t, err, ErrSecurityProviderOAuth2NoRefreshToken)
+
+	_, err = NewSecurityProviderOAuth2DeviceCode(OAuth2Config{TokenURL: "https://example.com/token"}, nil)
+	assert.ErrorIs(t, err, ErrSecurityProviderOAuth2NoDeviceAuthorizationURL)
+}
+
+const oauth2Spec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: OAuth2
+paths: {}
+components:
+  securitySchemes:
+    petsAuth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: %[1]s/token
+          scopes:
+            pets:write: Change pets
+            pets:read: Read pets
+        authorizationCode:
+          authorizationUrl: %[1]s/authorize
+          tokenUrl: %[1]s/token
+          refreshUrl: %[1]s/refresh
+          scopes: {}
+    openID:
+      type: openIdConnect
+      openIdConnectUrl: %[1]s/.well-known/openid-configuration
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+
+func TestNewOAuth2ConfigFromSpec(t *testing.T) {
+	server := newTokenServer(t)
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(fmt.Sprintf(oauth2Spec, server.URL)))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	config, err := NewOAuth2ConfigFromSpec(ctx, spec, "petsAuth", OAuth2FlowClientCredentials, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &OAuth2Config{
+		TokenURL: server.URL + "/token",
+		Scopes:   []string{"pets:read", "pets:write"},
+	}, config)
+
+	config, err = NewOAuth2ConfigFromSpec(ctx, spec, "petsAuth", OAuth2FlowAuthorizationCode, nil)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/refresh", config.RefreshURL)
+
+	config, err = NewOAuth2ConfigFromSpec(ctx, spec, "openID", "", server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/token", config.TokenURL)
+	assert.Equal(t, server.URL+"/device", config.DeviceAuthorizationURL)
+	assert.Equal(t, []string{"openid"}, config.Scopes)
+
+	// the config can be used by a provider, once it has the client's credentials
+	config.ClientID = "client"
+	provider, err := NewSecurityProviderOAuth2ClientCredentials(*config)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer access-0", authorization(t, provider))
+
+	_, err = NewOAuth2ConfigFromSpec(ctx, spec, "petsAuth", OAuth2FlowPassword, nil)
+	assert.ErrorContains(t, err, `has no "password" flow`)
+	_, err = NewOAuth2ConfigFromSpec(ctx, spec, "apiKey", OAuth2FlowClientCredentials, nil)
+	assert.ErrorContains(t, err, `is of the type "apiKey"`)
+	_, err = NewOAuth2ConfigFromSpec(ctx, spec, "missing", OAuth2FlowClientCredentials, nil)
+	assert.ErrorContains(t, err, `no security scheme "missing"`)
+}
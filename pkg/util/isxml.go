@@ -0,0 +1,14 @@
+package util
+
+import (
+	"mime"
+	"strings"
+)
+
+func IsMediaTypeXml(mediaType string) bool {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return parsed == "application/xml" || parsed == "text/xml" || strings.HasSuffix(parsed, "+xml")
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestIsMediaTypeXml(t *testing.T) {
+	type test struct {
+		name      string
+		mediaType string
+		want      bool
+	}
+
+	suite := []test{
+		{
+			name: "When no MediaType, returns false",
+			want: false,
+		},
+		{
+			name:      "When not an XML MediaType, returns false",
+			mediaType: "application/json",
+			want:      false,
+		},
+		{
+			name:      "When MediaType ends with xml, but isn't XML, returns false",
+			mediaType: "application/notxml",
+			want:      false,
+		},
+		{
+			name:      "When MediaType is application/xml, returns true",
+			mediaType: "application/xml",
+			want:      true,
+		},
+		{
+			name:      "When MediaType is text/xml, returns true",
+			mediaType: "text/xml",
+			want:      true,
+		},
+		{
+			name:      "When MediaType is application/problem+xml, returns true",
+			mediaType: "application/problem+xml",
+			want:      true,
+		},
+		{
+			name:      "When MediaType is application/xml;charset=utf-8, returns true",
+			mediaType: "application/xml;charset=utf-8",
+			want:      true,
+		},
+	}
+	for _, test := range suite {
+		t.Run(test.name, func(t *testing.T) {
+			got := IsMediaTypeXml(test.mediaType)
+			if got != test.want {
+				t.Fatalf("IsXml validation failed. Want [%v] Got [%v]", test.want, got)
+			}
+		})
+	}
+}
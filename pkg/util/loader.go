@@ -3,9 +3,11 @@ package util
 import (
 	"bytes"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/speakeasy-api/openapi-overlay/pkg/loader"
@@ -13,9 +15,15 @@ import (
 )
 
 func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
+	return loadSwagger(filePath, nil)
+}
 
+// loadSwagger loads the spec, whose files and URLs are read by readFromURI, or
+// the loader's default when it's nil.
+func loadSwagger(filePath string, readFromURI openapi3.ReadFromURIFunc) (swagger *openapi3.T, err error) {
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = readFromURI
 
 	u, err := url.Parse(filePath)
 	if err == nil && u.Scheme != "" && u.Host != "" {
@@ -35,10 +43,61 @@ func LoadSwaggerWithCircularReferenceCount(filePath string, _ int) (swagger *ope
 type LoadSwaggerWithOverlayOpts struct {
 	Path   string
 	Strict bool
+	// ReadFromURIFunc reads the spec, and the files and URLs that it refers
+	// to, such as one returned by NewSharedReadFromURI. When it's nil, the
+	// loader's default is used.
+	ReadFromURIFunc openapi3.ReadFromURIFunc
+}
+
+// sharedRead is the result of reading a file or URL, which is read once.
+type sharedRead struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// NewSharedReadFromURI returns a ReadFromURIFunc which reads each file or URL
+// only once, however many loaders, in however many goroutines, refer to it,
+// so that specs which refer to the same external files can be loaded
+// concurrently without reading them again.
+//
+// Relative paths are resolved from the working directory, so that they're
+// the same file, whichever spec refers to them.
+func NewSharedReadFromURI() openapi3.ReadFromURIFunc {
+	return newSharedReadFromURI(openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile))
+}
+
+// newSharedReadFromURI returns a ReadFromURIFunc which reads each file or URL
+// with the reader only once.
+func newSharedReadFromURI(reader openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	var mu sync.Mutex
+	reads := map[string]*sharedRead{}
+
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		key := location.String()
+		if location.Host == "" && (location.Scheme == "" || location.Scheme == "file") {
+			if abs, err := filepath.Abs(filepath.FromSlash(location.Path)); err == nil {
+				key = abs
+			}
+		}
+
+		mu.Lock()
+		read, ok := reads[key]
+		if !ok {
+			read = &sharedRead{}
+			reads[key] = read
+		}
+		mu.Unlock()
+
+		read.once.Do(func() {
+			read.data, read.err = reader(loader, location)
+		})
+		return read.data, read.err
+	}
 }
 
 func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi3.T, err error) {
-	spec, err := LoadSwagger(filePath)
+	spec, err := loadSwagger(filePath, opts.ReadFromURIFunc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
 	}
@@ -88,6 +147,7 @@ func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (s
 
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = opts.ReadFromURIFunc
 
 	swagger, err = loader.LoadFromDataWithPath(b, &url.URL{
 		Path: filepath.ToSlash(filePath),
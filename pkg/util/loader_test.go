@@ -0,0 +1,76 @@
+package util
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sharedReadSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pets
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: './common.yaml#/components/schemas/Pet'
+`
+
+func TestNewSharedReadFromURI(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	writeFile("common.yaml", `
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`)
+	specs := []string{
+		writeFile("pets.yaml", sharedReadSpec),
+		writeFile("stores.yaml", sharedReadSpec),
+	}
+
+	var mu sync.Mutex
+	reads := map[string]int{}
+	readFromURI := newSharedReadFromURI(func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		mu.Lock()
+		reads[filepath.Base(location.Path)]++
+		mu.Unlock()
+		return openapi3.ReadFromFile(loader, location)
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2*len(specs))
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var spec *openapi3.T
+			spec, errs[i] = LoadSwaggerWithOverlay(specs[i%len(specs)], LoadSwaggerWithOverlayOpts{ReadFromURIFunc: readFromURI})
+			if errs[i] == nil && spec.Components.Schemas["Pet"].Value == nil {
+				errs[i] = assert.AnError
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"common.yaml": 1, "pets.yaml": 1, "stores.yaml": 1}, reads)
+}